@@ -2,22 +2,29 @@
 package accounts
 
 import (
+	gocrypto "crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
-	"crypto/rand"
 	"math/big"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/crypto"
 )
 
-// Account represents an ECDSA private-public keypair.
-// Only an account's x and y curve values are stored persistently.
-// ecdsa.PrivateKey and ecdsa.PublicKey references can be obtained at runtime via .PrivateKey() and .PublicKey().
+// Account represents a private-public keypair of any signature scheme (P-521 by default).
+// Only an ECDSA account's x, y and d curve values (or an Ed25519 account's seed) are stored persistently.
+// ecdsa.PrivateKey and ecdsa.PublicKey references can be obtained at runtime via .PrivateKey() and .PublicKey(), and a private key
+// of any scheme via .Signer().
 type Account struct {
-	X *big.Int `json:"x"` // X value
-	Y *big.Int `json:"y"` // Y value
-	D *big.Int `json:"d"` // D value
+	Scheme crypto.SignatureScheme `json:"scheme"` // Signature scheme
+
+	X *big.Int `json:"x,omitempty"` // X value (ECDSA schemes only)
+	Y *big.Int `json:"y,omitempty"` // Y value (ECDSA schemes only)
+	D *big.Int `json:"d,omitempty"` // D value (ECDSA schemes only)
+
+	Seed []byte `json:"seed,omitempty"` // Private key seed (Ed25519 only)
 }
 
 /* BEGIN EXPORTED METHODS */
@@ -25,42 +32,73 @@ type Account struct {
 // NewAccount generates a new ECDSA private-public keypair, returns the initialized account.
 // Does not write the new account to persistent memory on creation.
 func NewAccount() (*Account, error) {
-	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
-	if err != nil {                                                    // Check for errors
+	return NewAccountWithScheme(crypto.P521) // Generate P-521 account
+}
+
+// NewAccountWithScheme generates a new private-public keypair of a given signature scheme, returns the initialized account.
+// Does not write the new account to persistent memory on creation.
+func NewAccountWithScheme(scheme crypto.SignatureScheme) (*Account, error) {
+	privateKey, err := crypto.GenerateKey(scheme) // Generate private key
+	if err != nil {                               // Check for errors
 		return &Account{}, err // Return found error
 	}
 
-	return &Account{
-		privateKey.X, // Set X
-		privateKey.Y, // Set Y
-		privateKey.D, // Set D
-	}, nil // Return initialized account
+	return AccountFromSigner(privateKey) // Return initialized account
 }
 
-// AccountFromKey initializes a new account instance from a given ECDSA private key.
+// AccountFromKey initializes a new account instance from a given ECDSA (P-521 or secp256k1) private key.
 func AccountFromKey(privateKey *ecdsa.PrivateKey) *Account {
+	scheme := crypto.P521 // Init scheme
+
+	if privateKey.Curve == btcec.S256() { // Check is secp256k1
+		scheme = crypto.Secp256k1 // Set scheme
+	}
+
 	return &Account{
-		X: privateKey.X, // Set X
-		Y: privateKey.Y, // Set Y
-		D: privateKey.D, // Set D
+		Scheme: scheme,       // Set scheme
+		X:      privateKey.X, // Set X
+		Y:      privateKey.Y, // Set Y
+		D:      privateKey.D, // Set D
 	} // Return initialized account
 }
 
+// AccountFromSigner initializes a new account instance from a given private key of any supported signature scheme.
+// Returns a crypto.ErrUnsupportedKey error if the key is neither an ECDSA nor an Ed25519 private key.
+func AccountFromSigner(privateKey gocrypto.Signer) (*Account, error) {
+	switch key := privateKey.(type) { // Handle key types
+	case *ecdsa.PrivateKey:
+		return AccountFromKey(key), nil // Return initialized account
+	case ed25519.PrivateKey:
+		return &Account{
+			Scheme: crypto.Ed25519,                  // Set scheme
+			Seed:   append([]byte{}, key.Seed()...), // Set seed
+		}, nil // Return initialized account
+	default:
+		return &Account{}, crypto.ErrUnsupportedKey // Return error
+	}
+}
+
 // Address attempts to derive an address from the given account.
 func (account *Account) Address() *common.Address {
+	if account.Scheme == crypto.Ed25519 { // Check is Ed25519
+		address, _ := crypto.AddressFromSigner(account.Signer()) // Derive address
+
+		return address // Return address value
+	}
+
 	return crypto.AddressFromPublicKey(account.PublicKey()) // Return address value
 }
 
-// PublicKey derives an ECDSA public key from the given account.
+// PublicKey derives an ECDSA public key from the given (ECDSA) account.
 func (account *Account) PublicKey() *ecdsa.PublicKey {
 	return &ecdsa.PublicKey{
-		Curve: elliptic.P521(), // Set curve
+		Curve: account.curve(), // Set curve
 		X:     account.X,       // Set X
 		Y:     account.Y,       // Set Y
 	} // Return public key
 }
 
-// PrivateKey derives an ECDSA private key from the given account.
+// PrivateKey derives an ECDSA private key from the given (ECDSA) account.
 func (account *Account) PrivateKey() *ecdsa.PrivateKey {
 	return &ecdsa.PrivateKey{
 		PublicKey: *account.PublicKey(), // Set public key
@@ -68,4 +106,26 @@ func (account *Account) PrivateKey() *ecdsa.PrivateKey {
 	} // Return private key
 }
 
+// Signer derives a private key of the account's signature scheme from the given account.
+func (account *Account) Signer() gocrypto.Signer {
+	if account.Scheme == crypto.Ed25519 { // Check is Ed25519
+		return ed25519.NewKeyFromSeed(account.Seed) // Return private key
+	}
+
+	return account.PrivateKey() // Return private key
+}
+
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// curve gets the elliptic curve of the given (ECDSA) account.
+func (account *Account) curve() elliptic.Curve {
+	if account.Scheme == crypto.Secp256k1 { // Check is secp256k1
+		return btcec.S256() // Return secp256k1 curve
+	}
+
+	return elliptic.P521() // Return P-521 curve
+}
+
+/* END INTERNAL METHODS */
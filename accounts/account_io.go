@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/polaris-project/go-polaris/common"
-	"github.com/polaris-project/go-polaris/crypto"
 )
 
 /* BEGIN EXPORTED METHODS */
@@ -25,6 +24,10 @@ func GetAllAccounts() []common.Address {
 	}
 
 	for _, file := range files { // Iterate through files
+		if !strings.HasPrefix(file.Name(), "account_") { // Check not account (e.g. multi-signature account)
+			continue // Continue
+		}
+
 		addressBytes, _ := hex.DecodeString(strings.Split(strings.Split(file.Name(), "account_")[1], ".json")[0]) // Decode hex addr
 
 		buffer = append(buffer, *common.NewAddress(addressBytes)) // Append address
@@ -47,14 +50,15 @@ func (account *Account) Bytes() []byte {
 	return marshaledVal // Return JSON
 }
 
-// WriteToMemory writes the given account's contents to persistent memory
+// WriteToMemory writes the given account's contents to persistent memory in plaintext (readable only by the current user).
+// Prefer WriteEncryptedToMemory on machines shared with other users.
 func (account *Account) WriteToMemory() error {
 	err := common.CreateDirIfDoesNotExist(common.KeystoreDir) // Create keystore dir if necessary
 	if err != nil {                                           // Check for errors
 		return err // Return found error
 	}
 
-	err = ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/account_%s.json", common.KeystoreDir, hex.EncodeToString(crypto.AddressFromPublicKey(account.PublicKey()).Bytes()))), account.Bytes(), 0o644) // Write account to persistent memory
+	err = writePrivateFile(accountPath(account.Address()), account.Bytes()) // Write account to persistent memory
 
 	if err != nil { // Check for errors
 		return err // Return error
@@ -64,12 +68,21 @@ func (account *Account) WriteToMemory() error {
 }
 
 // ReadAccountFromMemory reads an account with a given address from persistent memory.
+// Returns an ErrAccountLocked error if the account is encrypted, and has not been unlocked via Unlock.
 func ReadAccountFromMemory(address *common.Address) (*Account, error) {
-	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/account_%s.json", common.KeystoreDir, hex.EncodeToString(address.Bytes())))) // Read account
-	if err != nil {                                                                                                                              // Check for errors
+	data, err := ioutil.ReadFile(accountPath(address)) // Read account
+	if err != nil {                                    // Check for errors
 		return &Account{}, err // Return found error
 	}
 
+	if _, err = decodeEncryptedAccount(data); err != ErrAccountNotEncrypted { // Check encrypted
+		if err != nil { // Check for errors
+			return &Account{}, err // Return found error
+		}
+
+		return unlockedAccountFromMemory(address) // Return unlocked account
+	}
+
 	buffer := &Account{} // Initialize buffer
 
 	err = json.Unmarshal(data, buffer) // Deserialize JSON into buffer.
@@ -82,3 +95,12 @@ func ReadAccountFromMemory(address *common.Address) (*Account, error) {
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// accountPath gets the keystore path of the account with a given address.
+func accountPath(address *common.Address) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/account_%s.json", common.KeystoreDir, hex.EncodeToString(address.Bytes()))) // Return path
+}
+
+/* END INTERNAL METHODS */
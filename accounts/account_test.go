@@ -5,7 +5,10 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/json"
 	"testing"
+
+	"github.com/polaris-project/go-polaris/crypto"
 )
 
 /* BEGIN EXPORTED METHODS TESTS */
@@ -32,4 +35,29 @@ func TestAccountFromKey(t *testing.T) {
 	}
 }
 
+// TestNewAccountWithScheme tests the functionality of the NewAccountWithScheme() helper method.
+func TestNewAccountWithScheme(t *testing.T) {
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Ed25519, crypto.Secp256k1} { // Iterate through schemes
+		account, err := NewAccountWithScheme(scheme) // Initialize new account
+		if err != nil {                              // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		readAccount := &Account{} // Init account buffer
+
+		if err = json.Unmarshal(account.Bytes(), readAccount); err != nil { // Unmarshal account
+			t.Fatal(err) // Panic
+		}
+
+		address, err := crypto.AddressFromSigner(readAccount.Signer()) // Derive address of account key
+		if err != nil {                                                // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if readAccount.Scheme != scheme || *readAccount.Address() != *address || *account.Address() != *address { // Check invalid account
+			t.Fatalf("%s account should keep its scheme and key", scheme) // Panic
+		}
+	}
+}
+
 /* END EXPORTED METHODS TESTS */
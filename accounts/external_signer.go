@@ -0,0 +1,360 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"bufio"
+	gocrypto "crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+const (
+	// ExternalSignerAddresses is the method of an external signer request for the addresses the signer holds keys for.
+	ExternalSignerAddresses = "addresses"
+
+	// ExternalSignerPublicKey is the method of an external signer request for the public key of an address.
+	ExternalSignerPublicKey = "public_key"
+
+	// ExternalSignerSign is the method of an external signer request for the signature of a message hash by an address.
+	ExternalSignerSign = "sign"
+)
+
+var (
+	// ErrExternalSignerClosed is an error definition representing an external signer that closed its connection.
+	ErrExternalSignerClosed = errors.New("external signer closed the connection")
+
+	// ErrExternalKeyMismatch is an error definition representing an external signer public key that does not derive the requested address.
+	ErrExternalKeyMismatch = errors.New("external signer public key does not derive the requested address")
+
+	// ErrUnknownExternalSignerMethod is an error definition representing an external signer request with an unknown method.
+	ErrUnknownExternalSignerMethod = errors.New("unknown external signer method")
+)
+
+// ExternalSignerRequest is a request sent to an external signer.
+// Requests and responses are exchanged as JSON objects, one per line.
+type ExternalSignerRequest struct {
+	Method string `json:"method"` // Method (addresses, public_key or sign)
+
+	Address string `json:"address,omitempty"` // Hex encoded address (public_key and sign only)
+
+	Hash string `json:"hash,omitempty"` // Hex encoded message hash (sign only)
+}
+
+// ExternalSignerResponse is the response of an external signer to a request.
+type ExternalSignerResponse struct {
+	Addresses []string `json:"addresses,omitempty"` // Hex encoded addresses (addresses only)
+
+	Scheme string `json:"scheme,omitempty"` // Signature scheme name (public_key only)
+
+	PublicKey string `json:"public_key,omitempty"` // Hex encoded marshaled public key (public_key only)
+
+	Signature string `json:"signature,omitempty"` // Hex encoded signature: ASN.1 DER for ECDSA schemes, raw for Ed25519 (sign only)
+
+	Error string `json:"error,omitempty"` // Error message (if the request failed)
+}
+
+// ExternalSigner is a signer whose keys are held by a separate process, reached over its standard input and output or over a Unix
+// socket. Keys returned by an external signer never leave its process: each signature is requested from the process.
+type ExternalSigner struct {
+	conn io.ReadWriteCloser // Connection to the signer process
+
+	scanner *bufio.Scanner // Response scanner
+
+	mutex sync.Mutex // Request lock
+}
+
+// externalKey is a private key held by an external signer.
+type externalKey struct {
+	signer *ExternalSigner // Signer holding the key
+
+	address *common.Address // Address of the key
+
+	publicKey gocrypto.PublicKey // Public key
+}
+
+// processConn is a connection to a process over its standard input and output.
+type processConn struct {
+	io.WriteCloser // Standard input
+	io.Reader      // Standard output
+
+	cmd *exec.Cmd // Process
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewExternalSigner initializes a new external signer communicating over a given connection.
+func NewExternalSigner(conn io.ReadWriteCloser) *ExternalSigner {
+	return &ExternalSigner{
+		conn:    conn,                   // Set connection
+		scanner: bufio.NewScanner(conn), // Set scanner
+	} // Return initialized signer
+}
+
+// StartExternalSigner starts a given signer command, and initializes a new external signer communicating with the started process over
+// its standard input and output. The process's standard error is forwarded to that of the current process.
+func StartExternalSigner(command string, args ...string) (*ExternalSigner, error) {
+	cmd := exec.Command(command, args...) // Init command
+
+	cmd.Stderr = os.Stderr // Forward standard error
+
+	stdin, err := cmd.StdinPipe() // Get standard input
+	if err != nil {               // Check for errors
+		return nil, err // Return found error
+	}
+
+	stdout, err := cmd.StdoutPipe() // Get standard output
+	if err != nil {                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	if err = cmd.Start(); err != nil { // Start process
+		return nil, err // Return found error
+	}
+
+	return NewExternalSigner(&processConn{WriteCloser: stdin, Reader: stdout, cmd: cmd}), nil // Return initialized signer
+}
+
+// DialExternalSigner initializes a new external signer communicating with a signer process listening on a Unix socket at a given path.
+func DialExternalSigner(socketPath string) (*ExternalSigner, error) {
+	conn, err := net.Dial("unix", socketPath) // Connect to signer
+	if err != nil {                           // Check for errors
+		return nil, err // Return found error
+	}
+
+	return NewExternalSigner(conn), nil // Return initialized signer
+}
+
+// Addresses gets the addresses the external signer holds keys for.
+func (signer *ExternalSigner) Addresses() ([]common.Address, error) {
+	response, err := signer.request(&ExternalSignerRequest{Method: ExternalSignerAddresses}) // Request addresses
+	if err != nil {                                                                          // Check for errors
+		return nil, err // Return found error
+	}
+
+	addresses := []common.Address{} // Init buffer
+
+	for _, address := range response.Addresses { // Iterate through addresses
+		addressBytes, err := hex.DecodeString(address) // Decode address
+		if err != nil {                                // Check for errors
+			return nil, err // Return found error
+		}
+
+		addresses = append(addresses, *common.NewAddress(addressBytes)) // Append address
+	}
+
+	return addresses, nil // Return addresses
+}
+
+// Key gets a private key delegating signatures by a given address to the external signer.
+// Returns an ErrExternalKeyMismatch error if the public key reported by the signer does not derive the address.
+func (signer *ExternalSigner) Key(address *common.Address) (gocrypto.Signer, error) {
+	response, err := signer.request(&ExternalSignerRequest{Method: ExternalSignerPublicKey, Address: hex.EncodeToString(address.Bytes())}) // Request public key
+	if err != nil {                                                                                                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	scheme, err := crypto.SignatureSchemeFromString(response.Scheme) // Get scheme
+	if err != nil {                                                  // Check for errors
+		return nil, err // Return found error
+	}
+
+	algorithm, _ := crypto.GetSignatureAlgorithm(scheme) // Get algorithm
+
+	marshaledPublicKey, err := hex.DecodeString(response.PublicKey) // Decode public key
+	if err != nil {                                                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	if keyAddress, err := algorithm.Address(marshaledPublicKey); err != nil || *keyAddress != *address { // Check public key does not derive address
+		return nil, ErrExternalKeyMismatch // Return error
+	}
+
+	publicKey, err := algorithm.UnmarshalPublicKey(marshaledPublicKey) // Unmarshal public key
+	if err != nil {                                                    // Check for errors
+		return nil, err // Return found error
+	}
+
+	return &externalKey{
+		signer:    signer,    // Set signer
+		address:   address,   // Set address
+		publicKey: publicKey, // Set public key
+	}, nil // Return key
+}
+
+// Close closes the connection to the external signer (stopping the signer process, if it was started by StartExternalSigner).
+func (signer *ExternalSigner) Close() error {
+	return signer.conn.Close() // Close connection
+}
+
+// ServeExternalSigner serves the external signer protocol over a given connection, answering requests with the keys held by a given
+// signer until the connection is closed.
+func ServeExternalSigner(signer Signer, conn io.ReadWriter) error {
+	scanner := bufio.NewScanner(conn) // Init request scanner
+	encoder := json.NewEncoder(conn)  // Init response encoder
+
+	for scanner.Scan() { // Read requests
+		var response *ExternalSignerResponse // Init response buffer
+
+		request := &ExternalSignerRequest{} // Init request buffer
+
+		err := json.Unmarshal(scanner.Bytes(), request) // Decode request
+		if err == nil {                                 // Check valid request
+			response, err = handleExternalSignerRequest(signer, request) // Handle request
+		}
+
+		if err != nil { // Check for errors
+			response = &ExternalSignerResponse{Error: err.Error()} // Set error
+		}
+
+		if err = encoder.Encode(response); err != nil { // Write response
+			return err // Return found error
+		}
+	}
+
+	return scanner.Err() // Return read error (if any)
+}
+
+// Public gets the public key of the external key.
+func (key *externalKey) Public() gocrypto.PublicKey {
+	return key.publicKey // Return public key
+}
+
+// Sign requests the signature of a given digest by the external key. The randomness source and signer options are chosen by the signer.
+func (key *externalKey) Sign(random io.Reader, digest []byte, opts gocrypto.SignerOpts) ([]byte, error) {
+	response, err := key.signer.request(&ExternalSignerRequest{
+		Method:  ExternalSignerSign,                      // Set method
+		Address: hex.EncodeToString(key.address.Bytes()), // Set address
+		Hash:    hex.EncodeToString(digest),              // Set hash
+	}) // Request signature
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	return hex.DecodeString(response.Signature) // Return signature
+}
+
+// Close stops the process.
+func (conn *processConn) Close() error {
+	if err := conn.WriteCloser.Close(); err != nil { // Close standard input
+		return err // Return found error
+	}
+
+	return conn.cmd.Wait() // Wait for process to exit
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// request sends a given request to the external signer, and reads its response.
+func (signer *ExternalSigner) request(request *ExternalSignerRequest) (*ExternalSignerResponse, error) {
+	signer.mutex.Lock()         // Lock signer
+	defer signer.mutex.Unlock() // Unlock signer
+
+	encoded, err := json.Marshal(request) // Encode request
+	if err != nil {                       // Check for errors
+		return nil, err // Return found error
+	}
+
+	if _, err = signer.conn.Write(append(encoded, '\n')); err != nil { // Write request
+		return nil, err // Return found error
+	}
+
+	if !signer.scanner.Scan() { // Read response
+		if err = signer.scanner.Err(); err != nil { // Check for errors
+			return nil, err // Return found error
+		}
+
+		return nil, ErrExternalSignerClosed // Return error
+	}
+
+	response := &ExternalSignerResponse{} // Init response buffer
+
+	if err = json.Unmarshal(signer.scanner.Bytes(), response); err != nil { // Decode response
+		return nil, err // Return found error
+	}
+
+	if response.Error != "" { // Check request failed
+		return nil, errors.New(response.Error) // Return error
+	}
+
+	return response, nil // Return response
+}
+
+// handleExternalSignerRequest answers a given external signer request with the keys held by a given signer.
+func handleExternalSignerRequest(signer Signer, request *ExternalSignerRequest) (*ExternalSignerResponse, error) {
+	if request.Method == ExternalSignerAddresses { // Check is addresses request
+		addresses, err := signer.Addresses() // Get addresses
+		if err != nil {                      // Check for errors
+			return nil, err // Return found error
+		}
+
+		response := &ExternalSignerResponse{Addresses: []string{}} // Init response
+
+		for _, address := range addresses { // Iterate through addresses
+			response.Addresses = append(response.Addresses, hex.EncodeToString(address.Bytes())) // Append address
+		}
+
+		return response, nil // Return response
+	}
+
+	if request.Method != ExternalSignerPublicKey && request.Method != ExternalSignerSign { // Check unknown method
+		return nil, ErrUnknownExternalSignerMethod // Return error
+	}
+
+	addressBytes, err := hex.DecodeString(request.Address) // Decode address
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	privateKey, err := signer.Key(common.NewAddress(addressBytes)) // Get key
+	if err != nil {                                                // Check for errors
+		return nil, err // Return found error
+	}
+
+	scheme, err := crypto.SignatureSchemeOfKey(privateKey) // Get scheme
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	if request.Method == ExternalSignerPublicKey { // Check is public key request
+		algorithm, _ := crypto.GetSignatureAlgorithm(scheme) // Get algorithm
+
+		marshaledPublicKey, err := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+		if err != nil {                                                            // Check for errors
+			return nil, err // Return found error
+		}
+
+		return &ExternalSignerResponse{Scheme: scheme.String(), PublicKey: hex.EncodeToString(marshaledPublicKey)}, nil // Return public key
+	}
+
+	hash, err := hex.DecodeString(request.Hash) // Decode hash
+	if err != nil {                             // Check for errors
+		return nil, err // Return found error
+	}
+
+	opts := gocrypto.Hash(0) // Ed25519 signs the message hash as-is
+
+	if scheme != crypto.Ed25519 { // Check is ECDSA
+		opts = gocrypto.SHA3_256 // ECDSA signs the SHA3-256 message hash
+	}
+
+	signature, err := privateKey.Sign(rand.Reader, hash, opts) // Sign hash
+	if err != nil {                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	return &ExternalSignerResponse{Signature: hex.EncodeToString(signature)}, nil // Return signature
+}
+
+/* END INTERNAL METHODS */
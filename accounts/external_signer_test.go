@@ -0,0 +1,175 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+// standInSignerEnv is the environment variable marking the test binary as a stand-in external signer process (set to the hex encoded
+// address of the encrypted account the stand-in signer unlocks).
+const standInSignerEnv = "POLARIS_STAND_IN_SIGNER"
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestExternalSigner tests the functionality of the ExternalSigner signer over a process's standard input and output, and over a Unix
+// socket.
+func TestExternalSigner(t *testing.T) {
+	if encodedAddress := os.Getenv(standInSignerEnv); encodedAddress != "" { // Check is stand-in signer process
+		addressBytes, _ := hex.DecodeString(encodedAddress) // Decode encrypted account address
+
+		if err := Unlock(common.NewAddress(addressBytes), "passphrase", 0); err != nil { // Unlock encrypted account
+			os.Exit(1) // Panic
+		}
+
+		ServeExternalSigner(KeystoreSigner{}, struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, os.Stdout}) // Serve keystore
+
+		os.Exit(0) // Exit stand-in signer process
+	}
+
+	defer useLightScryptParams()() // Use light scrypt params
+
+	addresses := []*common.Address{} // Init address buffer
+
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Ed25519, crypto.Secp256k1} { // Iterate through schemes
+		account, err := NewAccountWithScheme(scheme) // Initialize account
+		if err != nil {                              // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if err = account.WriteToMemory(); err != nil { // Write account to persistent memory
+			t.Fatal(err) // Panic
+		}
+
+		addresses = append(addresses, account.Address()) // Append address
+	}
+
+	encryptedAccount, err := NewAccount() // Initialize encrypted account
+	if err != nil {                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = encryptedAccount.WriteEncryptedToMemory("passphrase"); err != nil { // Write encrypted account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = (KeystoreSigner{}).Key(encryptedAccount.Address()); err != ErrAccountLocked { // Get key of locked account
+		t.Fatalf("expected %v, got %v", ErrAccountLocked, err) // Panic
+	}
+
+	if err = Unlock(encryptedAccount.Address(), "passphrase", 0); err != nil { // Unlock encrypted account (for the socket signer)
+		t.Fatal(err) // Panic
+	}
+
+	defer Lock(encryptedAccount.Address()) // Lock encrypted account
+
+	addresses = append(addresses, encryptedAccount.Address()) // Append address
+
+	os.Setenv(standInSignerEnv, hex.EncodeToString(encryptedAccount.Address().Bytes())) // Mark stand-in signer process
+	defer os.Unsetenv(standInSignerEnv)                                                 // Unmark stand-in signer process
+
+	processSigner, err := StartExternalSigner(os.Args[0], "-test.run=^TestExternalSigner$") // Start stand-in signer process
+	if err != nil {                                                                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer processSigner.Close() // Stop stand-in signer process
+
+	socketDir, err := ioutil.TempDir("", "polaris-signer") // Create socket dir
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(socketDir) // Remove socket dir
+
+	listener, err := net.Listen("unix", filepath.Join(socketDir, "signer.sock")) // Listen on socket
+	if err != nil {                                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer listener.Close() // Stop listening
+
+	go func() {
+		conn, err := listener.Accept() // Accept connection
+		if err != nil {                // Check for errors
+			return // Stop serving
+		}
+
+		defer conn.Close() // Close connection
+
+		ServeExternalSigner(KeystoreSigner{}, conn) // Serve keystore
+	}() // Serve stand-in signer on socket
+
+	socketSigner, err := DialExternalSigner(filepath.Join(socketDir, "signer.sock")) // Connect to stand-in signer
+	if err != nil {                                                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer socketSigner.Close() // Close connection
+
+	for _, signer := range []*ExternalSigner{processSigner, socketSigner} { // Iterate through signers
+		signerAddresses, err := signer.Addresses() // Get addresses
+		if err != nil {                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		for _, address := range addresses { // Iterate through addresses
+			found := false // Init found
+
+			for _, signerAddress := range signerAddresses { // Iterate through signer addresses
+				if signerAddress == *address { // Check is address
+					found = true // Set found
+				}
+			}
+
+			if !found { // Check not found
+				t.Fatal("external signer should list keystore addresses") // Panic
+			}
+
+			key, err := signer.Key(address) // Get key
+			if err != nil {                 // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if keyAddress, err := crypto.AddressFromSigner(key); err != nil || *keyAddress != *address { // Check address mismatch
+				t.Fatalf("external key should derive its address (%v)", err) // Panic
+			}
+
+			scheme, _ := crypto.SignatureSchemeOfKey(key)        // Get scheme
+			algorithm, _ := crypto.GetSignatureAlgorithm(scheme) // Get algorithm
+
+			marshaledPublicKey, _ := algorithm.MarshalPublicKey(key.Public()) // Marshal public key
+
+			messageHash := crypto.Sha3([]byte("message")).Bytes() // Get message hash
+
+			r, s, err := algorithm.Sign(key, messageHash) // Sign message hash
+			if err != nil {                               // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if !algorithm.Verify(marshaledPublicKey, messageHash, r, s) { // Check invalid signature
+				t.Fatalf("external %s key should produce valid signatures", scheme) // Panic
+			}
+		}
+
+		unknownAddress := *addresses[0] // Copy address
+		unknownAddress[0]++             // Change address
+
+		if _, err = signer.Key(&unknownAddress); err == nil { // Get key of unknown address
+			t.Fatal("external signer should not return a key for an unknown address") // Panic
+		}
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -0,0 +1,493 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+	"golang.org/x/crypto/scrypt"
+)
+
+// keystoreVersion is the current version of the encrypted keystore format.
+const keystoreVersion = 1
+
+const (
+	keystoreCipher = "aes-256-gcm" // Keystore cipher
+	keystoreKDF    = "scrypt"      // Keystore key derivation function
+
+	scryptKeyLength  = 32 // Length of derived keys (AES-256)
+	scryptSaltLength = 32 // Length of key derivation salts
+
+	// maxScryptCost is the maximum cost (128 * N * r * p bytes) of the scrypt parameters of an encrypted account (that of the default
+	// parameters, 256 MiB), so that a tampered or corrupt keystore file can't make unlocking allocate memory (or run) without bound.
+	maxScryptCost = 256 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidPassphrase is an error definition representing a passphrase that does not decrypt an encrypted account.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+	// ErrAccountLocked is an error definition representing an encrypted account that has not been unlocked.
+	ErrAccountLocked = errors.New("account is locked")
+
+	// ErrAccountNotEncrypted is an error definition representing a plaintext account read as an encrypted account.
+	ErrAccountNotEncrypted = errors.New("account is not encrypted")
+
+	// ErrAccountAlreadyEncrypted is an error definition representing an encrypted account imported as a plaintext account.
+	ErrAccountAlreadyEncrypted = errors.New("account is already encrypted")
+
+	// ErrUnsupportedKeystoreVersion is an error definition representing an encrypted account written with an unknown keystore version.
+	ErrUnsupportedKeystoreVersion = errors.New("unsupported keystore version")
+
+	// ErrUnsupportedKeystoreCipher is an error definition representing an encrypted account using an unknown cipher or key derivation function.
+	ErrUnsupportedKeystoreCipher = errors.New("unsupported keystore cipher or key derivation function")
+
+	// ErrInvalidScryptParams is an error definition representing scrypt parameters that are malformed, or that exceed maxScryptCost.
+	ErrInvalidScryptParams = errors.New("invalid or excessive scrypt parameters")
+
+	// ErrEmptyPassphrase is an error definition representing an empty passphrase.
+	ErrEmptyPassphrase = errors.New("passphrase must not be empty")
+)
+
+// DefaultScryptParams are the scrypt parameters used to encrypt new accounts (the parameters of an encrypted account are stored
+// alongside it, so changing them does not affect existing accounts).
+var DefaultScryptParams = ScryptParams{N: 1 << 18, R: 8, P: 1}
+
+// EncryptedAccount is the persistent form of an account: its address and public key in plaintext, and its private key
+// encrypted with a key derived from a passphrase.
+type EncryptedAccount struct {
+	Version int `json:"version"` // Keystore version
+
+	Address string `json:"address"` // Hex encoded account address
+
+	Scheme crypto.SignatureScheme `json:"scheme"` // Signature scheme
+
+	PublicKey string `json:"public_key"` // Hex encoded marshaled public key
+
+	Crypto KeystoreCrypto `json:"crypto"` // Encrypted private key
+}
+
+// KeystoreCrypto is an encrypted account private key (the JSON encoding of the account), and the parameters needed to decrypt it.
+type KeystoreCrypto struct {
+	Cipher string `json:"cipher"` // Cipher (aes-256-gcm)
+
+	CipherText string `json:"ciphertext"` // Hex encoded ciphertext (authenticated along with the account address)
+
+	Nonce string `json:"nonce"` // Hex encoded cipher nonce
+
+	KDF string `json:"kdf"` // Key derivation function (scrypt)
+
+	KDFParams ScryptParams `json:"kdfparams"` // Key derivation function parameters
+}
+
+// ScryptParams are the parameters of the scrypt key derivation function.
+type ScryptParams struct {
+	N int `json:"n"` // CPU/memory cost
+	R int `json:"r"` // Block size
+	P int `json:"p"` // Parallelization
+
+	Salt string `json:"salt,omitempty"` // Hex encoded salt
+}
+
+// unlockedAccount is a decrypted account, and the timer locking it again.
+type unlockedAccount struct {
+	account *Account // Decrypted account
+
+	timer *time.Timer // Lock timer (nil if unlocked indefinitely)
+}
+
+var (
+	unlockedAccounts = make(map[common.Address]*unlockedAccount) // Unlocked accounts
+	unlockedLock     sync.Mutex                                  // Unlocked accounts lock
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// EncryptAccount encrypts a given account with a key derived from a given passphrase.
+func EncryptAccount(account *Account, passphrase string) (*EncryptedAccount, error) {
+	if passphrase == "" { // Check empty passphrase
+		return &EncryptedAccount{}, ErrEmptyPassphrase // Return error
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(account.Scheme) // Get signature algorithm
+	if err != nil {                                                // Check for errors
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	publicKey, err := algorithm.MarshalPublicKey(account.Signer().Public()) // Marshal public key
+	if err != nil {                                                         // Check for errors
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	address := account.Address() // Get account address
+
+	keystoreCrypto, err := sealKeystoreCrypto(account.Bytes(), address.Bytes(), passphrase) // Encrypt account
+	if err != nil {                                                                         // Check for errors
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	return &EncryptedAccount{
+		Version:   keystoreVersion,                     // Set version
+		Address:   hex.EncodeToString(address.Bytes()), // Set address
+		Scheme:    account.Scheme,                      // Set scheme
+		PublicKey: hex.EncodeToString(publicKey),       // Set public key
+		Crypto:    *keystoreCrypto,                     // Set crypto
+	}, nil // Return encrypted account
+}
+
+// Decrypt decrypts a given encrypted account with a given passphrase.
+// Returns an ErrInvalidPassphrase error if the passphrase does not decrypt the account.
+func (encryptedAccount *EncryptedAccount) Decrypt(passphrase string) (*Account, error) {
+	if encryptedAccount.Version != keystoreVersion { // Check unknown version
+		return &Account{}, ErrUnsupportedKeystoreVersion // Return error
+	}
+
+	address, err := hex.DecodeString(encryptedAccount.Address) // Decode address
+	if err != nil {                                            // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	plainText, err := encryptedAccount.Crypto.open(address, passphrase) // Decrypt account
+	if err != nil {                                                     // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	account := &Account{} // Init account buffer
+
+	if err = json.Unmarshal(plainText, account); err != nil { // Unmarshal account
+		return &Account{}, err // Return found error
+	}
+
+	return account, nil // Return decrypted account
+}
+
+// WriteEncryptedToMemory encrypts the given account with a key derived from a given passphrase, and writes the encrypted account
+// to persistent memory (readable only by the current user).
+func (account *Account) WriteEncryptedToMemory(passphrase string) error {
+	encryptedAccount, err := EncryptAccount(account, passphrase) // Encrypt account
+	if err != nil {                                              // Check for errors
+		return err // Return found error
+	}
+
+	err = common.CreateDirIfDoesNotExist(common.KeystoreDir) // Create keystore dir if necessary
+	if err != nil {                                          // Check for errors
+		return err // Return found error
+	}
+
+	marshaledVal, _ := json.MarshalIndent(*encryptedAccount, "", "  ") // Marshal JSON
+
+	return writePrivateFile(accountPath(account.Address()), marshaledVal) // Write encrypted account to persistent memory
+}
+
+// ReadEncryptedAccountFromMemory reads an encrypted account with a given address from persistent memory (without decrypting it).
+// Returns an ErrAccountNotEncrypted error if the account is stored in plaintext.
+func ReadEncryptedAccountFromMemory(address *common.Address) (*EncryptedAccount, error) {
+	data, err := ioutil.ReadFile(accountPath(address)) // Read account
+	if err != nil {                                    // Check for errors
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	return decodeEncryptedAccount(data) // Decode encrypted account
+}
+
+// ImportPlaintextAccount encrypts the plaintext account stored at a given path (e.g. an account written by WriteToMemory) with a key
+// derived from a given passphrase, and writes the encrypted account to the keystore (replacing the plaintext account, if the path
+// is that of the account in the keystore). Returns the address of the imported account.
+func ImportPlaintextAccount(path string, passphrase string) (*common.Address, error) {
+	data, err := ioutil.ReadFile(filepath.FromSlash(path)) // Read account
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	if _, err = decodeEncryptedAccount(data); err == nil { // Check already encrypted
+		return nil, ErrAccountAlreadyEncrypted // Return error
+	} else if err != ErrAccountNotEncrypted { // Check for errors
+		return nil, err // Return found error
+	}
+
+	account := &Account{} // Init account buffer
+
+	if err = json.Unmarshal(data, account); err != nil { // Unmarshal account
+		return nil, err // Return found error
+	}
+
+	if err = account.WriteEncryptedToMemory(passphrase); err != nil { // Write encrypted account
+		return nil, err // Return found error
+	}
+
+	return account.Address(), nil // Return address
+}
+
+// Unlock decrypts the encrypted account with a given address with a given passphrase, so that it can be read via
+// ReadAccountFromMemory, until it is locked again via Lock or a given timeout passes (a timeout of zero unlocks the account
+// until it is locked).
+func Unlock(address *common.Address, passphrase string, timeout time.Duration) error {
+	encryptedAccount, err := ReadEncryptedAccountFromMemory(address) // Read encrypted account
+	if err != nil {                                                  // Check for errors
+		return err // Return found error
+	}
+
+	account, err := encryptedAccount.Decrypt(passphrase) // Decrypt account
+	if err != nil {                                      // Check for errors
+		return err // Return found error
+	}
+
+	Lock(address) // Stop any existing lock timer
+
+	unlocked := &unlockedAccount{account: account} // Init unlocked account
+
+	if timeout > 0 { // Check has timeout
+		unlocked.timer = time.AfterFunc(timeout, func() { lockAccount(*address, unlocked) }) // Lock on timeout
+	}
+
+	unlockedLock.Lock() // Lock
+
+	defer unlockedLock.Unlock() // Unlock
+
+	unlockedAccounts[*address] = unlocked // Set unlocked
+
+	return nil // No error occurred, return nil
+}
+
+// Lock locks the unlocked account with a given address, if it is unlocked.
+func Lock(address *common.Address) {
+	unlockedLock.Lock() // Lock
+
+	defer unlockedLock.Unlock() // Unlock
+
+	if unlocked, ok := unlockedAccounts[*address]; ok { // Check unlocked
+		if unlocked.timer != nil { // Check has timer
+			unlocked.timer.Stop() // Stop timer
+		}
+
+		delete(unlockedAccounts, *address) // Lock
+	}
+}
+
+// IsUnlocked checks whether the account with a given address is unlocked.
+func IsUnlocked(address *common.Address) bool {
+	unlockedLock.Lock() // Lock
+
+	defer unlockedLock.Unlock() // Unlock
+
+	_, ok := unlockedAccounts[*address] // Check unlocked
+
+	return ok // Return is unlocked
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// sealKeystoreCrypto encrypts a given plaintext (authenticated along with given additional data) with a key derived from a given
+// passphrase.
+func sealKeystoreCrypto(plainText []byte, additionalData []byte, passphrase string) (*KeystoreCrypto, error) {
+	if passphrase == "" { // Check empty passphrase
+		return nil, ErrEmptyPassphrase // Return error
+	}
+
+	salt := make([]byte, scryptSaltLength) // Init salt buffer
+
+	if _, err := rand.Read(salt); err != nil { // Generate salt
+		return nil, err // Return found error
+	}
+
+	params := DefaultScryptParams // Copy default params
+
+	params.Salt = hex.EncodeToString(salt) // Set salt
+
+	aead, err := newKeystoreCipher(passphrase, params) // Initialize cipher
+	if err != nil {                                    // Check for errors
+		return nil, err // Return found error
+	}
+
+	nonce := make([]byte, aead.NonceSize()) // Init nonce buffer
+
+	if _, err = rand.Read(nonce); err != nil { // Generate nonce
+		return nil, err // Return found error
+	}
+
+	return &KeystoreCrypto{
+		Cipher:     keystoreCipher,                                                       // Set cipher
+		CipherText: hex.EncodeToString(aead.Seal(nil, nonce, plainText, additionalData)), // Set ciphertext
+		Nonce:      hex.EncodeToString(nonce),                                            // Set nonce
+		KDF:        keystoreKDF,                                                          // Set key derivation function
+		KDFParams:  params,                                                               // Set key derivation function params
+	}, nil // Return encrypted plaintext
+}
+
+// open decrypts the given encrypted plaintext (authenticated along with given additional data) with a given passphrase.
+// Returns an ErrInvalidPassphrase error if the passphrase does not decrypt the plaintext.
+func (keystoreCrypto *KeystoreCrypto) open(additionalData []byte, passphrase string) ([]byte, error) {
+	if keystoreCrypto.Cipher != keystoreCipher || keystoreCrypto.KDF != keystoreKDF { // Check unknown cipher
+		return nil, ErrUnsupportedKeystoreCipher // Return error
+	}
+
+	cipherText, err := hex.DecodeString(keystoreCrypto.CipherText) // Decode ciphertext
+	if err != nil {                                                // Check for errors
+		return nil, err // Return found error
+	}
+
+	nonce, err := hex.DecodeString(keystoreCrypto.Nonce) // Decode nonce
+	if err != nil {                                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	aead, err := newKeystoreCipher(passphrase, keystoreCrypto.KDFParams) // Initialize cipher
+	if err != nil {                                                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	if len(nonce) != aead.NonceSize() { // Check invalid nonce
+		return nil, ErrUnsupportedKeystoreCipher // Return error
+	}
+
+	plainText, err := aead.Open(nil, nonce, cipherText, additionalData) // Decrypt
+	if err != nil {                                                     // Check for errors
+		return nil, ErrInvalidPassphrase // Return error
+	}
+
+	return plainText, nil // Return plaintext
+}
+
+// newKeystoreCipher initializes an AES-256-GCM cipher with a key derived from a given passphrase via scrypt.
+// Returns an ErrInvalidScryptParams error if the scrypt parameters are not positive, or exceed maxScryptCost.
+func newKeystoreCipher(passphrase string, params ScryptParams) (cipher.AEAD, error) {
+	if !params.withinCost() { // Check invalid params
+		return nil, ErrInvalidScryptParams // Return error
+	}
+
+	salt, err := hex.DecodeString(params.Salt) // Decode salt
+	if err != nil {                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, scryptKeyLength) // Derive key
+	if err != nil {                                                                                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	block, err := aes.NewCipher(key) // Initialize block cipher
+	if err != nil {                  // Check for errors
+		return nil, err // Return found error
+	}
+
+	return cipher.NewGCM(block) // Return cipher
+}
+
+// withinCost checks that the given scrypt parameters are positive, and that their cost (128 * N * r * p bytes) doesn't exceed maxScryptCost.
+func (params ScryptParams) withinCost() bool {
+	if params.N <= 1 || params.R <= 0 || params.P <= 0 { // Check not positive
+		return false // Invalid
+	}
+
+	cost := 128 // Init cost
+
+	for _, factor := range []int{params.N, params.R, params.P} { // Iterate through cost factors
+		if factor > maxScryptCost/cost { // Check cost exceeded (without overflowing)
+			return false // Invalid
+		}
+
+		cost *= factor // Multiply cost
+	}
+
+	return true // Valid
+}
+
+// decodeEncryptedAccount decodes a given JSON encoded encrypted account.
+// Returns an ErrAccountNotEncrypted error if the data is not an encrypted account.
+func decodeEncryptedAccount(data []byte) (*EncryptedAccount, error) {
+	var probe struct {
+		Version int `json:"version"` // Keystore version
+
+		Crypto *KeystoreCrypto `json:"crypto"` // Encrypted private key
+	} // Init probe buffer
+
+	if err := json.Unmarshal(data, &probe); err != nil { // Unmarshal probe
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	if probe.Crypto == nil { // Check not encrypted
+		return &EncryptedAccount{}, ErrAccountNotEncrypted // Return error
+	}
+
+	if probe.Version != keystoreVersion { // Check unknown version
+		return &EncryptedAccount{}, ErrUnsupportedKeystoreVersion // Return error
+	}
+
+	encryptedAccount := &EncryptedAccount{} // Init encrypted account buffer
+
+	if err := json.Unmarshal(data, encryptedAccount); err != nil { // Unmarshal encrypted account
+		return &EncryptedAccount{}, err // Return found error
+	}
+
+	return encryptedAccount, nil // Return encrypted account
+}
+
+// writePrivateFile writes given data to a file at a given path readable only by the current user, replacing any existing file
+// (along with its permissions) via a temporary file in the same directory.
+func writePrivateFile(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), ".tmp-") // Create temporary file (readable only by the current user)
+	if err != nil {                                           // Check for errors
+		return err // Return found error
+	}
+
+	defer os.Remove(file.Name()) // Remove temporary file (if not renamed)
+
+	if _, err = file.Write(data); err != nil { // Write data
+		file.Close() // Close file
+
+		return err // Return found error
+	}
+
+	if err = file.Sync(); err != nil { // Flush to disk
+		file.Close() // Close file
+
+		return err // Return found error
+	}
+
+	if err = file.Close(); err != nil { // Close file
+		return err // Return found error
+	}
+
+	return os.Rename(file.Name(), path) // Replace file
+}
+
+// unlockedAccountFromMemory gets the unlocked account with a given address.
+// Returns an ErrAccountLocked error if the account is not unlocked.
+func unlockedAccountFromMemory(address *common.Address) (*Account, error) {
+	unlockedLock.Lock() // Lock
+
+	defer unlockedLock.Unlock() // Unlock
+
+	unlocked, ok := unlockedAccounts[*address] // Get unlocked account
+	if !ok {                                   // Check locked
+		return &Account{}, ErrAccountLocked // Return error
+	}
+
+	return unlocked.account, nil // Return account
+}
+
+// lockAccount locks a given unlocked account with a given address, if it has not since been locked or unlocked again.
+func lockAccount(address common.Address, unlocked *unlockedAccount) {
+	unlockedLock.Lock() // Lock
+
+	defer unlockedLock.Unlock() // Unlock
+
+	if unlockedAccounts[address] == unlocked { // Check still unlocked
+		delete(unlockedAccounts, address) // Lock
+	}
+}
+
+/* END INTERNAL METHODS */
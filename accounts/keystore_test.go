@@ -0,0 +1,193 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestEncryptAccount tests the functionality of the EncryptAccount() helper method.
+func TestEncryptAccount(t *testing.T) {
+	defer useLightScryptParams()() // Use light scrypt params
+
+	account, err := NewAccount() // Initialize new account
+	if err != nil {              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	encryptedAccount, err := EncryptAccount(account, "passphrase") // Encrypt account
+	if err != nil {                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = encryptedAccount.Decrypt("wrong passphrase"); err != ErrInvalidPassphrase { // Decrypt with wrong passphrase
+		t.Fatalf("expected %v, got %v", ErrInvalidPassphrase, err) // Panic
+	}
+
+	decryptedAccount, err := encryptedAccount.Decrypt("passphrase") // Decrypt account
+	if err != nil {                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if decryptedAccount.String() != account.String() { // Check account mismatch
+		t.Fatal("decrypted account does not match") // Panic
+	}
+
+	encryptedAccount.Address = encryptedAccount.Address[2:] + encryptedAccount.Address[:2] // Tamper with address
+
+	if _, err = encryptedAccount.Decrypt("passphrase"); err != ErrInvalidPassphrase { // Decrypt tampered account
+		t.Fatalf("expected %v, got %v", ErrInvalidPassphrase, err) // Panic
+	}
+
+	for _, params := range []ScryptParams{{N: 1 << 30, R: 8, P: 1}, {N: 1 << 12, R: 8, P: 1 << 20}, {N: 0, R: 8, P: 1}} { // Iterate through excessive and malformed params
+		tamperedAccount := *encryptedAccount // Copy account
+
+		params.Salt = tamperedAccount.Crypto.KDFParams.Salt // Keep salt
+		tamperedAccount.Crypto.KDFParams = params           // Tamper with params
+
+		if _, err = tamperedAccount.Decrypt("passphrase"); err != ErrInvalidScryptParams { // Decrypt tampered account
+			t.Fatalf("expected %v, got %v", ErrInvalidScryptParams, err) // Panic
+		}
+	}
+}
+
+// TestUnlock tests the functionality of the Unlock() helper method.
+func TestUnlock(t *testing.T) {
+	defer useLightScryptParams()() // Use light scrypt params
+
+	account, err := NewAccount() // Initialize new account
+	if err != nil {              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = account.WriteEncryptedToMemory("passphrase"); err != nil { // Write encrypted account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = ReadAccountFromMemory(account.Address()); err != ErrAccountLocked { // Read locked account
+		t.Fatalf("expected %v, got %v", ErrAccountLocked, err) // Panic
+	}
+
+	if err = Unlock(account.Address(), "wrong passphrase", 0); err != ErrInvalidPassphrase { // Unlock with wrong passphrase
+		t.Fatalf("expected %v, got %v", ErrInvalidPassphrase, err) // Panic
+	}
+
+	if err = Unlock(account.Address(), "passphrase", 0); err != nil { // Unlock account
+		t.Fatal(err) // Panic
+	}
+
+	readAccount, err := ReadAccountFromMemory(account.Address()) // Read unlocked account
+	if err != nil {                                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if readAccount.String() != account.String() { // Check account mismatch
+		t.Fatal("unlocked account does not match") // Panic
+	}
+
+	Lock(account.Address()) // Lock account
+
+	if IsUnlocked(account.Address()) { // Check still unlocked
+		t.Fatal("account should be locked") // Panic
+	}
+
+	if err = Unlock(account.Address(), "passphrase", 10*time.Millisecond); err != nil { // Unlock account with timeout
+		t.Fatal(err) // Panic
+	}
+
+	time.Sleep(50 * time.Millisecond) // Wait for timeout
+
+	if IsUnlocked(account.Address()) { // Check still unlocked
+		t.Fatal("account should be locked after timeout") // Panic
+	}
+}
+
+// TestImportPlaintextAccount tests the functionality of the ImportPlaintextAccount() helper method.
+func TestImportPlaintextAccount(t *testing.T) {
+	defer useLightScryptParams()() // Use light scrypt params
+
+	account, err := NewAccount() // Initialize new account
+	if err != nil {              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = account.WriteToMemory(); err != nil { // Write plaintext account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	address, err := ImportPlaintextAccount(accountPath(account.Address()), "passphrase") // Import account
+	if err != nil {                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *address != *account.Address() { // Check address mismatch
+		t.Fatal("imported account address does not match") // Panic
+	}
+
+	if _, err = ReadAccountFromMemory(address); err != ErrAccountLocked { // Read imported account
+		t.Fatalf("expected %v, got %v", ErrAccountLocked, err) // Panic
+	}
+
+	if _, err = ImportPlaintextAccount(accountPath(address), "passphrase"); err != ErrAccountAlreadyEncrypted { // Import encrypted account
+		t.Fatalf("expected %v, got %v", ErrAccountAlreadyEncrypted, err) // Panic
+	}
+}
+
+// TestImportPlaintextAccountPermissions tests that the ImportPlaintextAccount() helper method restricts the permissions of an existing
+// world-readable plaintext account it replaces.
+func TestImportPlaintextAccountPermissions(t *testing.T) {
+	defer useLightScryptParams()() // Use light scrypt params
+
+	account, err := NewAccount() // Initialize new account
+	if err != nil {              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = common.CreateDirIfDoesNotExist(common.KeystoreDir); err != nil { // Create keystore dir if necessary
+		t.Fatal(err) // Panic
+	}
+
+	path := accountPath(account.Address()) // Get account path
+
+	if err = ioutil.WriteFile(path, account.Bytes(), 0o644); err != nil { // Write world-readable plaintext account
+		t.Fatal(err) // Panic
+	}
+
+	if err = os.Chmod(path, 0o644); err != nil { // Ensure world-readable (regardless of umask)
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = ImportPlaintextAccount(path, "passphrase"); err != nil { // Import account
+		t.Fatal(err) // Panic
+	}
+
+	info, err := os.Stat(path) // Get encrypted account info
+	if err != nil {            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if info.Mode().Perm() != 0o600 { // Check readable by other users
+		t.Fatalf("encrypted account should only be readable by the current user; got mode %o", info.Mode().Perm()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS */
+
+// useLightScryptParams sets light scrypt parameters for faster tests, returning a function restoring the default parameters.
+func useLightScryptParams() func() {
+	defaultParams := DefaultScryptParams // Get default params
+
+	DefaultScryptParams = ScryptParams{N: 1 << 12, R: 8, P: 1} // Set light params
+
+	return func() { DefaultScryptParams = defaultParams } // Return restore function
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,116 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+// ErrInvalidMultisigAccount is an error definition representing a stored multi-signature account whose policy does not derive its address.
+var ErrInvalidMultisigAccount = errors.New("multi-signature account policy does not derive its address")
+
+// MultisigAccount represents an M-of-N multi-signature address, and the policy it is derived from.
+// A multi-signature account holds no private keys: each of its keys belongs to (and signs via) a separate account.
+type MultisigAccount struct {
+	Policy *crypto.MultisigPolicy `json:"policy"` // Multi-signature policy
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMultisigAccount initializes a new multi-signature account requiring threshold signatures from a given set of public keys.
+// Does not write the new account to persistent memory on creation.
+func NewMultisigAccount(threshold uint32, keys []crypto.MultisigKey) (*MultisigAccount, error) {
+	policy, err := crypto.NewMultisigPolicy(threshold, keys) // Initialize policy
+	if err != nil {                                          // Check for errors
+		return &MultisigAccount{}, err // Return found error
+	}
+
+	return &MultisigAccount{Policy: policy}, nil // Return initialized account
+}
+
+// Address derives the address of the given multi-signature account.
+func (account *MultisigAccount) Address() *common.Address {
+	return account.Policy.Address() // Return address value
+}
+
+// GetAllMultisigAccounts gets a list of all the known multi-signature accounts.
+func GetAllMultisigAccounts() []common.Address {
+	buffer := []common.Address{} // Init buffer
+
+	files, err := ioutil.ReadDir(common.KeystoreDir) // Walk keystore dir
+	if err != nil {                                  // Check for errors
+		return []common.Address{} // Return nil
+	}
+
+	for _, file := range files { // Iterate through files
+		if !strings.HasPrefix(file.Name(), "multisig_") { // Check not multi-signature account
+			continue // Continue
+		}
+
+		addressBytes, _ := hex.DecodeString(strings.TrimSuffix(strings.TrimPrefix(file.Name(), "multisig_"), ".json")) // Decode hex addr
+
+		buffer = append(buffer, *common.NewAddress(addressBytes)) // Append address
+	}
+
+	return buffer // No error occurred, return success
+}
+
+// String marshals a given multi-signature account's contents to a JSON-encoded string.
+func (account *MultisigAccount) String() string {
+	return string(account.Bytes()) // Return JSON
+}
+
+// Bytes encodes a given multi-signature account's contents to a JSON-encoded byte array.
+func (account *MultisigAccount) Bytes() []byte {
+	marshaledVal, _ := json.MarshalIndent(*account, "", "  ") // Marshal JSON
+
+	return marshaledVal // Return JSON
+}
+
+// WriteToMemory writes the given multi-signature account's contents to persistent memory.
+func (account *MultisigAccount) WriteToMemory() error {
+	err := common.CreateDirIfDoesNotExist(common.KeystoreDir) // Create keystore dir if necessary
+	if err != nil {                                           // Check for errors
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/multisig_%s.json", common.KeystoreDir, hex.EncodeToString(account.Address().Bytes()))), account.Bytes(), 0o644) // Write account to persistent memory
+}
+
+// ReadMultisigAccountFromMemory reads a multi-signature account with a given address from persistent memory.
+func ReadMultisigAccountFromMemory(address *common.Address) (*MultisigAccount, error) {
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/multisig_%s.json", common.KeystoreDir, hex.EncodeToString(address.Bytes())))) // Read account
+	if err != nil {                                                                                                                               // Check for errors
+		return &MultisigAccount{}, err // Return found error
+	}
+
+	buffer := &MultisigAccount{} // Initialize buffer
+
+	if err = json.Unmarshal(data, buffer); err != nil { // Deserialize JSON into buffer
+		return &MultisigAccount{}, err // Return found error
+	}
+
+	if buffer.Policy == nil { // Check no policy
+		return &MultisigAccount{}, ErrInvalidMultisigAccount // Return error
+	}
+
+	if err = buffer.Policy.Validate(); err != nil { // Validate policy
+		return &MultisigAccount{}, err // Return found error
+	}
+
+	if *buffer.Address() != *address { // Check policy does not derive address
+		return &MultisigAccount{}, ErrInvalidMultisigAccount // Return error
+	}
+
+	return buffer, nil // No error occurred, return read account
+}
+
+/* END EXPORTED METHODS */
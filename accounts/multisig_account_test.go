@@ -0,0 +1,55 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"testing"
+
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestReadMultisigAccountFromMemory tests the functionality of the ReadMultisigAccountFromMemory() helper method.
+func TestReadMultisigAccountFromMemory(t *testing.T) {
+	var keys []crypto.MultisigKey // Init key buffer
+
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Ed25519} { // Iterate through schemes
+		account, err := NewAccountWithScheme(scheme) // Initialize new account
+		if err != nil {                              // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		key, err := crypto.NewMultisigKey(account.Signer().Public()) // Initialize multi-signature key
+		if err != nil {                                              // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		keys = append(keys, key) // Append key
+	}
+
+	account, err := NewMultisigAccount(2, keys) // Initialize 2-of-2 account
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = account.WriteToMemory(); err != nil { // Write account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	readAccount, err := ReadMultisigAccountFromMemory(account.Address()) // Read account from memory
+	if err != nil {                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *readAccount.Address() != *account.Address() || readAccount.Policy.Threshold != 2 { // Check invalid account
+		t.Fatal("read multi-signature account should match written account") // Panic
+	}
+
+	for _, address := range GetAllAccounts() { // Iterate through accounts
+		if address == *account.Address() { // Check multi-signature account listed as account
+			t.Fatal("multi-signature accounts should not be listed as accounts") // Panic
+		}
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
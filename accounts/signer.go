@@ -0,0 +1,39 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	gocrypto "crypto"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// Signer is a source of the private keys of a set of addresses.
+// Keys need not be held in memory: a signer may return keys that delegate signing to another process or device.
+type Signer interface {
+	Addresses() ([]common.Address, error) // Get the addresses the signer holds keys for
+
+	Key(address *common.Address) (gocrypto.Signer, error) // Get the private key of a given address
+}
+
+// KeystoreSigner is a signer holding the keys of the (plaintext or unlocked) accounts in the keystore.
+type KeystoreSigner struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// Addresses gets the addresses of all the accounts in the keystore.
+func (KeystoreSigner) Addresses() ([]common.Address, error) {
+	return GetAllAccounts(), nil // Return addresses
+}
+
+// Key gets the private key of the account with a given address.
+// Returns an ErrAccountLocked error if the account is encrypted and not unlocked.
+func (KeystoreSigner) Key(address *common.Address) (gocrypto.Signer, error) {
+	account, err := ReadAccountFromMemory(address) // Open account
+	if err != nil {                                // Check for errors
+		return nil, err // Return found error
+	}
+
+	return account.Signer(), nil // Return private key
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,289 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// MnemonicEntropyBits is the number of bits of entropy of generated mnemonics (24 words).
+const MnemonicEntropyBits = 256
+
+// HardenedKeyStart is the index of the first hardened child key.
+const HardenedKeyStart = uint32(0x80000000)
+
+// DefaultDerivationPath is the derivation path of the parent of a wallet's accounts: account i is derived at m/0'/i'.
+const DefaultDerivationPath = "m/0'"
+
+var (
+	// ErrInvalidMnemonic is an error definition representing a mnemonic with an unknown word, or an invalid checksum.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+	// ErrInvalidDerivationPath is an error definition representing a malformed derivation path.
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+	// ErrNonHardenedDerivation is an error definition representing a derivation path with a non-hardened index (only hardened
+	// derivation is defined for every signature scheme).
+	ErrNonHardenedDerivation = errors.New("derivation path indices must be hardened")
+
+	// ErrInvalidSeed is an error definition representing a wallet seed that is too short or too long.
+	ErrInvalidSeed = errors.New("wallet seed must be between 16 and 64 bytes")
+)
+
+// masterKeySalts are the HMAC keys deriving the master key of a wallet of each signature scheme from its seed (those of
+// SLIP-0010 for secp256k1, which is therefore compatible with BIP-32 hardened derivation, and Ed25519).
+var masterKeySalts = map[crypto.SignatureScheme][]byte{
+	crypto.P521:      []byte("Polaris P-521 seed"), // P-521
+	crypto.Secp256k1: []byte("Bitcoin seed"),       // secp256k1
+	crypto.Ed25519:   []byte("ed25519 seed"),       // Ed25519
+}
+
+// Wallet is a hierarchical deterministic wallet: a seed (usually derived from a mnemonic backup phrase) from which any number of
+// accounts of a signature scheme can be derived, following SLIP-0010 hardened derivation.
+type Wallet struct {
+	Scheme crypto.SignatureScheme `json:"scheme"` // Signature scheme of derived accounts
+
+	Seed []byte `json:"seed"` // Wallet seed
+}
+
+// extendedKey is a private key of a wallet, and the chain code deriving its children.
+type extendedKey struct {
+	key []byte // Private key (ECDSA D or Ed25519 seed)
+
+	chainCode []byte // Chain code
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMnemonic generates a new random BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropyBits) // Generate entropy
+	if err != nil {                                       // Check for errors
+		return "", err // Return found error
+	}
+
+	return bip39.NewMnemonic(entropy) // Return mnemonic
+}
+
+// NewWalletFromMnemonic initializes a new wallet of a given signature scheme from a given BIP-39 mnemonic.
+// Returns an ErrInvalidMnemonic error if the mnemonic is invalid.
+func NewWalletFromMnemonic(mnemonic string, scheme crypto.SignatureScheme) (*Wallet, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(strings.Join(strings.Fields(mnemonic), " "), "") // Derive seed
+	if err != nil {                                                                              // Check for errors
+		return &Wallet{}, ErrInvalidMnemonic // Return error
+	}
+
+	return NewWalletFromSeed(seed, scheme) // Return initialized wallet
+}
+
+// NewWalletFromSeed initializes a new wallet of a given signature scheme from a given seed.
+func NewWalletFromSeed(seed []byte, scheme crypto.SignatureScheme) (*Wallet, error) {
+	if len(seed) < 16 || len(seed) > 64 { // Check invalid seed
+		return &Wallet{}, ErrInvalidSeed // Return error
+	}
+
+	if _, ok := masterKeySalts[scheme]; !ok { // Check unsupported scheme
+		return &Wallet{}, crypto.ErrUnknownSignatureScheme // Return error
+	}
+
+	return &Wallet{
+		Scheme: scheme,                    // Set scheme
+		Seed:   append([]byte{}, seed...), // Set seed
+	}, nil // Return initialized wallet
+}
+
+// ID gets the identifier of the given wallet: the first 8 bytes of the sha3 hash of its scheme and seed.
+func (wallet *Wallet) ID() string {
+	return hex.EncodeToString(crypto.Sha3(append([]byte{byte(wallet.Scheme)}, wallet.Seed...)).Bytes()[:8]) // Return ID
+}
+
+// Derive derives the account at a given derivation path (e.g. "m/0'/1'") from the given wallet.
+func (wallet *Wallet) Derive(path string) (*Account, error) {
+	indices, err := ParseDerivationPath(path) // Parse path
+	if err != nil {                           // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	key := wallet.masterKey() // Derive master key
+
+	for _, index := range indices { // Iterate through indices
+		if key, err = wallet.childKey(key, index); err != nil { // Derive child key
+			return &Account{}, err // Return found error
+		}
+	}
+
+	return wallet.account(key), nil // Return derived account
+}
+
+// DeriveIndex derives the account with a given index (at DefaultDerivationPath/index') from the given wallet.
+func (wallet *Wallet) DeriveIndex(index uint32) (*Account, error) {
+	if index >= HardenedKeyStart { // Check index too large
+		return &Account{}, ErrInvalidDerivationPath // Return error
+	}
+
+	return wallet.Derive(fmt.Sprintf("%s/%d'", DefaultDerivationPath, index)) // Return derived account
+}
+
+// ParseDerivationPath parses a given derivation path (e.g. "m/0'/1'", or "m/0h/1h"), returning the (hardened) index of each of
+// its levels.
+// Returns an ErrNonHardenedDerivation error if the path contains a non-hardened index.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	components := strings.Split(strings.TrimSpace(path), "/") // Split path
+
+	if components[0] != "m" { // Check not relative to master key
+		return nil, ErrInvalidDerivationPath // Return error
+	}
+
+	var indices []uint32 // Init indices buffer
+
+	for _, component := range components[1:] { // Iterate through components
+		if !strings.HasSuffix(component, "'") && !strings.HasSuffix(component, "h") { // Check not hardened
+			return nil, ErrNonHardenedDerivation // Return error
+		}
+
+		index, err := strconv.ParseUint(component[:len(component)-1], 10, 32) // Parse index
+		if err != nil || uint32(index) >= HardenedKeyStart {                  // Check invalid index
+			return nil, ErrInvalidDerivationPath // Return error
+		}
+
+		indices = append(indices, uint32(index)+HardenedKeyStart) // Append hardened index
+	}
+
+	return indices, nil // Return indices
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// masterKey derives the master key of the given wallet from its seed.
+func (wallet *Wallet) masterKey() *extendedKey {
+	digest := hmacSHA512(masterKeySalts[wallet.Scheme], wallet.Seed) // Derive master key
+
+	if wallet.Scheme == crypto.Ed25519 { // Check is Ed25519
+		return &extendedKey{key: digest[:32], chainCode: digest[32:]} // Return master key
+	}
+
+	for {
+		if masterKey, ok := wallet.keyScalar(digest[:32]); ok { // Check valid key
+			return &extendedKey{key: wallet.serializeKey(masterKey.Bytes()), chainCode: digest[32:]} // Return master key
+		}
+
+		digest = hmacSHA512(masterKeySalts[wallet.Scheme], digest) // Derive again
+	}
+}
+
+// childKey derives the child key with a given (hardened) index of a given key.
+func (wallet *Wallet) childKey(parent *extendedKey, index uint32) (*extendedKey, error) {
+	if index < HardenedKeyStart { // Check not hardened
+		return nil, ErrNonHardenedDerivation // Return error
+	}
+
+	serializedIndex := make([]byte, 4) // Init index buffer
+
+	binary.BigEndian.PutUint32(serializedIndex, index) // Serialize index
+
+	data := append(append([]byte{0}, parent.key...), serializedIndex...) // Init data (0x00 || key || index)
+
+	for {
+		digest := hmacSHA512(parent.chainCode, data) // Derive child key
+
+		if wallet.Scheme == crypto.Ed25519 { // Check is Ed25519
+			return &extendedKey{key: digest[:32], chainCode: digest[32:]}, nil // Return child key
+		}
+
+		if childKey, ok := wallet.keyScalar(digest[:32]); ok { // Check valid tweak
+			childKey.Add(childKey, new(big.Int).SetBytes(parent.key)) // Add parent key
+			childKey.Mod(childKey, wallet.curve().Params().N)         // Reduce mod n
+
+			if childKey.Sign() != 0 { // Check valid child key
+				return &extendedKey{key: wallet.serializeKey(childKey.Bytes()), chainCode: digest[32:]}, nil // Return child key
+			}
+		}
+
+		data = append(append([]byte{1}, digest[32:]...), serializedIndex...) // Derive again (0x01 || chain code || index)
+	}
+}
+
+// keyScalar converts a given 256-bit derived key (or tweak) to a scalar of the given (ECDSA) wallet's curve, returning false if
+// the scalar is not a valid private key.
+// Keys of curves no wider than 256 bits are used as is (as in SLIP-0010); keys of wider curves (i.e. P-521) are expanded via
+// HMAC-SHA512 to the curve's byte length plus a 16-byte margin, then reduced mod n, such that derived keys span the whole curve order.
+func (wallet *Wallet) keyScalar(key []byte) (*big.Int, bool) {
+	n := wallet.curve().Params().N // Get curve order
+
+	length := (wallet.curve().Params().BitSize + 7) / 8 // Get curve byte length
+
+	if length <= len(key) { // Check key spans curve
+		d := new(big.Int).SetBytes(key) // Get key int
+
+		return d, d.Sign() != 0 && d.Cmp(n) < 0 // Return is in [1, n)
+	}
+
+	var expanded []byte // Init expanded key buffer
+
+	for counter := byte(1); len(expanded) < length+16; counter++ { // Expand until wide enough
+		expanded = append(expanded, hmacSHA512(key, []byte{counter})...) // Append expansion block
+	}
+
+	d := new(big.Int).SetBytes(expanded) // Get expanded key int
+
+	d.Mod(d, n) // Reduce mod n
+
+	return d, d.Sign() != 0 // Return is in [1, n)
+}
+
+// serializeKey left-pads a given ECDSA private key of the given wallet's curve to the curve's byte length (Ed25519 keys are
+// returned as is).
+func (wallet *Wallet) serializeKey(key []byte) []byte {
+	if wallet.Scheme == crypto.Ed25519 { // Check is Ed25519
+		return key // Return key
+	}
+
+	length := (wallet.curve().Params().BitSize + 7) / 8 // Get curve byte length
+
+	return append(make([]byte, length-len(key)), key...) // Return padded key
+}
+
+// curve gets the elliptic curve of the given (ECDSA) wallet.
+func (wallet *Wallet) curve() elliptic.Curve {
+	return (&Account{Scheme: wallet.Scheme}).curve() // Return curve
+}
+
+// account initializes an account from a given key of the given wallet.
+func (wallet *Wallet) account(key *extendedKey) *Account {
+	if wallet.Scheme == crypto.Ed25519 { // Check is Ed25519
+		return &Account{Scheme: crypto.Ed25519, Seed: append([]byte{}, key.key...)} // Return account
+	}
+
+	curve := wallet.curve() // Get curve
+
+	d := new(big.Int).SetBytes(key.key) // Get D
+
+	x, y := curve.ScalarBaseMult(key.key) // Derive public key
+
+	return AccountFromKey(&ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y}, D: d}) // Return account
+}
+
+// hmacSHA512 calculates the HMAC-SHA512 of a given message with a given key.
+func hmacSHA512(key []byte, message []byte) []byte {
+	mac := hmac.New(sha512.New, key) // Init HMAC
+
+	mac.Write(message) // Write message
+
+	return mac.Sum(nil) // Return digest
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,164 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+// EncryptedWallet is the persistent form of a wallet: its identifier, signature scheme and the index of the next account to
+// derive in plaintext, and its seed encrypted with a key derived from a passphrase.
+type EncryptedWallet struct {
+	Version int `json:"version"` // Keystore version
+
+	ID string `json:"id"` // Wallet identifier
+
+	Scheme crypto.SignatureScheme `json:"scheme"` // Signature scheme of derived accounts
+
+	NextIndex uint32 `json:"next_index"` // Index of the next account to derive
+
+	Crypto KeystoreCrypto `json:"crypto"` // Encrypted seed
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// GetAllWallets gets a list of the identifiers of all the owned wallets.
+func GetAllWallets() []string {
+	buffer := []string{} // Init buffer
+
+	files, err := ioutil.ReadDir(common.KeystoreDir) // Walk keystore dir
+	if err != nil {                                  // Check for errors
+		return []string{} // Return nil
+	}
+
+	for _, file := range files { // Iterate through files
+		if !strings.HasPrefix(file.Name(), "wallet_") { // Check not wallet
+			continue // Continue
+		}
+
+		buffer = append(buffer, strings.TrimSuffix(strings.TrimPrefix(file.Name(), "wallet_"), ".json")) // Append ID
+	}
+
+	return buffer // No error occurred, return success
+}
+
+// Encrypt encrypts the given wallet with a key derived from a given passphrase.
+func (wallet *Wallet) Encrypt(passphrase string) (*EncryptedWallet, error) {
+	id := wallet.ID() // Get ID
+
+	keystoreCrypto, err := sealKeystoreCrypto(wallet.Seed, walletAdditionalData(id, wallet.Scheme), passphrase) // Encrypt seed
+	if err != nil {                                                                                             // Check for errors
+		return &EncryptedWallet{}, err // Return found error
+	}
+
+	return &EncryptedWallet{
+		Version: keystoreVersion, // Set version
+		ID:      id,              // Set ID
+		Scheme:  wallet.Scheme,   // Set scheme
+		Crypto:  *keystoreCrypto, // Set crypto
+	}, nil // Return encrypted wallet
+}
+
+// Decrypt decrypts a given encrypted wallet with a given passphrase.
+// Returns an ErrInvalidPassphrase error if the passphrase does not decrypt the wallet.
+func (encryptedWallet *EncryptedWallet) Decrypt(passphrase string) (*Wallet, error) {
+	if encryptedWallet.Version != keystoreVersion { // Check unknown version
+		return &Wallet{}, ErrUnsupportedKeystoreVersion // Return error
+	}
+
+	seed, err := encryptedWallet.Crypto.open(walletAdditionalData(encryptedWallet.ID, encryptedWallet.Scheme), passphrase) // Decrypt seed
+	if err != nil {                                                                                                        // Check for errors
+		return &Wallet{}, err // Return found error
+	}
+
+	return NewWalletFromSeed(seed, encryptedWallet.Scheme) // Return decrypted wallet
+}
+
+// DeriveNextAccount decrypts the given encrypted wallet with a given passphrase, derives the wallet's next account, writes the
+// account to persistent memory (encrypted with the same passphrase), and advances the wallet's next index.
+func (encryptedWallet *EncryptedWallet) DeriveNextAccount(passphrase string) (*Account, error) {
+	wallet, err := encryptedWallet.Decrypt(passphrase) // Decrypt wallet
+	if err != nil {                                    // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	account, err := wallet.DeriveIndex(encryptedWallet.NextIndex) // Derive account
+	if err != nil {                                               // Check for errors
+		return &Account{}, err // Return found error
+	}
+
+	if err = account.WriteEncryptedToMemory(passphrase); err != nil { // Write account to persistent memory
+		return &Account{}, err // Return found error
+	}
+
+	encryptedWallet.NextIndex++ // Increment next index
+
+	return account, encryptedWallet.WriteToMemory() // Return derived account
+}
+
+// String marshals a given encrypted wallet's contents to a JSON-encoded string.
+func (encryptedWallet *EncryptedWallet) String() string {
+	marshaledVal, _ := json.MarshalIndent(*encryptedWallet, "", "  ") // Marshal JSON
+
+	return string(marshaledVal) // Return JSON
+}
+
+// Bytes encodes a given encrypted wallet's contents to a JSON-encoded byte array.
+func (encryptedWallet *EncryptedWallet) Bytes() []byte {
+	marshaledVal, _ := json.MarshalIndent(*encryptedWallet, "", "  ") // Marshal JSON
+
+	return marshaledVal // Return JSON
+}
+
+// WriteToMemory writes the given encrypted wallet's contents to persistent memory (readable only by the current user).
+func (encryptedWallet *EncryptedWallet) WriteToMemory() error {
+	err := common.CreateDirIfDoesNotExist(common.KeystoreDir) // Create keystore dir if necessary
+	if err != nil {                                           // Check for errors
+		return err // Return found error
+	}
+
+	return writePrivateFile(walletPath(encryptedWallet.ID), encryptedWallet.Bytes()) // Write wallet to persistent memory
+}
+
+// ReadEncryptedWalletFromMemory reads an encrypted wallet with a given identifier from persistent memory.
+func ReadEncryptedWalletFromMemory(id string) (*EncryptedWallet, error) {
+	if _, err := hex.DecodeString(id); err != nil { // Check invalid ID
+		return &EncryptedWallet{}, err // Return found error
+	}
+
+	data, err := ioutil.ReadFile(walletPath(id)) // Read wallet
+	if err != nil {                              // Check for errors
+		return &EncryptedWallet{}, err // Return found error
+	}
+
+	buffer := &EncryptedWallet{} // Initialize buffer
+
+	if err = json.Unmarshal(data, buffer); err != nil { // Deserialize JSON into buffer
+		return &EncryptedWallet{}, err // Return found error
+	}
+
+	return buffer, nil // No error occurred, return read wallet
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// walletAdditionalData gets the data authenticated along with the seed of a wallet with a given identifier and signature scheme.
+func walletAdditionalData(id string, scheme crypto.SignatureScheme) []byte {
+	return append([]byte(id), byte(scheme)) // Return additional data
+}
+
+// walletPath gets the keystore path of the wallet with a given identifier.
+func walletPath(id string) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/wallet_%s.json", common.KeystoreDir, id)) // Return path
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,209 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestNewWalletFromMnemonic tests the functionality of the NewWalletFromMnemonic() helper method.
+func TestNewWalletFromMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic() // Generate mnemonic
+	if err != nil {                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Secp256k1, crypto.Ed25519} { // Iterate through schemes
+		wallet, err := NewWalletFromMnemonic(mnemonic, scheme) // Initialize wallet
+		if err != nil {                                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		restoredWallet, err := NewWalletFromMnemonic(" "+mnemonic+"\n", scheme) // Restore wallet
+		if err != nil {                                                         // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		account, err := wallet.DeriveIndex(1) // Derive account
+		if err != nil {                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		restoredAccount, err := restoredWallet.DeriveIndex(1) // Derive restored account
+		if err != nil {                                       // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if *account.Address() != *restoredAccount.Address() { // Check address mismatch
+			t.Fatal("restored wallet derived a different account") // Panic
+		}
+
+		otherAccount, err := wallet.DeriveIndex(2) // Derive other account
+		if err != nil {                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if *account.Address() == *otherAccount.Address() { // Check address match
+			t.Fatal("wallet derived the same account at different indices") // Panic
+		}
+
+		if _, err = crypto.AddressFromSigner(account.Signer()); err != nil { // Check derived key
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if _, err = NewWalletFromMnemonic(mnemonic+" abandon", crypto.P521); err != ErrInvalidMnemonic { // Initialize wallet with invalid mnemonic
+		t.Fatalf("expected %v, got %v", ErrInvalidMnemonic, err) // Panic
+	}
+}
+
+// TestDerive tests the functionality of the Derive() helper method against the SLIP-0010 test vectors.
+func TestDerive(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f") // Decode test vector seed
+
+	vectors := map[crypto.SignatureScheme]map[string]string{
+		crypto.Secp256k1: {
+			"m":    "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", // Master key
+			"m/0'": "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea", // Child key
+		},
+		crypto.Ed25519: {
+			"m":             "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", // Master key
+			"m/0'":          "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3", // Child key
+			"m/0h/1h/2h/2h": "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662", // Descendant key
+		},
+	} // Init test vectors
+
+	for scheme, paths := range vectors { // Iterate through schemes
+		wallet, err := NewWalletFromSeed(seed, scheme) // Initialize wallet
+		if err != nil {                                // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		for path, expected := range paths { // Iterate through paths
+			account, err := wallet.Derive(path) // Derive account
+			if err != nil {                     // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			key := hex.EncodeToString(account.Seed) // Get Ed25519 key
+
+			if scheme != crypto.Ed25519 { // Check is ECDSA
+				key = hex.EncodeToString(account.D.Bytes()) // Get ECDSA key
+			}
+
+			if key != expected { // Check key mismatch
+				t.Fatalf("%s: expected %s, got %s", path, expected, key) // Panic
+			}
+		}
+	}
+
+	wallet, _ := NewWalletFromSeed(seed, crypto.P521) // Initialize wallet
+
+	if _, err := wallet.Derive("m/0'/1"); err != ErrNonHardenedDerivation { // Derive non-hardened path
+		t.Fatalf("expected %v, got %v", ErrNonHardenedDerivation, err) // Panic
+	}
+}
+
+// TestDeriveP521KeyWidth tests that the Derive() helper method derives P-521 keys spanning the full curve order.
+func TestDeriveP521KeyWidth(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f") // Decode test vector seed
+
+	wallet, err := NewWalletFromSeed(seed, crypto.P521) // Initialize wallet
+	if err != nil {                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	paths := []string{"m"} // Init paths (starting with the master key)
+
+	for i := 0; i < 64; i++ { // Add child paths
+		paths = append(paths, fmt.Sprintf("m/0'/%d'", i)) // Append path
+	}
+
+	maxBitLength := 0 // Init max key bit length
+
+	for _, path := range paths { // Iterate through paths
+		account, err := wallet.Derive(path) // Derive account
+		if err != nil {                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if account.D.BitLen() <= 300 { // Check key confined to a small range
+			t.Fatalf("%s: derived P-521 key should span the curve order; got a %d-bit key", path, account.D.BitLen()) // Panic
+		}
+
+		if account.D.BitLen() > maxBitLength { // Check new max
+			maxBitLength = account.D.BitLen() // Set max
+		}
+	}
+
+	if maxBitLength != 521 { // Check no full-width key
+		t.Fatalf("derived P-521 keys should use the full 521-bit length; longest was %d bits", maxBitLength) // Panic
+	}
+}
+
+// TestDeriveNextAccount tests the functionality of the DeriveNextAccount() helper method.
+func TestDeriveNextAccount(t *testing.T) {
+	defer useLightScryptParams()() // Use light scrypt params
+
+	mnemonic, err := NewMnemonic() // Generate mnemonic
+	if err != nil {                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	wallet, err := NewWalletFromMnemonic(mnemonic, crypto.P521) // Initialize wallet
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	encryptedWallet, err := wallet.Encrypt("passphrase") // Encrypt wallet
+	if err != nil {                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = encryptedWallet.WriteToMemory(); err != nil { // Write wallet to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	encryptedWallet, err = ReadEncryptedWalletFromMemory(wallet.ID()) // Read wallet
+	if err != nil {                                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = encryptedWallet.DeriveNextAccount("wrong passphrase"); err != ErrInvalidPassphrase { // Derive with wrong passphrase
+		t.Fatalf("expected %v, got %v", ErrInvalidPassphrase, err) // Panic
+	}
+
+	account, err := encryptedWallet.DeriveNextAccount("passphrase") // Derive account
+	if err != nil {                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	expected, _ := wallet.DeriveIndex(0) // Derive expected account
+
+	if *account.Address() != *expected.Address() { // Check address mismatch
+		t.Fatal("derived account does not match") // Panic
+	}
+
+	if encryptedWallet, err = ReadEncryptedWalletFromMemory(wallet.ID()); err != nil || encryptedWallet.NextIndex != 1 { // Check next index
+		t.Fatalf("expected next index 1, got %d (%v)", encryptedWallet.NextIndex, err) // Panic
+	}
+
+	found := false // Init found
+
+	for _, address := range GetAllAccounts() { // Iterate through accounts
+		if address == *account.Address() { // Check is derived account
+			found = true // Set found
+		}
+	}
+
+	if !found { // Check not found
+		t.Fatal("derived account not listed") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -0,0 +1,239 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+var (
+	// ErrInvalidLabel is an error definition representing an empty watch-only label, or a label that could be mistaken for an
+	// address or a list of parameters.
+	ErrInvalidLabel = errors.New("watch-only labels must be non-empty, contain no commas, and not be hex encoded")
+
+	// ErrDuplicateLabel is an error definition representing a watch-only label already used by another address.
+	ErrDuplicateLabel = errors.New("watch-only label is already in use")
+
+	// ErrWatchOnlyKeyMismatch is an error definition representing a watch-only public key that does not derive its address.
+	ErrWatchOnlyKeyMismatch = errors.New("watch-only public key does not derive its address")
+
+	// ErrUnknownAddress is an error definition representing a string that is neither an address nor a watch-only label.
+	ErrUnknownAddress = errors.New("not an address or a known watch-only label")
+)
+
+// WatchOnlyAccount represents an address book entry: an address (and, optionally, its public key) tracked under a label,
+// whose private key is not held by the keystore (e.g. a cold storage address).
+type WatchOnlyAccount struct {
+	Address *common.Address `json:"address"` // Address
+
+	Label string `json:"label"` // Label
+
+	Scheme crypto.SignatureScheme `json:"scheme,omitempty"` // Signature scheme (if the public key is known)
+
+	MarshaledPublicKey []byte `json:"pub,omitempty"` // Marshaled public key (if known)
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewWatchOnlyAccount initializes a new watch-only account tracking a given address under a given label.
+// Does not write the new account to persistent memory on creation.
+func NewWatchOnlyAccount(address *common.Address, label string) (*WatchOnlyAccount, error) {
+	account := &WatchOnlyAccount{
+		Address: address, // Set address
+		Label:   label,   // Set label
+	} // Initialize account
+
+	if err := account.Validate(); err != nil { // Validate account
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	return account, nil // Return initialized account
+}
+
+// NewWatchOnlyAccountFromPublicKey initializes a new watch-only account tracking the address of a given marshaled public key of a
+// given signature scheme under a given label.
+// Does not write the new account to persistent memory on creation.
+func NewWatchOnlyAccountFromPublicKey(scheme crypto.SignatureScheme, marshaledPublicKey []byte, label string) (*WatchOnlyAccount, error) {
+	algorithm, err := crypto.GetSignatureAlgorithm(scheme) // Get signature algorithm
+	if err != nil {                                        // Check for errors
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	address, err := algorithm.Address(marshaledPublicKey) // Derive address
+	if err != nil {                                       // Check for errors
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	account := &WatchOnlyAccount{
+		Address:            address,            // Set address
+		Label:              label,              // Set label
+		Scheme:             scheme,             // Set scheme
+		MarshaledPublicKey: marshaledPublicKey, // Set public key
+	} // Initialize account
+
+	if err = account.Validate(); err != nil { // Validate account
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	return account, nil // Return initialized account
+}
+
+// Validate checks that the given watch-only account has a valid label, and that its public key (if any) derives its address.
+func (account *WatchOnlyAccount) Validate() error {
+	if account.Address == nil { // Check no address
+		return ErrUnknownAddress // Return error
+	}
+
+	if account.Label == "" || strings.Contains(account.Label, ",") { // Check invalid label
+		return ErrInvalidLabel // Return error
+	}
+
+	if _, err := hex.DecodeString(account.Label); err == nil { // Check hex encoded label
+		return ErrInvalidLabel // Return error
+	}
+
+	if account.MarshaledPublicKey == nil { // Check no public key
+		return nil // Account is valid
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(account.Scheme) // Get signature algorithm
+	if err != nil {                                                // Check for errors
+		return err // Return found error
+	}
+
+	address, err := algorithm.Address(account.MarshaledPublicKey) // Derive address
+	if err != nil {                                               // Check for errors
+		return err // Return found error
+	}
+
+	if *address != *account.Address { // Check public key does not derive address
+		return ErrWatchOnlyKeyMismatch // Return error
+	}
+
+	return nil // Account is valid
+}
+
+// GetAllWatchOnlyAccounts gets a list of all the watch-only accounts.
+func GetAllWatchOnlyAccounts() []*WatchOnlyAccount {
+	buffer := []*WatchOnlyAccount{} // Init buffer
+
+	files, err := ioutil.ReadDir(common.KeystoreDir) // Walk keystore dir
+	if err != nil {                                  // Check for errors
+		return []*WatchOnlyAccount{} // Return nil
+	}
+
+	for _, file := range files { // Iterate through files
+		if !strings.HasPrefix(file.Name(), "watch_") { // Check not watch-only account
+			continue // Continue
+		}
+
+		addressBytes, _ := hex.DecodeString(strings.TrimSuffix(strings.TrimPrefix(file.Name(), "watch_"), ".json")) // Decode hex addr
+
+		account, err := ReadWatchOnlyAccountFromMemory(common.NewAddress(addressBytes)) // Read account
+		if err != nil {                                                                 // Check for errors
+			continue // Skip invalid account
+		}
+
+		buffer = append(buffer, account) // Append account
+	}
+
+	return buffer // No error occurred, return success
+}
+
+// ResolveAddress resolves a given hex encoded address or watch-only label to an address.
+// Returns an ErrUnknownAddress error if the string is neither.
+func ResolveAddress(addressOrLabel string) (*common.Address, error) {
+	if addressBytes, err := hex.DecodeString(addressOrLabel); err == nil { // Check is hex encoded address
+		return common.NewAddress(addressBytes), nil // Return address
+	}
+
+	for _, account := range GetAllWatchOnlyAccounts() { // Iterate through watch-only accounts
+		if account.Label == addressOrLabel { // Check matching label
+			return account.Address, nil // Return address
+		}
+	}
+
+	return nil, ErrUnknownAddress // Return error
+}
+
+// String marshals a given watch-only account's contents to a JSON-encoded string.
+func (account *WatchOnlyAccount) String() string {
+	return string(account.Bytes()) // Return JSON
+}
+
+// Bytes encodes a given watch-only account's contents to a JSON-encoded byte array.
+func (account *WatchOnlyAccount) Bytes() []byte {
+	marshaledVal, _ := json.MarshalIndent(*account, "", "  ") // Marshal JSON
+
+	return marshaledVal // Return JSON
+}
+
+// WriteToMemory writes the given watch-only account's contents to persistent memory.
+// Returns an ErrDuplicateLabel error if another address is tracked under the same label.
+func (account *WatchOnlyAccount) WriteToMemory() error {
+	if err := account.Validate(); err != nil { // Validate account
+		return err // Return found error
+	}
+
+	for _, existing := range GetAllWatchOnlyAccounts() { // Iterate through watch-only accounts
+		if existing.Label == account.Label && *existing.Address != *account.Address { // Check label in use
+			return ErrDuplicateLabel // Return error
+		}
+	}
+
+	err := common.CreateDirIfDoesNotExist(common.KeystoreDir) // Create keystore dir if necessary
+	if err != nil {                                           // Check for errors
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(watchOnlyAccountPath(account.Address), account.Bytes(), 0o644) // Write account to persistent memory
+}
+
+// ReadWatchOnlyAccountFromMemory reads a watch-only account with a given address from persistent memory.
+func ReadWatchOnlyAccountFromMemory(address *common.Address) (*WatchOnlyAccount, error) {
+	data, err := ioutil.ReadFile(watchOnlyAccountPath(address)) // Read account
+	if err != nil {                                             // Check for errors
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	buffer := &WatchOnlyAccount{} // Initialize buffer
+
+	if err = json.Unmarshal(data, buffer); err != nil { // Deserialize JSON into buffer
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	if err = buffer.Validate(); err != nil { // Validate account
+		return &WatchOnlyAccount{}, err // Return found error
+	}
+
+	if *buffer.Address != *address { // Check address mismatch
+		return &WatchOnlyAccount{}, ErrUnknownAddress // Return error
+	}
+
+	return buffer, nil // No error occurred, return read account
+}
+
+// RemoveWatchOnlyAccount removes the watch-only account with a given address from persistent memory.
+func RemoveWatchOnlyAccount(address *common.Address) error {
+	return os.Remove(watchOnlyAccountPath(address)) // Remove account
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// watchOnlyAccountPath gets the keystore path of the watch-only account with a given address.
+func watchOnlyAccountPath(address *common.Address) string {
+	return filepath.FromSlash(fmt.Sprintf("%s/watch_%s.json", common.KeystoreDir, hex.EncodeToString(address.Bytes()))) // Return path
+}
+
+/* END INTERNAL METHODS */
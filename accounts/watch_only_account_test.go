@@ -0,0 +1,89 @@
+// Package accounts defines a set of ECDSA private-public keypair management utilities and helper methods.
+package accounts
+
+import (
+	"testing"
+
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestReadWatchOnlyAccountFromMemory tests the functionality of the ReadWatchOnlyAccountFromMemory() helper method.
+func TestReadWatchOnlyAccountFromMemory(t *testing.T) {
+	coldAccount, err := NewAccountWithScheme(crypto.Ed25519) // Initialize cold storage account
+	if err != nil {                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	algorithm, _ := crypto.GetSignatureAlgorithm(crypto.Ed25519) // Get signature algorithm
+
+	publicKey, err := algorithm.MarshalPublicKey(coldAccount.Signer().Public()) // Marshal public key
+	if err != nil {                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	account, err := NewWatchOnlyAccountFromPublicKey(crypto.Ed25519, publicKey, "cold storage") // Initialize watch-only account
+	if err != nil {                                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *account.Address != *coldAccount.Address() { // Check address mismatch
+		t.Fatal("watch-only account address should match cold storage address") // Panic
+	}
+
+	if err = account.WriteToMemory(); err != nil { // Write account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	readAccount, err := ReadWatchOnlyAccountFromMemory(account.Address) // Read account from memory
+	if err != nil {                                                     // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if readAccount.String() != account.String() { // Check account mismatch
+		t.Fatal("read watch-only account should match written account") // Panic
+	}
+
+	resolved, err := ResolveAddress("cold storage")  // Resolve label
+	if err != nil || *resolved != *account.Address { // Check invalid address
+		t.Fatalf("label should resolve to watch-only address (%v)", err) // Panic
+	}
+
+	other, err := NewWatchOnlyAccount(coldAccount.Address(), "other") // Initialize other account with the same address
+	if err != nil {                                                   // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	other.Address[0]++ // Change address
+
+	if err = other.WriteToMemory(); err != nil { // Write other account to persistent memory
+		t.Fatal(err) // Panic
+	}
+
+	other.Label = "cold storage" // Reuse label
+
+	if err = other.WriteToMemory(); err != ErrDuplicateLabel { // Write account with duplicate label
+		t.Fatalf("expected %v, got %v", ErrDuplicateLabel, err) // Panic
+	}
+
+	if _, err = NewWatchOnlyAccount(account.Address, "cafe"); err != ErrInvalidLabel { // Initialize account with hex label
+		t.Fatalf("expected %v, got %v", ErrInvalidLabel, err) // Panic
+	}
+
+	for _, address := range GetAllAccounts() { // Iterate through accounts
+		if address == *account.Address { // Check watch-only account listed as account
+			t.Fatal("watch-only accounts should not be listed as accounts") // Panic
+		}
+	}
+
+	if err = RemoveWatchOnlyAccount(other.Address); err != nil { // Remove other account
+		t.Fatal(err) // Panic
+	}
+
+	if err = RemoveWatchOnlyAccount(account.Address); err != nil { // Remove account
+		t.Fatal(err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -7,16 +7,19 @@ import (
 	"net/http"
 	"path/filepath"
 
+	"github.com/polaris-project/go-polaris/accounts"
 	"github.com/polaris-project/go-polaris/common"
 	accountsProto "github.com/polaris-project/go-polaris/internal/proto/accounts"
 	configProto "github.com/polaris-project/go-polaris/internal/proto/config"
 	cryptoProto "github.com/polaris-project/go-polaris/internal/proto/crypto"
 	dagProto "github.com/polaris-project/go-polaris/internal/proto/dag"
+	mempoolProto "github.com/polaris-project/go-polaris/internal/proto/mempool"
 	transactionProto "github.com/polaris-project/go-polaris/internal/proto/transaction"
 	accountsServer "github.com/polaris-project/go-polaris/internal/rpc/accounts"
 	configServer "github.com/polaris-project/go-polaris/internal/rpc/config"
 	cryptoServer "github.com/polaris-project/go-polaris/internal/rpc/crypto"
 	dagServer "github.com/polaris-project/go-polaris/internal/rpc/dag"
+	mempoolServer "github.com/polaris-project/go-polaris/internal/rpc/mempool"
 	transactionServer "github.com/polaris-project/go-polaris/internal/rpc/transaction"
 )
 
@@ -29,6 +32,8 @@ type RPCAPI struct {
 	URI string `json:"uri"` // API URI
 
 	Server *http.Server `json:"server"` // Working server
+
+	Signer accounts.Signer `json:"-"` // Source of transaction signing keys (the keystore if nil)
 }
 
 /* BEGIN EXPORTED METHODS */
@@ -81,11 +86,12 @@ func (rpcAPI *RPCAPI) StartServing(ctx context.Context) error {
 		return err // Return found error
 	}
 
-	configHandler := configProto.NewConfigServer(&configServer.Server{}, nil)                     // Get handler
-	cryptoHandler := cryptoProto.NewCryptoServer(&cryptoServer.Server{}, nil)                     // Get handler
-	transactionHandler := transactionProto.NewTransactionServer(&transactionServer.Server{}, nil) // Get handler
-	accountsHandler := accountsProto.NewAccountsServer(&accountsServer.Server{}, nil)             // Get handler
-	dagHandler := dagProto.NewDagServer(&dagServer.Server{}, nil)                                 // Get handler
+	configHandler := configProto.NewConfigServer(&configServer.Server{}, nil)                                          // Get handler
+	cryptoHandler := cryptoProto.NewCryptoServer(&cryptoServer.Server{}, nil)                                          // Get handler
+	transactionHandler := transactionProto.NewTransactionServer(&transactionServer.Server{Signer: rpcAPI.Signer}, nil) // Get handler
+	accountsHandler := accountsProto.NewAccountsServer(&accountsServer.Server{}, nil)                                  // Get handler
+	dagHandler := dagProto.NewDagServer(&dagServer.Server{}, nil)                                                      // Get handler
+	mempoolHandler := mempoolProto.NewMempoolServer(&mempoolServer.Server{}, nil)                                      // Get handler
 
 	mux := http.NewServeMux() // Init mux
 
@@ -94,6 +100,7 @@ func (rpcAPI *RPCAPI) StartServing(ctx context.Context) error {
 	mux.Handle(transactionProto.TransactionPathPrefix, transactionHandler) // Set route handler
 	mux.Handle(accountsProto.AccountsPathPrefix, accountsHandler)          // Set route handler
 	mux.Handle(dagProto.DagPathPrefix, dagHandler)                         // Set route handler
+	mux.Handle(mempoolProto.MempoolPathPrefix, mempoolHandler)             // Set route handler
 
 	return http.ListenAndServeTLS(rpcAPI.URI, filepath.FromSlash(fmt.Sprintf("%s/rpcCert.pem", common.CertificatesDir)), filepath.FromSlash(fmt.Sprintf("%s/rpcKey.pem", common.CertificatesDir)), mux) // Start serving
 }
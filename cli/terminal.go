@@ -16,23 +16,30 @@ import (
 
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/crypto"
+	"golang.org/x/crypto/ssh/terminal"
 
 	accountsProto "github.com/polaris-project/go-polaris/internal/proto/accounts"
 	configProto "github.com/polaris-project/go-polaris/internal/proto/config"
 	cryptoProto "github.com/polaris-project/go-polaris/internal/proto/crypto"
 	dagProto "github.com/polaris-project/go-polaris/internal/proto/dag"
+	mempoolProto "github.com/polaris-project/go-polaris/internal/proto/mempool"
 	transactionProto "github.com/polaris-project/go-polaris/internal/proto/transaction"
 )
 
-// ErrInvalidParams is an error definition describing invalid input parameters.
-var ErrInvalidParams = errors.New("invalid parameters")
+var (
+	// ErrInvalidParams is an error definition describing invalid input parameters.
+	ErrInvalidParams = errors.New("invalid parameters")
+
+	// ErrPassphraseMismatch is an error definition describing a passphrase that does not match its confirmation.
+	ErrPassphraseMismatch = errors.New("passphrases do not match")
+)
+
+var reader = bufio.NewScanner(os.Stdin) // Stdin reader
 
 /* BEGIN EXPORTED METHODS */
 
 // NewTerminal attempts to start a handler for term commands.
 func NewTerminal(rpcPort uint, rpcAddress string) {
-	reader := bufio.NewScanner(os.Stdin) // Init reader
-
 	transport := &http.Transport{ // Init transport
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 	}
@@ -64,6 +71,7 @@ func handleCommand(receiver, methodname string, params []string, rpcPort uint, r
 	configClient := configProto.NewConfigProtobufClient("https://"+rpcAddress+":"+strconv.Itoa(int(rpcPort)), &http.Client{Transport: transport})                // Init config client
 	transactionClient := transactionProto.NewTransactionProtobufClient("https://"+rpcAddress+":"+strconv.Itoa(int(rpcPort)), &http.Client{Transport: transport}) // Init transaction client
 	dagClient := dagProto.NewDagProtobufClient("https://"+rpcAddress+":"+strconv.Itoa(int(rpcPort)), &http.Client{Transport: transport})                         // Init dag client
+	mempoolClient := mempoolProto.NewMempoolProtobufClient("https://"+rpcAddress+":"+strconv.Itoa(int(rpcPort)), &http.Client{Transport: transport})             // Init mempool client
 
 	switch receiver {
 	case "crypto":
@@ -91,8 +99,13 @@ func handleCommand(receiver, methodname string, params []string, rpcPort uint, r
 		if err != nil {                                  // Check for errors
 			fmt.Println("\n" + err.Error()) // Log found error
 		}
+	case "mempool":
+		err := handleMempool(&mempoolClient, methodname, params) // Handle mempool
+		if err != nil {                                          // Check for errors
+			fmt.Println("\n" + err.Error()) // Log found error
+		}
 	default:
-		fmt.Println("\n" + "unrecognized namespace " + `"` + receiver + `"` + ", available namespaces: crypto, accounts, config, transaction") // Log invalid namespace
+		fmt.Println("\n" + "unrecognized namespace " + `"` + receiver + `"` + ", available namespaces: crypto, accounts, config, transaction, dag, mempool") // Log invalid namespace
 	}
 }
 
@@ -147,20 +160,144 @@ func handleAccounts(accountsClient *accountsProto.Accounts, methodname string, p
 	reflectParams = append(reflectParams, reflect.ValueOf(context.Background())) // Append request context
 
 	switch methodname { // Handle different methods
-	case "NewAccount", "GetAllAccounts":
+	case "NewAccount":
+		if len(params) > 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		passphrase, err := readNewPassphrase() // Read passphrase
+		if err != nil {                        // Check for errors
+			return err // Return found error
+		}
+
+		request := &accountsProto.GeneralRequest{Passphrase: passphrase} // Init request
+
+		if len(params) == 1 { // Check has signature scheme
+			request.Scheme = params[0] // Set signature scheme
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
+	case "NewWallet":
+		if len(params) > 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		passphrase, err := readNewPassphrase() // Read passphrase
+		if err != nil {                        // Check for errors
+			return err // Return found error
+		}
+
+		request := &accountsProto.GeneralRequest{Passphrase: passphrase} // Init request
+
+		if len(params) == 1 { // Check has signature scheme
+			request.Scheme = params[0] // Set signature scheme
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
+	case "RestoreWallet":
+		if len(params) != 1 && len(params) != 2 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		count, err := strconv.ParseUint(params[0], 10, 32) // Get number of accounts to derive
+		if err != nil {                                    // Check for errors
+			return ErrInvalidParams // Return error
+		}
+
+		mnemonic, err := readPassphrase("Mnemonic: ") // Read mnemonic
+		if err != nil {                               // Check for errors
+			return err // Return found error
+		}
+
+		passphrase, err := readNewPassphrase() // Read passphrase
+		if err != nil {                        // Check for errors
+			return err // Return found error
+		}
+
+		request := &accountsProto.GeneralRequest{Mnemonic: mnemonic, Count: uint32(count), Passphrase: passphrase} // Init request
+
+		if len(params) == 2 { // Check has signature scheme
+			request.Scheme = params[1] // Set signature scheme
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
+	case "DeriveAccount":
+		if len(params) != 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		passphrase, err := readPassphrase("Passphrase: ") // Read passphrase
+		if err != nil {                                   // Check for errors
+			return err // Return found error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Wallet: params[0], Passphrase: passphrase})) // Append params
+	case "AddWatchOnlyAccount":
+		if len(params) != 2 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{PrivatePublicKey: params[0], Label: params[1]})) // Append params
+	case "GetAllAccounts", "GetAllWallets", "GetAllWatchOnlyAccounts":
 		if len(params) != 0 { // Check for invalid params
 			return ErrInvalidParams // Return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{})) // Append params
-	case "AccountFromKey", "Address", "PublicKey", "PrivateKey", "String":
+	case "AccountFromKey", "ImportAccount":
+		if len(params) != 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		passphrase, err := readNewPassphrase() // Read passphrase
+		if err != nil {                        // Check for errors
+			return err // Return found error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{PrivatePublicKey: params[0], Path: params[0], Passphrase: passphrase})) // Append params
+	case "Unlock":
+		if len(params) != 1 && len(params) != 2 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		request := &accountsProto.GeneralRequest{PrivatePublicKey: params[0]} // Init request
+
+		if len(params) == 2 { // Check has timeout
+			timeout, err := strconv.ParseUint(params[1], 10, 32) // Get timeout (in seconds)
+			if err != nil {                                      // Check for errors
+				return ErrInvalidParams // Return error
+			}
+
+			request.Timeout = uint32(timeout) // Set timeout
+		}
+
+		passphrase, err := readPassphrase("Passphrase: ") // Read passphrase
+		if err != nil {                                   // Check for errors
+			return err // Return found error
+		}
+
+		request.Passphrase = passphrase // Set passphrase
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
+	case "Address", "PublicKey", "PrivateKey", "String", "Lock", "RemoveWatchOnlyAccount":
 		if len(params) != 1 { // Check for invalid params
 			return ErrInvalidParams // return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{PrivatePublicKey: params[0]})) // Append params
+	case "NewMultisigAccount":
+		if len(params) < 2 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		threshold, err := strconv.ParseUint(params[0], 10, 32) // Get threshold
+		if err != nil {                                        // Check for errors
+			return ErrInvalidParams // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&accountsProto.GeneralRequest{Threshold: uint32(threshold), PublicKeys: params[1:]})) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: NewAccount(), AccountFromKey(), Address(), PublicKey(), PrivateKey(), String()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: NewAccount(), AccountFromKey(), Address(), PublicKey(), PrivateKey(), String(), NewMultisigAccount(), Unlock(), Lock(), ImportAccount(), NewWallet(), RestoreWallet(), DeriveAccount(), GetAllWallets(), AddWatchOnlyAccount(), GetAllWatchOnlyAccounts(), RemoveWatchOnlyAccount()") // Return error
 	}
 
 	result := reflect.ValueOf(*accountsClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -226,7 +363,7 @@ func handleTransaction(transactionClient *transactionProto.Transaction, methodna
 
 	switch methodname { // Handle different methods
 	case "NewTransaction":
-		if len(params) != 8 { // Check for invalid params
+		if len(params) != 8 && len(params) != 9 { // Check for invalid params
 			return ErrInvalidParams // Return error
 		}
 
@@ -250,13 +387,35 @@ func handleTransaction(transactionClient *transactionProto.Transaction, methodna
 
 		gasPrice, _ := strconv.Atoi(params[x+2]) // Get gas price
 
-		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Nonce: uint64(nonce), Amount: []byte(params[1]), Address: params[2], Address2: params[3], TransactionHash: parentHashes, GasLimit: uint64(gasLimit), GasPrice: uint64(gasPrice), Payload: []byte(params[x+3])})) // Append params
-	case "CalculateTotalValue", "SignTransaction", "Verify", "String", "Publish":
+		var validUntil int64 // Init expiry buffer
+
+		if len(params) > x+4 { // Check has expiry
+			validUntil, _ = strconv.ParseInt(params[x+4], 10, 64) // Get expiry (unix time)
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Nonce: uint64(nonce), Amount: []byte(params[1]), Address: params[2], Address2: params[3], TransactionHash: parentHashes, GasLimit: uint64(gasLimit), GasPrice: uint64(gasPrice), Payload: []byte(params[x+3]), ValidUntil: validUntil})) // Append params
+	case "CalculateTotalValue", "Verify", "String", "Publish", "MergeSignatures":
 		if len(params) == 0 { // Check for invalid params
 			return ErrInvalidParams // Return error
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{TransactionHash: params})) // Append params
+	case "SignTransaction":
+		if len(params) == 0 || len(params) > 3 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		request := &transactionProto.GeneralRequest{TransactionHash: params[:1]} // Init request
+
+		for _, param := range params[1:] { // Iterate through options
+			if param == "compact" { // Check compact
+				request.Compact = true // Set compact
+			} else {
+				request.Address = param // Set multi-signature signer
+			}
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(request)) // Append params
 	case "SignMessage":
 		if len(params) != 2 { // Check for invalid params
 			return ErrInvalidParams // Return error
@@ -264,7 +423,7 @@ func handleTransaction(transactionClient *transactionProto.Transaction, methodna
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&transactionProto.GeneralRequest{Address: params[0], Payload: crypto.Sha3([]byte(params[1])).Bytes()})) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: NewTransaction(), CalculateTotalValue(), SignTransaction(), Verify(), String(), SignMessage()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: NewTransaction(), CalculateTotalValue(), SignTransaction(), MergeSignatures(), Verify(), String(), SignMessage()") // Return error
 	}
 
 	result := reflect.ValueOf(*transactionClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -293,7 +452,7 @@ func handleDag(dagClient *dagProto.Dag, methodname string, params []string) erro
 		}
 
 		reflectParams = append(reflectParams, reflect.ValueOf(&dagProto.GeneralRequest{Network: params[0]})) // Append params
-	case "MakeGenesis", "GetBestTransaction":
+	case "MakeGenesis", "GetBestTransaction", "CalculateWatchOnlyBalances":
 		if len(params) != 0 { // Check for invalid params
 			return ErrInvalidParams // Return error
 		}
@@ -304,7 +463,7 @@ func handleDag(dagClient *dagProto.Dag, methodname string, params []string) erro
 	case "GetTransactionsByAddress", "GetTransactionsBySender", "CalculateAddressBalance":
 		reflectParams = append(reflectParams, reflect.ValueOf(&dagProto.GeneralRequest{Address: params[0]})) // Append params
 	default:
-		return errors.New("illegal method: " + methodname + ", available methods: NewDag(), MakeGenesis(), GetBestTransaction(), GetsTransactionByHash(), GetTransactionChildren(), GetTransactionsByAddress(), GetTransactionsBySender(), CalculateAddressBalance()") // Return error
+		return errors.New("illegal method: " + methodname + ", available methods: NewDag(), MakeGenesis(), GetBestTransaction(), GetsTransactionByHash(), GetTransactionChildren(), GetTransactionsByAddress(), GetTransactionsBySender(), CalculateAddressBalance(), CalculateWatchOnlyBalances()") // Return error
 	}
 
 	result := reflect.ValueOf(*dagClient).MethodByName(methodname).Call(reflectParams) // Call method
@@ -320,4 +479,88 @@ func handleDag(dagClient *dagProto.Dag, methodname string, params []string) erro
 	return nil // No error occurred, return nil
 }
 
+// handleMempool handles the mempool receiver.
+func handleMempool(mempoolClient *mempoolProto.Mempool, methodname string, params []string) error {
+	reflectParams := []reflect.Value{} // Init buffer
+
+	reflectParams = append(reflectParams, reflect.ValueOf(context.Background())) // Append request context
+
+	switch methodname { // Handle different methods
+	case "GetPending":
+		if len(params) != 0 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&mempoolProto.GeneralRequest{})) // Append params
+	case "GetTransactionByHash":
+		if len(params) != 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&mempoolProto.GeneralRequest{TransactionHash: params[0]})) // Append params
+	case "GetTransactionsBySender":
+		if len(params) != 1 { // Check for invalid params
+			return ErrInvalidParams // Return error
+		}
+
+		reflectParams = append(reflectParams, reflect.ValueOf(&mempoolProto.GeneralRequest{Address: params[0]})) // Append params
+	default:
+		return errors.New("illegal method: " + methodname + ", available methods: GetPending(), GetTransactionByHash(), GetTransactionsBySender()") // Return error
+	}
+
+	result := reflect.ValueOf(*mempoolClient).MethodByName(methodname).Call(reflectParams) // Call method
+
+	response := result[0].Interface().(*mempoolProto.GeneralResponse) // Get response
+
+	if result[1].Interface() != nil { // Check for errors
+		return result[1].Interface().(error) // Return error
+	}
+
+	fmt.Println("\n" + response.Message) // Log response
+
+	return nil // No error occurred, return nil
+}
+
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// readPassphrase prompts for a passphrase, without echoing it if stdin is a terminal.
+func readPassphrase(prompt string) (string, error) {
+	fmt.Print(prompt) // Print prompt
+
+	if terminal.IsTerminal(int(os.Stdin.Fd())) { // Check is terminal
+		passphrase, err := terminal.ReadPassword(int(os.Stdin.Fd())) // Read passphrase
+
+		fmt.Println() // Print newline
+
+		return string(passphrase), err // Return passphrase
+	}
+
+	if !reader.Scan() { // Scan
+		return "", reader.Err() // Return found error
+	}
+
+	return reader.Text(), nil // Return passphrase
+}
+
+// readNewPassphrase prompts for a new passphrase, and its confirmation.
+func readNewPassphrase() (string, error) {
+	passphrase, err := readPassphrase("Passphrase: ") // Read passphrase
+	if err != nil {                                   // Check for errors
+		return "", err // Return found error
+	}
+
+	confirmation, err := readPassphrase("Repeat passphrase: ") // Read confirmation
+	if err != nil {                                            // Check for errors
+		return "", err // Return found error
+	}
+
+	if passphrase != confirmation { // Check mismatch
+		return "", ErrPassphraseMismatch // Return error
+	}
+
+	return passphrase, nil // Return passphrase
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,156 @@
+// Package main is a stand-in external signer, serving the keys of a keystore over the external signer protocol (for tests, and as a
+// reference implementation for signers backed by hardware or a locked-down process).
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/loggo"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/polaris-project/go-polaris/accounts"
+	"github.com/polaris-project/go-polaris/common"
+)
+
+var (
+	logger = loggo.GetLogger("") // Get logger
+
+	keystoreDirFlag    = flag.String("keystore-dir", common.KeystoreDir, "serve the plaintext and unlocked accounts of a given keystore directory")                // Init keystore dir flag
+	socketFlag         = flag.String("socket", "", "serve on a Unix socket at a given path, rather than on standard input and output")                             // Init socket flag
+	unlockFlag         = flag.String("unlock", "", "unlock the encrypted accounts with a given comma-separated list of addresses before serving")                  // Init unlock flag
+	passphraseFileFlag = flag.String("passphrase-file", "", "unlock accounts with the passphrase in a given file, rather than prompting for each on the terminal") // Init passphrase file flag
+)
+
+// errNoTerminal is an error definition describing a passphrase prompt without a terminal to prompt on.
+var errNoTerminal = errors.New("no terminal to prompt for a passphrase on; use -passphrase-file")
+
+// Main serves the external signer protocol until standard input is closed (or until killed, when serving on a Unix socket).
+func main() {
+	flag.Parse() // Parse flags
+
+	common.KeystoreDir = filepath.FromSlash(*keystoreDirFlag) // Set keystore dir
+
+	if err := unlockAccounts(); err != nil { // Unlock accounts
+		logger.Criticalf("signer panicked: %s", err.Error()) // Log pending panic
+
+		os.Exit(1) // Panic
+	}
+
+	if *socketFlag == "" { // Check serving on standard input and output
+		err := accounts.ServeExternalSigner(accounts.KeystoreSigner{}, struct {
+			io.Reader
+			io.Writer
+		}{os.Stdin, os.Stdout}) // Serve keystore
+
+		if err != nil { // Check for errors
+			logger.Criticalf("signer panicked: %s", err.Error()) // Log pending panic
+
+			os.Exit(1) // Panic
+		}
+
+		return // Standard input closed
+	}
+
+	listener, err := net.Listen("unix", *socketFlag) // Listen on socket
+	if err != nil {                                  // Check for errors
+		logger.Criticalf("signer panicked: %s", err.Error()) // Log pending panic
+
+		os.Exit(1) // Panic
+	}
+
+	for { // Accept connections
+		conn, err := listener.Accept() // Accept connection
+		if err != nil {                // Check for errors
+			logger.Errorf("failed to accept connection: %s", err.Error()) // Log error
+
+			continue // Continue
+		}
+
+		go func(conn net.Conn) {
+			defer conn.Close() // Close connection
+
+			if err := accounts.ServeExternalSigner(accounts.KeystoreSigner{}, conn); err != nil { // Serve keystore
+				logger.Errorf("connection failed: %s", err.Error()) // Log error
+			}
+		}(conn) // Serve connection
+	}
+}
+
+// unlockAccounts unlocks each of the encrypted accounts listed by the unlock flag (until the signer exits), with either the passphrase
+// read from the passphrase file, or a passphrase prompted for on the terminal (standard input and output may be serving the signer).
+func unlockAccounts() error {
+	if *unlockFlag == "" { // Check nothing to unlock
+		return nil // Nothing to unlock
+	}
+
+	passphrase := "" // Init passphrase
+
+	if *passphraseFileFlag != "" { // Check has passphrase file
+		file, err := os.Open(*passphraseFileFlag) // Open passphrase file
+		if err != nil {                           // Check for errors
+			return err // Return found error
+		}
+
+		defer file.Close() // Close passphrase file
+
+		reader := bufio.NewReader(file) // Init reader
+
+		if passphrase, err = reader.ReadString('\n'); err != nil && err != io.EOF { // Read passphrase
+			return err // Return found error
+		}
+
+		passphrase = strings.TrimRight(passphrase, "\r\n") // Trim newline
+	}
+
+	for _, encodedAddress := range strings.Split(*unlockFlag, ",") { // Iterate through addresses
+		addressBytes, err := hex.DecodeString(strings.TrimSpace(encodedAddress)) // Decode address
+		if err != nil {                                                          // Check for errors
+			return err // Return found error
+		}
+
+		address := common.NewAddress(addressBytes) // Init address
+
+		accountPassphrase := passphrase // Init account passphrase
+
+		if *passphraseFileFlag == "" { // Check must prompt
+			if accountPassphrase, err = promptPassphrase(fmt.Sprintf("Passphrase for %x: ", address.Bytes())); err != nil { // Prompt for passphrase
+				return err // Return found error
+			}
+		}
+
+		if err = accounts.Unlock(address, accountPassphrase, 0); err != nil { // Unlock account
+			return fmt.Errorf("failed to unlock account %x: %s", address.Bytes(), err.Error()) // Return found error
+		}
+
+		logger.Infof("unlocked account %x", address.Bytes()) // Log unlocked
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// promptPassphrase prompts for a passphrase on the controlling terminal, without echoing it.
+func promptPassphrase(prompt string) (string, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0) // Open terminal
+	if err != nil {                                   // Check for errors
+		return "", errNoTerminal // Return error
+	}
+
+	defer tty.Close() // Close terminal
+
+	fmt.Fprint(tty, prompt) // Print prompt
+
+	passphrase, err := terminal.ReadPassword(int(tty.Fd())) // Read passphrase
+
+	fmt.Fprintln(tty) // Print newline
+
+	return string(passphrase), err // Return passphrase
+}
@@ -0,0 +1,87 @@
+// Package common defines a set of commonly used helper methods and data types.
+package common
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Decimals is the number of decimal places of a single unit of polaris.
+// All amounts (balances, transaction amounts, gas prices and genesis allocations) are integer numbers of base units,
+// each worth 10^-Decimals of a unit.
+const Decimals = 9
+
+// ErrInvalidAmount represents an error describing an amount string that is not a valid, non-negative decimal number of units
+// with at most Decimals decimal places.
+var ErrInvalidAmount = errors.New("invalid amount")
+
+/* BEGIN EXPORTED METHODS */
+
+// UnitsToBaseUnits converts a given number of whole units to base units.
+func UnitsToBaseUnits(units int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(units), baseUnitsPerUnit()) // Return base units
+}
+
+// ParseAmount parses a given decimal number of units (e.g. "1.5") to base units, exactly.
+// Returns an ErrInvalidAmount error if the amount is negative, malformed, or has more than Decimals decimal places.
+func ParseAmount(s string) (*big.Int, error) {
+	whole, fraction := s, "" // Init split buffers
+
+	if i := strings.IndexByte(s, '.'); i >= 0 { // Check has decimal point
+		whole, fraction = s[:i], s[i+1:] // Split
+	}
+
+	if whole == "" || len(fraction) > Decimals || !isDigits(whole) || !isDigits(fraction) { // Check invalid amount
+		return nil, ErrInvalidAmount // Return error
+	}
+
+	amount, _ := new(big.Int).SetString(whole+fraction+strings.Repeat("0", Decimals-len(fraction)), 10) // Parse base units
+
+	return amount, nil // Return amount
+}
+
+// FormatAmount formats a given number of base units as a decimal number of units (e.g. "1.5"), exactly.
+func FormatAmount(amount *big.Int) string {
+	if amount == nil { // Check nil
+		return "0" // No amount
+	}
+
+	quotient, remainder := new(big.Int).QuoRem(new(big.Int).Abs(amount), baseUnitsPerUnit(), new(big.Int)) // Split whole and fractional units
+
+	formatted := quotient.String() // Format whole units
+
+	if remainder.Sign() != 0 { // Check has fractional units
+		fraction := remainder.String() // Format fractional units
+
+		formatted += "." + strings.TrimRight(strings.Repeat("0", Decimals-len(fraction))+fraction, "0") // Append fraction
+	}
+
+	if amount.Sign() < 0 { // Check negative
+		formatted = "-" + formatted // Prepend sign
+	}
+
+	return formatted // Return formatted amount
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// baseUnitsPerUnit returns the number of base units in a single unit (10^Decimals).
+func baseUnitsPerUnit() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil) // Return 10^Decimals
+}
+
+// isDigits checks whether a given string consists only of ascii digits.
+func isDigits(s string) bool {
+	for _, c := range s { // Iterate through characters
+		if c < '0' || c > '9' { // Check not digit
+			return false // Not digits
+		}
+	}
+
+	return true // Only digits
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,44 @@
+// Package common defines a set of commonly used helper methods and data types.
+package common
+
+import (
+	"testing"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestParseAmount tests the functionality of the ParseAmount() and FormatAmount() helper methods.
+func TestParseAmount(t *testing.T) {
+	for _, testCase := range []struct {
+		amount    string // Amount to parse
+		baseUnits string // Expected base units
+		formatted string // Expected formatted amount
+	}{
+		{"1", "1000000000", "1"},
+		{"1.5", "1500000000", "1.5"},
+		{"0.000000001", "1", "0.000000001"},
+		{"10.", "10000000000", "10"},
+		{"1000000000000000000", "1000000000000000000000000000", "1000000000000000000"},
+	} { // Iterate through test cases
+		amount, err := ParseAmount(testCase.amount) // Parse amount
+		if err != nil {                             // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if amount.String() != testCase.baseUnits { // Check invalid base units
+			t.Errorf("ParseAmount(%s) should be %s base units; got %s", testCase.amount, testCase.baseUnits, amount.String()) // Log error
+		}
+
+		if formatted := FormatAmount(amount); formatted != testCase.formatted { // Check invalid format
+			t.Errorf("FormatAmount(%s) should be %s; got %s", amount.String(), testCase.formatted, formatted) // Log error
+		}
+	}
+
+	for _, invalid := range []string{"", "-1", ".5", "1.0000000001", "1e9", "1,5"} { // Iterate through invalid amounts
+		if _, err := ParseAmount(invalid); err != ErrInvalidAmount { // Parse invalid amount
+			t.Errorf("ParseAmount(%q) should return ErrInvalidAmount; got %v", invalid, err) // Log error
+		}
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
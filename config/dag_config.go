@@ -5,58 +5,163 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
 )
 
+// configVersion is the current version of the dag config format.
+// Version 0 (legacy) configs specify allocations as decimal numbers of whole units, while version 1 configs specify them
+// as integer numbers of base units.
+const configVersion = uint64(1)
+
 // DagConfigRequest represents the global dag config request message byte value.
 var DagConfigRequest = []byte("dag_config_req")
 
 // DagConfig represents a DAG configuration.
 type DagConfig struct {
-	Alloc map[string]float64 `json:"alloc"` // Account balances at genesis
+	Alloc map[string]*big.Int `json:"alloc"` // Account balances at genesis (in base units)
 
 	Identifier string `json:"identifier"` // Dag/network name (e.g. "mainnet_beta", "mainnet_alpha")
 
 	Network uint64 `json:"network"` // Dag version (e.g. 0 => mainnet, 1 => testnet, etc...)
+
+	SignatureSchemes []crypto.SignatureScheme `json:"signature_schemes,omitempty"` // Signature schemes accepted in transactions (only P-521 if empty)
+
+	ConfigVersion uint64 `json:"config_version"` // Config format version
 }
 
 /* BEGIN EXPORTED METHODS */
 
 // NewDagConfig initializes a new DagConfig from a given set of parameters.
-func NewDagConfig(alloc map[string]float64, identifier string, network uint64) *DagConfig {
+func NewDagConfig(alloc map[string]*big.Int, identifier string, network uint64) *DagConfig {
 	return &DagConfig{
-		Alloc:      alloc,      // Set alloc
-		Identifier: identifier, // Set identifier
-		Network:    network,    // Set network
+		Alloc:         alloc,         // Set alloc
+		Identifier:    identifier,    // Set identifier
+		Network:       network,       // Set network
+		ConfigVersion: configVersion, // Set config version
 	} // Return initialized dag config
 }
 
 // NewDagConfigFromGenesis generates a new DagConfig from the given genesis.json file.
+// Legacy genesis files (without a config_version, allocating decimal numbers of whole units) are converted to base units.
 func NewDagConfigFromGenesis(genesisFilePath string) (*DagConfig, error) {
 	rawJSON, err := ioutil.ReadFile(genesisFilePath) // Read genesis file
 	if err != nil {                                  // Check for errors
 		return &DagConfig{}, err // Return found error
 	}
 
-	var readJSON map[string]interface{} // Init buffer
+	dagConfig := &DagConfig{} // Init buffer
 
-	err = json.Unmarshal(rawJSON, &readJSON) // Unmarshal to buffer
+	err = json.Unmarshal(rawJSON, dagConfig) // Unmarshal to buffer
 
 	if err != nil { // Check for errors
 		return &DagConfig{}, err // Return found error
 	}
 
-	alloc := make(map[string]float64) // Init alloc map
+	return dagConfig, nil // Return read config
+}
+
+// UnmarshalJSON deserializes a given dag config from json, converting legacy (version 0) allocations from
+// whole units to base units.
+func (dagConfig *DagConfig) UnmarshalJSON(b []byte) error {
+	var readJSON struct {
+		Alloc map[string]json.RawMessage `json:"alloc"` // Raw allocations
+
+		Identifier string `json:"identifier"` // Identifier
+
+		Network uint64 `json:"network"` // Network
+
+		SignatureSchemes []crypto.SignatureScheme `json:"signature_schemes"` // Accepted signature schemes
+
+		ConfigVersion uint64 `json:"config_version"` // Config format version
+	} // Init buffer
+
+	if err := json.Unmarshal(b, &readJSON); err != nil { // Unmarshal to buffer
+		return err // Return found error
+	}
+
+	if readJSON.ConfigVersion > configVersion { // Check unknown version
+		return fmt.Errorf("unsupported dag config version %d", readJSON.ConfigVersion) // Return error
+	}
+
+	var alloc map[string]*big.Int // Init alloc buffer
 
-	for key, value := range readJSON["alloc"].(map[string]interface{}) { // Iterate through genesis addresses
-		alloc[key] = value.(float64) // Set alloc for address
+	if readJSON.Alloc != nil { // Check has alloc
+		alloc = make(map[string]*big.Int) // Init alloc map
 	}
 
-	return &DagConfig{
-		Network:    uint64(readJSON["network"].(float64)), // Set network
-		Identifier: readJSON["identifier"].(string),       // Set ID
-		Alloc:      alloc,                                 // Set supply allocation
-	}, nil
+	for key, value := range readJSON.Alloc { // Iterate through genesis addresses
+		amount, err := parseAllocAmount(strings.Trim(string(value), `"`), readJSON.ConfigVersion) // Parse allocated amount
+		if err != nil {                                                                           // Check for errors
+			return fmt.Errorf("invalid alloc for address %s: %s", key, err.Error()) // Return found error
+		}
+
+		alloc[key] = amount // Set alloc for address
+	}
+
+	*dagConfig = DagConfig{
+		Alloc:            alloc,                     // Set supply allocation
+		Identifier:       readJSON.Identifier,       // Set ID
+		Network:          readJSON.Network,          // Set network
+		SignatureSchemes: readJSON.SignatureSchemes, // Set accepted signature schemes
+		ConfigVersion:    configVersion,             // Set (migrated) config version
+	} // Set config
+
+	return nil // No error occurred, return nil
+}
+
+// AcceptedSignatureSchemes gets the signature schemes accepted in the dag's transactions (only P-521 if none are specified).
+func (dagConfig *DagConfig) AcceptedSignatureSchemes() []crypto.SignatureScheme {
+	if len(dagConfig.SignatureSchemes) == 0 { // Check no schemes specified
+		return []crypto.SignatureScheme{crypto.P521} // Return default scheme
+	}
+
+	return dagConfig.SignatureSchemes // Return schemes
+}
+
+// AcceptsSignatureScheme checks whether a given signature scheme is accepted in the dag's transactions.
+func (dagConfig *DagConfig) AcceptsSignatureScheme(scheme crypto.SignatureScheme) bool {
+	for _, acceptedScheme := range dagConfig.AcceptedSignatureSchemes() { // Iterate through accepted schemes
+		if acceptedScheme == scheme { // Check scheme accepted
+			return true // Accepted
+		}
+	}
+
+	return false // Not accepted
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// parseAllocAmount parses a given allocated amount, written in a config of a given version, to base units.
+func parseAllocAmount(value string, version uint64) (*big.Int, error) {
+	if version > 0 { // Check current version
+		amount, ok := new(big.Int).SetString(value, 10) // Parse base units
+		if !ok || amount.Sign() < 0 {                   // Check invalid amount
+			return nil, common.ErrInvalidAmount // Return error
+		}
+
+		return amount, nil // Return amount
+	}
+
+	units, ok := new(big.Rat).SetString(value) // Parse legacy decimal number of units (e.g. "1.5", "1e+21")
+	if !ok || units.Sign() < 0 {               // Check invalid amount
+		return nil, common.ErrInvalidAmount // Return error
+	}
+
+	baseUnits := units.Mul(units, new(big.Rat).SetInt(common.UnitsToBaseUnits(1))) // Convert to base units
+
+	if !baseUnits.IsInt() { // Check more than common.Decimals decimal places
+		return nil, common.ErrInvalidAmount // Return error
+	}
+
+	return new(big.Int).Set(baseUnits.Num()), nil // Return amount
+}
+
+/* END INTERNAL METHODS */
@@ -3,7 +3,13 @@
 // supply allocations, the dag identifier, and other metadata.
 package config
 
-import "testing"
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/polaris-project/go-polaris/crypto"
+)
 
 /* BEGIN EXPORTED METHODS TESTS */
 
@@ -21,7 +27,38 @@ func TestNewDagConfigFromGenesis(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
+	expected, _ := new(big.Int).SetString("1000000000000000000000000000", 10) // Legacy allocation of 10^18 units, in base units
+
+	if alloc := dagConfig.Alloc["0x040028d536d5351e83fbbec320c194629ace"]; alloc == nil || alloc.Cmp(expected) != 0 { // Check legacy alloc not converted
+		t.Fatalf("legacy alloc should have been converted to %s base units; got %v", expected.String(), alloc) // Panic
+	}
+
+	if dagConfig.ConfigVersion != configVersion { // Check not migrated
+		t.Fatalf("config version should be %d; got %d", configVersion, dagConfig.ConfigVersion) // Panic
+	}
+
 	t.Log(dagConfig) // Log success
 }
 
+// TestAcceptsSignatureScheme tests the functionality of the AcceptsSignatureScheme() dag config helper method.
+func TestAcceptsSignatureScheme(t *testing.T) {
+	if dagConfig := NewDagConfig(nil, "test_dag_config", 1); !dagConfig.AcceptsSignatureScheme(crypto.P521) || dagConfig.AcceptsSignatureScheme(crypto.Ed25519) { // Check default schemes
+		t.Fatal("a dag config without signature schemes should only accept p521 signatures") // Panic
+	}
+
+	dagConfig := &DagConfig{} // Init config buffer
+
+	if err := json.Unmarshal([]byte(`{"identifier": "test_dag_config", "signature_schemes": ["ed25519", "secp256k1"], "config_version": 1}`), dagConfig); err != nil { // Unmarshal config
+		t.Fatal(err) // Panic
+	}
+
+	if dagConfig.AcceptsSignatureScheme(crypto.P521) || !dagConfig.AcceptsSignatureScheme(crypto.Ed25519) || !dagConfig.AcceptsSignatureScheme(crypto.Secp256k1) { // Check invalid schemes
+		t.Fatal("dag config should only accept the listed signature schemes") // Panic
+	}
+
+	if err := json.Unmarshal([]byte(`{"identifier": "test_dag_config", "signature_schemes": ["rsa"]}`), dagConfig); err == nil { // Unmarshal config with unknown scheme
+		t.Fatal("unmarshaling a config with an unknown signature scheme should fail") // Panic
+	}
+}
+
 /* END EXPORTED METHODS TESTS */
@@ -5,6 +5,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 
+	"github.com/btcsuite/btcd/btcec"
 	"github.com/polaris-project/go-polaris/common"
 )
 
@@ -16,7 +17,12 @@ func AddressFromPrivateKey(privateKey *ecdsa.PrivateKey) *common.Address {
 }
 
 // AddressFromPublicKey serializes and converts an ecdsa public key into an address.
+// secp256k1 public keys are converted as defined by the Secp256k1 signature scheme; all other keys are treated as P-521 public keys.
 func AddressFromPublicKey(publicKey *ecdsa.PublicKey) *common.Address {
+	if publicKey.Curve == btcec.S256() { // Check is secp256k1
+		return schemeAddress(Secp256k1, (*btcec.PublicKey)(publicKey).SerializeCompressed()) // Return address value
+	}
+
 	publicKeyBytes := elliptic.Marshal(elliptic.P521(), publicKey.X, publicKey.Y) // Marshal public key
 
 	return common.NewAddress(Sha3(publicKeyBytes).Bytes()) // Return address value
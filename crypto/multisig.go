@@ -0,0 +1,174 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	"bytes"
+	gocrypto "crypto"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// MaxMultisigKeys is the maximum number of keys a multi-signature policy may consist of.
+const MaxMultisigKeys = 16
+
+// multisigAddressPrefix prefixes the preimage of every multi-signature address, so that no multi-signature address can share
+// an address with a single key of any signature scheme.
+const multisigAddressPrefix = byte(0xff)
+
+var (
+	// ErrInvalidMultisigThreshold is an error definition representing a multi-signature threshold of zero, or of more than the
+	// policy's number of keys.
+	ErrInvalidMultisigThreshold = errors.New("multi-signature threshold must be between 1 and the number of keys")
+
+	// ErrInvalidMultisigKeyCount is an error definition representing a multi-signature policy with no keys, or with more than
+	// MaxMultisigKeys keys.
+	ErrInvalidMultisigKeyCount = errors.New("invalid number of multi-signature keys")
+
+	// ErrDuplicateMultisigKey is an error definition representing a key listed more than once in a multi-signature policy.
+	ErrDuplicateMultisigKey = errors.New("duplicate multi-signature key")
+
+	// ErrNonCanonicalMultisigPolicy is an error definition representing a multi-signature policy whose keys are not sorted.
+	ErrNonCanonicalMultisigPolicy = errors.New("multi-signature keys are not sorted")
+)
+
+// MultisigKey is a public key of any registered signature scheme participating in a multi-signature policy.
+type MultisigKey struct {
+	Scheme SignatureScheme `json:"scheme"` // Signature scheme
+
+	MarshaledPublicKey []byte `json:"pub"` // Marshaled public key (see SignatureAlgorithm.MarshalPublicKey())
+}
+
+// MultisigPolicy is an M-of-N multi-signature policy: a set of N keys, any Threshold (M) of which must sign a message.
+// The keys of a policy are always sorted (by scheme, then public key), so that a set of keys and a threshold derive exactly one address.
+type MultisigPolicy struct {
+	Threshold uint32 `json:"threshold"` // Number of signatures required
+
+	Keys []MultisigKey `json:"keys"` // Participating keys
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMultisigKey initializes a new multi-signature key from a given public key of any registered signature scheme.
+// Returns an ErrUnsupportedKey error if the key does not belong to any registered scheme.
+func NewMultisigKey(publicKey gocrypto.PublicKey) (MultisigKey, error) {
+	for scheme, algorithm := range signatureAlgorithms { // Iterate through algorithms
+		if !algorithm.OwnsKey(publicKey) { // Check does not own key
+			continue // Continue
+		}
+
+		marshaledPublicKey, err := algorithm.MarshalPublicKey(publicKey) // Marshal public key
+		if err != nil {                                                  // Check for errors
+			return MultisigKey{}, err // Return found error
+		}
+
+		return MultisigKey{Scheme: scheme, MarshaledPublicKey: marshaledPublicKey}, nil // Return initialized key
+	}
+
+	return MultisigKey{}, ErrUnsupportedKey // Return error
+}
+
+// NewMultisigPolicy initializes a new multi-signature policy requiring a given number of signatures from a given set of keys,
+// sorting the keys.
+func NewMultisigPolicy(threshold uint32, keys []MultisigKey) (*MultisigPolicy, error) {
+	sortedKeys := append([]MultisigKey{}, keys...) // Copy keys
+
+	sort.Slice(sortedKeys, func(i, j int) bool { return compareMultisigKeys(sortedKeys[i], sortedKeys[j]) < 0 }) // Sort keys
+
+	policy := &MultisigPolicy{
+		Threshold: threshold,  // Set threshold
+		Keys:      sortedKeys, // Set keys
+	} // Initialize policy
+
+	if err := policy.Validate(); err != nil { // Validate policy
+		return nil, err // Return found error
+	}
+
+	return policy, nil // Return initialized policy
+}
+
+// Validate checks that a given multi-signature policy has a valid threshold and number of keys, that each of its keys is a valid
+// public key of a registered signature scheme, and that its keys are sorted and unique.
+func (policy *MultisigPolicy) Validate() error {
+	if len(policy.Keys) == 0 || len(policy.Keys) > MaxMultisigKeys { // Check invalid key count
+		return ErrInvalidMultisigKeyCount // Return error
+	}
+
+	if policy.Threshold == 0 || int(policy.Threshold) > len(policy.Keys) { // Check invalid threshold
+		return ErrInvalidMultisigThreshold // Return error
+	}
+
+	for i, key := range policy.Keys { // Iterate through keys
+		algorithm, err := GetSignatureAlgorithm(key.Scheme) // Get signature algorithm
+		if err != nil {                                     // Check for errors
+			return err // Return found error
+		}
+
+		if _, err = algorithm.Address(key.MarshaledPublicKey); err != nil { // Check invalid public key
+			return err // Return found error
+		}
+
+		if i == 0 { // Check first key
+			continue // Nothing to compare
+		}
+
+		switch compareMultisigKeys(policy.Keys[i-1], key) {
+		case 0:
+			return ErrDuplicateMultisigKey // Return error
+		case 1:
+			return ErrNonCanonicalMultisigPolicy // Return error
+		}
+	}
+
+	return nil // Policy is valid
+}
+
+// KeyIndex gets the index of a given key in a given multi-signature policy (-1 if the key does not participate in the policy).
+func (policy *MultisigPolicy) KeyIndex(scheme SignatureScheme, marshaledPublicKey []byte) int {
+	for i, key := range policy.Keys { // Iterate through keys
+		if compareMultisigKeys(key, MultisigKey{Scheme: scheme, MarshaledPublicKey: marshaledPublicKey}) == 0 { // Check matching key
+			return i // Return index
+		}
+	}
+
+	return -1 // Key not found
+}
+
+// Address derives the address of a given multi-signature policy: the sha3 hash of a multi-signature prefix byte, followed by the
+// policy's threshold (uint32), and the scheme (1 byte) and length-prefixed public key of each of its keys.
+func (policy *MultisigPolicy) Address() *common.Address {
+	buffer := bytes.NewBuffer([]byte{multisigAddressPrefix}) // Write prefix
+
+	binary.Write(buffer, binary.BigEndian, policy.Threshold) // Write threshold
+
+	for _, key := range policy.Keys { // Iterate through keys
+		buffer.WriteByte(byte(key.Scheme)) // Write scheme
+
+		binary.Write(buffer, binary.BigEndian, uint32(len(key.MarshaledPublicKey))) // Write public key length
+
+		buffer.Write(key.MarshaledPublicKey) // Write public key
+	}
+
+	return common.NewAddress(Sha3(buffer.Bytes()).Bytes()) // Return address value
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// compareMultisigKeys compares two given multi-signature keys by scheme, then by public key (-1 if a precedes b, 0 if they are
+// the same key, and 1 if b precedes a).
+func compareMultisigKeys(a MultisigKey, b MultisigKey) int {
+	switch {
+	case a.Scheme < b.Scheme:
+		return -1 // a precedes b
+	case a.Scheme > b.Scheme:
+		return 1 // b precedes a
+	default:
+		return bytes.Compare(a.MarshaledPublicKey, b.MarshaledPublicKey) // Compare public keys
+	}
+}
+
+/* END INTERNAL METHODS */
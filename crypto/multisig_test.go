@@ -0,0 +1,69 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import "testing"
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestNewMultisigPolicy tests the functionality of the NewMultisigPolicy() helper method.
+func TestNewMultisigPolicy(t *testing.T) {
+	var keys []MultisigKey // Init key buffer
+
+	for _, scheme := range []SignatureScheme{Secp256k1, P521, Ed25519} { // Iterate through schemes
+		privateKey, err := GenerateKey(scheme) // Generate private key
+		if err != nil {                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		key, err := NewMultisigKey(privateKey.Public()) // Initialize multi-signature key
+		if err != nil {                                 // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		keys = append(keys, key) // Append key
+	}
+
+	policy, err := NewMultisigPolicy(2, keys) // Initialize policy
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	reorderedPolicy, err := NewMultisigPolicy(2, []MultisigKey{keys[2], keys[0], keys[1]}) // Initialize policy with reordered keys
+	if err != nil {                                                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if *policy.Address() != *reorderedPolicy.Address() { // Check order-dependent address
+		t.Fatal("policy address should not depend on the order of its keys") // Panic
+	}
+
+	if policy.KeyIndex(keys[0].Scheme, keys[0].MarshaledPublicKey) != 2 { // Check keys not sorted
+		t.Fatal("policy keys should be sorted by scheme") // Panic
+	}
+
+	otherPolicy, _ := NewMultisigPolicy(1, keys) // Initialize policy with different threshold
+
+	if *policy.Address() == *otherPolicy.Address() { // Check threshold not committed to
+		t.Fatal("policies with different thresholds should have different addresses") // Panic
+	}
+
+	if _, err = NewMultisigPolicy(4, keys); err != ErrInvalidMultisigThreshold { // Check threshold above key count
+		t.Fatalf("should have returned ErrInvalidMultisigThreshold; got %v", err) // Panic
+	}
+
+	if _, err = NewMultisigPolicy(1, []MultisigKey{keys[0], keys[0]}); err != ErrDuplicateMultisigKey { // Check duplicate key
+		t.Fatalf("should have returned ErrDuplicateMultisigKey; got %v", err) // Panic
+	}
+
+	if _, err = NewMultisigPolicy(1, []MultisigKey{{Scheme: Ed25519, MarshaledPublicKey: []byte{1}}}); err != ErrInvalidPublicKey { // Check invalid key
+		t.Fatalf("should have returned ErrInvalidPublicKey; got %v", err) // Panic
+	}
+
+	policy.Keys[0], policy.Keys[1] = policy.Keys[1], policy.Keys[0] // Unsort keys
+
+	if err = policy.Validate(); err != ErrNonCanonicalMultisigPolicy { // Check unsorted keys
+		t.Fatalf("should have returned ErrNonCanonicalMultisigPolicy; got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
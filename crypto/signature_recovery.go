@@ -0,0 +1,165 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+)
+
+// maxRecoveryID is the largest ECDSA public key recovery ID: bit 0 holds the parity of the y coordinate of the signature's
+// ephemeral point R, and bit 1 whether R's x coordinate exceeds the curve order.
+const maxRecoveryID = byte(3)
+
+var (
+	// ErrUnrecoverableSignatureScheme is an error definition representing a signature scheme whose public keys cannot be
+	// recovered from a signature.
+	ErrUnrecoverableSignatureScheme = errors.New("public keys of signature scheme cannot be recovered from signatures")
+
+	// ErrInvalidRecoverableSignature is an error definition representing a signature from which no public key could be recovered.
+	ErrInvalidRecoverableSignature = errors.New("could not recover public key from signature")
+)
+
+// RecoverableSignatureAlgorithm is a signature algorithm whose public keys can be recovered from a signature and the signed
+// message hash (given a small recovery ID), so that signatures need not carry their public key.
+type RecoverableSignatureAlgorithm interface {
+	SignatureAlgorithm
+
+	SignRecoverable(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, byte, error) // Sign a given message hash, returning the signature's recovery ID
+
+	RecoverPublicKey(messageHash []byte, r *big.Int, s *big.Int, recoveryID byte) ([]byte, error) // Recover the marshaled public key of a signature of a given message hash
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// GetRecoverableSignatureAlgorithm gets the algorithm registered for a given signature scheme, if public keys of the scheme can
+// be recovered from signatures.
+// Returns an ErrUnrecoverableSignatureScheme error if the scheme's public keys cannot be recovered.
+func GetRecoverableSignatureAlgorithm(scheme SignatureScheme) (RecoverableSignatureAlgorithm, error) {
+	algorithm, err := GetSignatureAlgorithm(scheme) // Get algorithm
+	if err != nil {                                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	recoverableAlgorithm, ok := algorithm.(RecoverableSignatureAlgorithm) // Get recoverable algorithm
+	if !ok {                                                              // Check not recoverable
+		return nil, ErrUnrecoverableSignatureScheme // Return error
+	}
+
+	return recoverableAlgorithm, nil // Return algorithm
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// recoverNISTPublicKey recovers the public key of an ECDSA signature (r, s) of a given message hash over a given NIST curve
+// (y^2 = x^3 - 3x + b, with p = 3 mod 4), given the signature's recovery ID.
+func recoverNISTPublicKey(curve elliptic.Curve, messageHash []byte, r *big.Int, s *big.Int, recoveryID byte) (*ecdsa.PublicKey, error) {
+	params := curve.Params() // Get curve params
+
+	if recoveryID > maxRecoveryID || r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(params.N) >= 0 || s.Cmp(params.N) >= 0 { // Check invalid signature
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	x := new(big.Int).Set(r) // Init x coordinate of R
+
+	if recoveryID&2 != 0 { // Check x exceeds curve order
+		x.Add(x, params.N) // Add curve order
+	}
+
+	if x.Cmp(params.P) >= 0 { // Check x not in field
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	y := nistCurveY(params, x, recoveryID&1 == 1) // Calculate y coordinate of R
+	if y == nil {                                 // Check x not on curve
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	rInverse := new(big.Int).ModInverse(r, params.N) // Calculate r^-1
+
+	e := hashToInt(messageHash, params.N) // Get message hash int
+
+	u1 := new(big.Int).Neg(e) // Init -e
+	u1.Mul(u1, rInverse)      // Calculate -e * r^-1
+	u1.Mod(u1, params.N)      // Reduce mod n
+
+	u2 := new(big.Int).Mul(s, rInverse) // Calculate s * r^-1
+	u2.Mod(u2, params.N)                // Reduce mod n
+
+	x1, y1 := curve.ScalarBaseMult(u1.Bytes())   // Calculate -e * r^-1 * G
+	x2, y2 := curve.ScalarMult(x, y, u2.Bytes()) // Calculate s * r^-1 * R
+
+	qx, qy := curve.Add(x1, y1, x2, y2) // Calculate public key Q
+
+	if qx.Sign() == 0 && qy.Sign() == 0 { // Check point at infinity
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: qx, Y: qy}, nil // Return public key
+}
+
+// nistRecoveryID finds the recovery ID of an ECDSA signature (r, s) of a given message hash by a given public key over a NIST curve.
+func nistRecoveryID(publicKey *ecdsa.PublicKey, messageHash []byte, r *big.Int, s *big.Int) (byte, error) {
+	for recoveryID := byte(0); recoveryID <= maxRecoveryID; recoveryID++ { // Iterate through recovery IDs
+		recovered, err := recoverNISTPublicKey(publicKey.Curve, messageHash, r, s, recoveryID)    // Recover public key
+		if err == nil && recovered.X.Cmp(publicKey.X) == 0 && recovered.Y.Cmp(publicKey.Y) == 0 { // Check recovered signer key
+			return recoveryID, nil // Return recovery ID
+		}
+	}
+
+	return 0, ErrInvalidRecoverableSignature // Return error
+}
+
+// nistCurveY calculates the y coordinate with a given parity of the point with a given x coordinate on a NIST curve.
+// Returns nil if no point with the x coordinate is on the curve.
+func nistCurveY(params *elliptic.CurveParams, x *big.Int, odd bool) *big.Int {
+	ySquared := new(big.Int).Exp(x, big.NewInt(3), params.P) // Calculate x^3
+
+	threeX := new(big.Int).Lsh(x, 1) // Calculate 2x
+	threeX.Add(threeX, x)            // Calculate 3x
+
+	ySquared.Sub(ySquared, threeX)   // Calculate x^3 - 3x
+	ySquared.Add(ySquared, params.B) // Calculate x^3 - 3x + b
+	ySquared.Mod(ySquared, params.P) // Reduce mod p
+
+	exponent := new(big.Int).Add(params.P, big.NewInt(1)) // Calculate p + 1
+	exponent.Rsh(exponent, 2)                             // Calculate (p + 1) / 4
+
+	y := new(big.Int).Exp(ySquared, exponent, params.P) // Calculate square root
+
+	if new(big.Int).Exp(y, big.NewInt(2), params.P).Cmp(ySquared) != 0 { // Check no square root
+		return nil // Not on curve
+	}
+
+	if (y.Bit(0) == 1) != odd { // Check wrong parity
+		y.Sub(params.P, y) // Negate y
+	}
+
+	return y // Return y
+}
+
+// hashToInt converts a given message hash to an integer as defined by ECDSA, truncating the hash to the bit length of a given
+// curve order.
+func hashToInt(messageHash []byte, order *big.Int) *big.Int {
+	orderBits := order.BitLen() // Get order bit length
+
+	orderBytes := (orderBits + 7) / 8 // Get order byte length
+
+	if len(messageHash) > orderBytes { // Check hash longer than order
+		messageHash = messageHash[:orderBytes] // Truncate hash
+	}
+
+	e := new(big.Int).SetBytes(messageHash) // Get hash int
+
+	if excess := len(messageHash)*8 - orderBits; excess > 0 { // Check excess bits
+		e.Rsh(e, uint(excess)) // Truncate excess bits
+	}
+
+	return e // Return hash int
+}
+
+/* END INTERNAL METHODS */
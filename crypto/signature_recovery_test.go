@@ -0,0 +1,51 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestRecoverPublicKey tests the functionality of the SignRecoverable() and RecoverPublicKey() methods of every recoverable
+// signature algorithm.
+func TestRecoverPublicKey(t *testing.T) {
+	for _, scheme := range []SignatureScheme{P521, Secp256k1} { // Iterate through recoverable schemes
+		algorithm, err := GetRecoverableSignatureAlgorithm(scheme) // Get algorithm
+		if err != nil {                                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		for i := 0; i < 8; i++ { // Sign several messages (covering both R parities)
+			privateKey, err := algorithm.GenerateKey() // Generate private key
+			if err != nil {                            // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			marshaledPublicKey, _ := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+
+			messageHash := Sha3([]byte{byte(i)}).Bytes() // Get message hash
+
+			r, s, recoveryID, err := algorithm.SignRecoverable(privateKey, messageHash) // Sign
+			if err != nil {                                                             // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			recovered, err := algorithm.RecoverPublicKey(messageHash, r, s, recoveryID) // Recover public key
+			if err != nil {                                                             // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if !bytes.Equal(recovered, marshaledPublicKey) || !algorithm.Verify(recovered, messageHash, r, s) { // Check invalid recovered key
+				t.Fatalf("should have recovered %s signer public key", scheme) // Panic
+			}
+		}
+	}
+
+	if _, err := GetRecoverableSignatureAlgorithm(Ed25519); err != ErrUnrecoverableSignatureScheme { // Get unrecoverable algorithm
+		t.Fatalf("should have returned ErrUnrecoverableSignatureScheme; got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -0,0 +1,206 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/rand"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// SignatureScheme is an identifier of a digital signature algorithm.
+type SignatureScheme byte
+
+const (
+	// P521 is the ECDSA over NIST P-521 signature scheme (the scheme of all signatures made before signature schemes were introduced).
+	P521 SignatureScheme = iota
+
+	// Ed25519 is the Ed25519 signature scheme.
+	Ed25519
+
+	// Secp256k1 is the ECDSA over secp256k1 signature scheme.
+	Secp256k1
+)
+
+var (
+	// ErrUnknownSignatureScheme is an error definition representing a signature scheme with no registered algorithm.
+	ErrUnknownSignatureScheme = errors.New("unknown signature scheme")
+
+	// ErrUnsupportedKey is an error definition representing a key that does not belong to any registered signature scheme.
+	ErrUnsupportedKey = errors.New("key does not belong to any registered signature scheme")
+
+	// ErrInvalidPublicKey is an error definition representing a marshaled public key that could not be decoded.
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// ErrInvalidSignature is an error definition representing a signature returned by a private key that could not be decoded.
+	ErrInvalidSignature = errors.New("private key returned an invalid signature")
+)
+
+// SignatureAlgorithm is an implementation of a signature scheme.
+// Signatures are represented by a pair of integers (r, s); schemes not natively producing such a pair split their signatures in two.
+// Private keys need not be held in memory: any crypto.Signer with a public key of the scheme (e.g. a key held by a hardware device or
+// an external process) can sign, provided its Sign method signs the given digest as-is (a SHA3-256 hash for ECDSA schemes, and a
+// message with crypto.Hash(0) for Ed25519), returning an ASN.1 DER encoded signature for ECDSA schemes.
+type SignatureAlgorithm interface {
+	Name() string // Get the name of the scheme (e.g. "ed25519")
+
+	GenerateKey() (gocrypto.Signer, error) // Generate a new private key
+
+	OwnsKey(publicKey gocrypto.PublicKey) bool // Check whether a given public key belongs to the scheme
+
+	MarshalPublicKey(publicKey gocrypto.PublicKey) ([]byte, error) // Marshal a given public key
+
+	UnmarshalPublicKey(marshaledPublicKey []byte) (gocrypto.PublicKey, error) // Unmarshal a given marshaled public key
+
+	Address(marshaledPublicKey []byte) (*common.Address, error) // Derive the address of a given marshaled public key
+
+	Sign(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, error) // Sign a given message hash
+
+	Verify(marshaledPublicKey []byte, messageHash []byte, r *big.Int, s *big.Int) bool // Verify a signature of a given message hash
+}
+
+// signatureAlgorithms holds the algorithm registered for each signature scheme.
+var signatureAlgorithms = map[SignatureScheme]SignatureAlgorithm{
+	P521:      p521Algorithm{},      // P-521
+	Ed25519:   ed25519Algorithm{},   // Ed25519
+	Secp256k1: secp256k1Algorithm{}, // secp256k1
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// RegisterSignatureAlgorithm registers a given algorithm as the implementation of a given signature scheme,
+// replacing any algorithm previously registered for the scheme. Algorithms should be registered before any signature is
+// made or verified.
+func RegisterSignatureAlgorithm(scheme SignatureScheme, algorithm SignatureAlgorithm) {
+	signatureAlgorithms[scheme] = algorithm // Register algorithm
+}
+
+// GetSignatureAlgorithm gets the algorithm registered for a given signature scheme.
+// Returns an ErrUnknownSignatureScheme error if no algorithm has been registered for the scheme.
+func GetSignatureAlgorithm(scheme SignatureScheme) (SignatureAlgorithm, error) {
+	algorithm, ok := signatureAlgorithms[scheme] // Get algorithm
+	if !ok {                                     // Check unknown scheme
+		return nil, ErrUnknownSignatureScheme // Return error
+	}
+
+	return algorithm, nil // Return algorithm
+}
+
+// SignatureSchemeOfKey gets the signature scheme a given private key belongs to.
+// Returns an ErrUnsupportedKey error if the key does not belong to any registered scheme.
+func SignatureSchemeOfKey(privateKey gocrypto.Signer) (SignatureScheme, error) {
+	for scheme, algorithm := range signatureAlgorithms { // Iterate through algorithms
+		if algorithm.OwnsKey(privateKey.Public()) { // Check owns key
+			return scheme, nil // Return scheme
+		}
+	}
+
+	return 0, ErrUnsupportedKey // Return error
+}
+
+// GenerateKey generates a new private key for a given signature scheme.
+func GenerateKey(scheme SignatureScheme) (gocrypto.Signer, error) {
+	algorithm, err := GetSignatureAlgorithm(scheme) // Get algorithm
+	if err != nil {                                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	return algorithm.GenerateKey() // Generate key
+}
+
+// AddressFromSigner derives the address of a given private key of any registered signature scheme.
+func AddressFromSigner(privateKey gocrypto.Signer) (*common.Address, error) {
+	scheme, err := SignatureSchemeOfKey(privateKey) // Get scheme
+	if err != nil {                                 // Check for errors
+		return nil, err // Return found error
+	}
+
+	algorithm, _ := GetSignatureAlgorithm(scheme) // Get algorithm
+
+	marshaledPublicKey, err := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+	if err != nil {                                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	return algorithm.Address(marshaledPublicKey) // Return address
+}
+
+// SignatureSchemeFromString gets the signature scheme with a given name (e.g. "p521", "ed25519", "secp256k1").
+func SignatureSchemeFromString(name string) (SignatureScheme, error) {
+	for scheme, algorithm := range signatureAlgorithms { // Iterate through algorithms
+		if strings.EqualFold(algorithm.Name(), name) { // Check matching name
+			return scheme, nil // Return scheme
+		}
+	}
+
+	return 0, ErrUnknownSignatureScheme // Return error
+}
+
+// String gets the name of a given signature scheme.
+func (scheme SignatureScheme) String() string {
+	algorithm, err := GetSignatureAlgorithm(scheme) // Get algorithm
+	if err != nil {                                 // Check for errors
+		return "unknown" // Unknown scheme
+	}
+
+	return algorithm.Name() // Return name
+}
+
+// MarshalText marshals a given signature scheme to its name.
+func (scheme SignatureScheme) MarshalText() ([]byte, error) {
+	if _, err := GetSignatureAlgorithm(scheme); err != nil { // Check unknown scheme
+		return nil, err // Return found error
+	}
+
+	return []byte(scheme.String()), nil // Return name
+}
+
+// UnmarshalText unmarshals a signature scheme from its name.
+func (scheme *SignatureScheme) UnmarshalText(text []byte) error {
+	readScheme, err := SignatureSchemeFromString(string(text)) // Get scheme
+	if err != nil {                                            // Check for errors
+		return err // Return found error
+	}
+
+	*scheme = readScheme // Set scheme
+
+	return nil // No error occurred, return nil
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// ecdsaSignature is the ASN.1 structure of an ECDSA signature.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// signECDSAWithSigner signs a given message hash with a given opaque ECDSA private key (one whose Sign method returns an ASN.1 DER
+// encoded signature), returning the signature's R and S values.
+func signECDSAWithSigner(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, error) {
+	signature, err := privateKey.Sign(rand.Reader, messageHash, gocrypto.SHA3_256) // Sign message hash
+	if err != nil {                                                                // Check for errors
+		return nil, nil, err // Return found error
+	}
+
+	decoded := ecdsaSignature{} // Init signature buffer
+
+	if rest, err := asn1.Unmarshal(signature, &decoded); err != nil || len(rest) != 0 || decoded.R == nil || decoded.S == nil { // Decode signature
+		return nil, nil, ErrInvalidSignature // Return error
+	}
+
+	return decoded.R, decoded.S, nil // Return R and S
+}
+
+// schemeAddress derives the address of a given marshaled public key of a given (non-P521) signature scheme, prefixing the key
+// with the scheme so that no two schemes can share an address.
+func schemeAddress(scheme SignatureScheme, marshaledPublicKey []byte) *common.Address {
+	return common.NewAddress(Sha3(append([]byte{byte(scheme)}, marshaledPublicKey...)).Bytes()) // Return address value
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,118 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// ed25519ComponentSize is the size of each half (R and S) of an Ed25519 signature.
+const ed25519ComponentSize = ed25519.SignatureSize / 2
+
+// ed25519Algorithm implements the Ed25519 signature scheme.
+// Signatures are split into their R and S halves, each read as a big-endian integer.
+type ed25519Algorithm struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// Name gets the name of the scheme.
+func (ed25519Algorithm) Name() string {
+	return "ed25519" // Return name
+}
+
+// GenerateKey generates a new Ed25519 private key.
+func (ed25519Algorithm) GenerateKey() (gocrypto.Signer, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader) // Generate key
+
+	return privateKey, err // Return key
+}
+
+// OwnsKey checks whether a given public key is an Ed25519 public key.
+func (ed25519Algorithm) OwnsKey(publicKey gocrypto.PublicKey) bool {
+	_, ok := publicKey.(ed25519.PublicKey) // Get ed25519 public key
+
+	return ok // Return is Ed25519
+}
+
+// MarshalPublicKey marshals a given Ed25519 public key.
+func (ed25519Algorithm) MarshalPublicKey(publicKey gocrypto.PublicKey) ([]byte, error) {
+	ed25519PublicKey, ok := publicKey.(ed25519.PublicKey)      // Get ed25519 public key
+	if !ok || len(ed25519PublicKey) != ed25519.PublicKeySize { // Check not Ed25519
+		return nil, ErrUnsupportedKey // Return error
+	}
+
+	return append([]byte{}, ed25519PublicKey...), nil // Return marshaled public key
+}
+
+// UnmarshalPublicKey unmarshals a given marshaled Ed25519 public key.
+func (ed25519Algorithm) UnmarshalPublicKey(marshaledPublicKey []byte) (gocrypto.PublicKey, error) {
+	if len(marshaledPublicKey) != ed25519.PublicKeySize { // Check invalid public key
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return ed25519.PublicKey(append([]byte{}, marshaledPublicKey...)), nil // Return public key
+}
+
+// Address derives the address of a given marshaled Ed25519 public key.
+func (ed25519Algorithm) Address(marshaledPublicKey []byte) (*common.Address, error) {
+	if len(marshaledPublicKey) != ed25519.PublicKeySize { // Check invalid public key
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return schemeAddress(Ed25519, marshaledPublicKey), nil // Return address value
+}
+
+// Sign signs a given message hash with a given Ed25519 private key.
+func (algorithm ed25519Algorithm) Sign(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, error) {
+	var signature []byte // Init signature buffer
+
+	if ed25519PrivateKey, ok := privateKey.(ed25519.PrivateKey); ok { // Check is in-memory key
+		if len(ed25519PrivateKey) != ed25519.PrivateKeySize { // Check invalid key
+			return nil, nil, ErrUnsupportedKey // Return error
+		}
+
+		signature = ed25519.Sign(ed25519PrivateKey, messageHash) // Sign via Ed25519
+	} else {
+		if !algorithm.OwnsKey(privateKey.Public()) { // Check not Ed25519
+			return nil, nil, ErrUnsupportedKey // Return error
+		}
+
+		var err error // Init error buffer
+
+		if signature, err = privateKey.Sign(rand.Reader, messageHash, gocrypto.Hash(0)); err != nil { // Sign via opaque key
+			return nil, nil, err // Return found error
+		}
+
+		if len(signature) != ed25519.SignatureSize { // Check invalid signature
+			return nil, nil, ErrInvalidSignature // Return error
+		}
+	}
+
+	return new(big.Int).SetBytes(signature[:ed25519ComponentSize]), new(big.Int).SetBytes(signature[ed25519ComponentSize:]), nil // Return R and S
+}
+
+// Verify verifies an Ed25519 signature of a given message hash.
+func (ed25519Algorithm) Verify(marshaledPublicKey []byte, messageHash []byte, r *big.Int, s *big.Int) bool {
+	if len(marshaledPublicKey) != ed25519.PublicKeySize || r.Sign() < 0 || s.Sign() < 0 { // Check invalid public key or signature
+		return false // Invalid
+	}
+
+	rBytes, sBytes := r.Bytes(), s.Bytes() // Get R and S bytes
+
+	if len(rBytes) > ed25519ComponentSize || len(sBytes) > ed25519ComponentSize { // Check invalid signature
+		return false // Invalid
+	}
+
+	signature := make([]byte, ed25519.SignatureSize) // Init signature buffer
+
+	copy(signature[ed25519ComponentSize-len(rBytes):ed25519ComponentSize], rBytes) // Copy R (left-padded)
+	copy(signature[ed25519.SignatureSize-len(sBytes):], sBytes)                    // Copy S (left-padded)
+
+	return ed25519.Verify(ed25519.PublicKey(marshaledPublicKey), messageHash, signature) // Verify signature of message hash
+}
+
+/* END EXPORTED METHODS */
@@ -0,0 +1,144 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// p521HalfOrder is half the order of the P-521 curve. Signatures with an S value above it are rejected (as every signature has a
+// second, "high S" form).
+var p521HalfOrder = new(big.Int).Rsh(elliptic.P521().Params().N, 1)
+
+// p521Algorithm implements the ECDSA over NIST P-521 signature scheme.
+// Public keys are marshaled in uncompressed form, and addresses are the hash of the marshaled public key.
+type p521Algorithm struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// Name gets the name of the scheme.
+func (p521Algorithm) Name() string {
+	return "p521" // Return name
+}
+
+// GenerateKey generates a new P-521 private key.
+func (p521Algorithm) GenerateKey() (gocrypto.Signer, error) {
+	return ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate key
+}
+
+// OwnsKey checks whether a given public key is a P-521 public key.
+func (p521Algorithm) OwnsKey(publicKey gocrypto.PublicKey) bool {
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey) // Get ecdsa public key
+
+	return ok && ecdsaPublicKey.Curve == elliptic.P521() // Return is P-521
+}
+
+// MarshalPublicKey marshals a given P-521 public key in uncompressed form.
+func (algorithm p521Algorithm) MarshalPublicKey(publicKey gocrypto.PublicKey) ([]byte, error) {
+	if !algorithm.OwnsKey(publicKey) { // Check not P-521
+		return nil, ErrUnsupportedKey // Return error
+	}
+
+	ecdsaPublicKey := publicKey.(*ecdsa.PublicKey) // Get ecdsa public key
+
+	return elliptic.Marshal(elliptic.P521(), ecdsaPublicKey.X, ecdsaPublicKey.Y), nil // Return marshaled public key
+}
+
+// UnmarshalPublicKey unmarshals a given marshaled P-521 public key.
+func (p521Algorithm) UnmarshalPublicKey(marshaledPublicKey []byte) (gocrypto.PublicKey, error) {
+	x, y := elliptic.Unmarshal(elliptic.P521(), marshaledPublicKey) // Unmarshal public key
+
+	if x == nil { // Check invalid public key
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P521(), // Set curve
+		X:     x,               // Set x
+		Y:     y,               // Set y
+	}, nil // Return public key
+}
+
+// Address derives the address of a given marshaled P-521 public key.
+func (p521Algorithm) Address(marshaledPublicKey []byte) (*common.Address, error) {
+	if x, _ := elliptic.Unmarshal(elliptic.P521(), marshaledPublicKey); x == nil { // Check invalid public key
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return common.NewAddress(Sha3(marshaledPublicKey).Bytes()), nil // Return address value
+}
+
+// Sign signs a given message hash with a given P-521 private key, normalizing the signature to its low S form.
+func (algorithm p521Algorithm) Sign(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, error) {
+	if !algorithm.OwnsKey(privateKey.Public()) { // Check not P-521
+		return nil, nil, ErrUnsupportedKey // Return error
+	}
+
+	var r, s *big.Int // Init signature buffers
+	var err error     // Init error buffer
+
+	if ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey); ok { // Check is in-memory key
+		r, s, err = ecdsa.Sign(rand.Reader, ecdsaPrivateKey, messageHash) // Sign via ECDSA
+	} else {
+		r, s, err = signECDSAWithSigner(privateKey, messageHash) // Sign via opaque key
+	}
+
+	if err != nil { // Check for errors
+		return nil, nil, err // Return found error
+	}
+
+	if s.Cmp(p521HalfOrder) > 0 { // Check high S
+		s = new(big.Int).Sub(elliptic.P521().Params().N, s) // Normalize to low S
+	}
+
+	return r, s, nil // Return R and S
+}
+
+// Verify verifies a P-521 signature of a given message hash. Signatures with a high S value are rejected.
+func (p521Algorithm) Verify(marshaledPublicKey []byte, messageHash []byte, r *big.Int, s *big.Int) bool {
+	x, y := elliptic.Unmarshal(elliptic.P521(), marshaledPublicKey) // Unmarshal public key
+
+	if x == nil || s.Cmp(p521HalfOrder) > 0 { // Check invalid public key or high S
+		return false // Invalid
+	}
+
+	publicKey := &ecdsa.PublicKey{
+		Curve: elliptic.P521(), // Set curve
+		X:     x,               // Set x
+		Y:     y,               // Set y
+	} // Recover public key
+
+	return ecdsa.Verify(publicKey, messageHash, r, s) // Verify signature of message hash
+}
+
+// SignRecoverable signs a given message hash with a given P-521 private key, returning the signature's public key recovery ID.
+func (algorithm p521Algorithm) SignRecoverable(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, byte, error) {
+	r, s, err := algorithm.Sign(privateKey, messageHash) // Sign
+	if err != nil {                                      // Check for errors
+		return nil, nil, 0, err // Return found error
+	}
+
+	recoveryID, err := nistRecoveryID(privateKey.Public().(*ecdsa.PublicKey), messageHash, r, s) // Find recovery ID
+	if err != nil {                                                                              // Check for errors
+		return nil, nil, 0, err // Return found error
+	}
+
+	return r, s, recoveryID, nil // Return signature
+}
+
+// RecoverPublicKey recovers the marshaled public key of a P-521 signature of a given message hash.
+func (p521Algorithm) RecoverPublicKey(messageHash []byte, r *big.Int, s *big.Int, recoveryID byte) ([]byte, error) {
+	publicKey, err := recoverNISTPublicKey(elliptic.P521(), messageHash, r, s, recoveryID) // Recover public key
+	if err != nil {                                                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	return elliptic.Marshal(elliptic.P521(), publicKey.X, publicKey.Y), nil // Return marshaled public key
+}
+
+/* END EXPORTED METHODS */
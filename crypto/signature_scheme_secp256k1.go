@@ -0,0 +1,192 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	"bytes"
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// secp256k1HalfOrder is half the order of the secp256k1 curve. Signatures with an S value above it are rejected (as every
+// signature has a second, "high S" form).
+var secp256k1HalfOrder = new(big.Int).Rsh(btcec.S256().N, 1)
+
+// secp256k1CompactHeader is the header byte of a compact secp256k1 signature (of a compressed public key) with a recovery ID of zero.
+const secp256k1CompactHeader = byte(27 + 4)
+
+// secp256k1Algorithm implements the ECDSA over secp256k1 signature scheme.
+// Public keys are marshaled in compressed form, and signatures are deterministic (RFC 6979) with a low S value.
+type secp256k1Algorithm struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// Name gets the name of the scheme.
+func (secp256k1Algorithm) Name() string {
+	return "secp256k1" // Return name
+}
+
+// GenerateKey generates a new secp256k1 private key.
+func (secp256k1Algorithm) GenerateKey() (gocrypto.Signer, error) {
+	privateKey, err := btcec.NewPrivateKey(btcec.S256()) // Generate key
+	if err != nil {                                      // Check for errors
+		return nil, err // Return found error
+	}
+
+	return privateKey.ToECDSA(), nil // Return key
+}
+
+// OwnsKey checks whether a given public key is a secp256k1 public key.
+func (secp256k1Algorithm) OwnsKey(publicKey gocrypto.PublicKey) bool {
+	ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey) // Get ecdsa public key
+
+	return ok && ecdsaPublicKey.Curve == btcec.S256() // Return is secp256k1
+}
+
+// MarshalPublicKey marshals a given secp256k1 public key in compressed form.
+func (algorithm secp256k1Algorithm) MarshalPublicKey(publicKey gocrypto.PublicKey) ([]byte, error) {
+	if !algorithm.OwnsKey(publicKey) { // Check not secp256k1
+		return nil, ErrUnsupportedKey // Return error
+	}
+
+	return (*btcec.PublicKey)(publicKey.(*ecdsa.PublicKey)).SerializeCompressed(), nil // Return marshaled public key
+}
+
+// UnmarshalPublicKey unmarshals a given marshaled (compressed) secp256k1 public key.
+func (secp256k1Algorithm) UnmarshalPublicKey(marshaledPublicKey []byte) (gocrypto.PublicKey, error) {
+	if !btcec.IsCompressedPubKey(marshaledPublicKey) { // Check not compressed
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	publicKey, err := btcec.ParsePubKey(marshaledPublicKey, btcec.S256()) // Parse public key
+	if err != nil {                                                       // Check for errors
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return publicKey.ToECDSA(), nil // Return public key
+}
+
+// Address derives the address of a given marshaled secp256k1 public key.
+func (secp256k1Algorithm) Address(marshaledPublicKey []byte) (*common.Address, error) {
+	if !btcec.IsCompressedPubKey(marshaledPublicKey) { // Check not compressed
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	if _, err := btcec.ParsePubKey(marshaledPublicKey, btcec.S256()); err != nil { // Check invalid public key
+		return nil, ErrInvalidPublicKey // Return error
+	}
+
+	return schemeAddress(Secp256k1, marshaledPublicKey), nil // Return address value
+}
+
+// Sign signs a given message hash with a given secp256k1 private key.
+func (algorithm secp256k1Algorithm) Sign(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, error) {
+	if !algorithm.OwnsKey(privateKey.Public()) { // Check not secp256k1
+		return nil, nil, ErrUnsupportedKey // Return error
+	}
+
+	ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey) // Get ecdsa private key
+	if !ok {                                              // Check is opaque key
+		r, s, err := signECDSAWithSigner(privateKey, messageHash) // Sign via opaque key
+		if err != nil {                                           // Check for errors
+			return nil, nil, err // Return found error
+		}
+
+		if s.Cmp(secp256k1HalfOrder) > 0 { // Check high S
+			s = new(big.Int).Sub(btcec.S256().N, s) // Normalize to low S
+		}
+
+		return r, s, nil // Return R and S
+	}
+
+	signature, err := (*btcec.PrivateKey)(ecdsaPrivateKey).Sign(messageHash) // Sign via ECDSA
+	if err != nil {                                                          // Check for errors
+		return nil, nil, err // Return found error
+	}
+
+	return signature.R, signature.S, nil // Return R and S
+}
+
+// Verify verifies a secp256k1 signature of a given message hash.
+func (secp256k1Algorithm) Verify(marshaledPublicKey []byte, messageHash []byte, r *big.Int, s *big.Int) bool {
+	if !btcec.IsCompressedPubKey(marshaledPublicKey) || s.Cmp(secp256k1HalfOrder) > 0 { // Check not compressed or high S
+		return false // Invalid
+	}
+
+	publicKey, err := btcec.ParsePubKey(marshaledPublicKey, btcec.S256()) // Parse public key
+	if err != nil {                                                       // Check for errors
+		return false // Invalid
+	}
+
+	return (&btcec.Signature{R: r, S: s}).Verify(messageHash, publicKey) // Verify signature of message hash
+}
+
+// SignRecoverable signs a given message hash with a given secp256k1 private key, returning the signature's public key recovery ID.
+func (algorithm secp256k1Algorithm) SignRecoverable(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, byte, error) {
+	if !algorithm.OwnsKey(privateKey.Public()) { // Check not secp256k1
+		return nil, nil, 0, ErrUnsupportedKey // Return error
+	}
+
+	ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey) // Get ecdsa private key
+	if !ok {                                              // Check is opaque key
+		return algorithm.signRecoverableWithSigner(privateKey, messageHash) // Sign via opaque key
+	}
+
+	compactSignature, err := btcec.SignCompact(btcec.S256(), (*btcec.PrivateKey)(ecdsaPrivateKey), messageHash, true) // Sign via ECDSA
+	if err != nil {                                                                                                   // Check for errors
+		return nil, nil, 0, err // Return found error
+	}
+
+	return new(big.Int).SetBytes(compactSignature[1:33]), new(big.Int).SetBytes(compactSignature[33:]), compactSignature[0] - secp256k1CompactHeader, nil // Return R, S and recovery ID
+}
+
+// RecoverPublicKey recovers the marshaled (compressed) public key of a secp256k1 signature of a given message hash.
+func (secp256k1Algorithm) RecoverPublicKey(messageHash []byte, r *big.Int, s *big.Int, recoveryID byte) ([]byte, error) {
+	if recoveryID > maxRecoveryID || r.Sign() <= 0 || s.Sign() <= 0 || r.BitLen() > 256 || s.BitLen() > 256 { // Check invalid signature
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	compactSignature := make([]byte, 65) // Init compact signature buffer
+
+	rBytes, sBytes := r.Bytes(), s.Bytes() // Get R and S bytes
+
+	compactSignature[0] = secp256k1CompactHeader + recoveryID // Set header
+	copy(compactSignature[33-len(rBytes):33], rBytes)         // Copy R (left-padded)
+	copy(compactSignature[65-len(sBytes):], sBytes)           // Copy S (left-padded)
+
+	publicKey, _, err := btcec.RecoverCompact(btcec.S256(), compactSignature, messageHash) // Recover public key
+	if err != nil {                                                                        // Check for errors
+		return nil, ErrInvalidRecoverableSignature // Return error
+	}
+
+	return publicKey.SerializeCompressed(), nil // Return marshaled public key
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// signRecoverableWithSigner signs a given message hash with a given opaque secp256k1 private key, finding the signature's public key
+// recovery ID by recovering each candidate public key.
+func (algorithm secp256k1Algorithm) signRecoverableWithSigner(privateKey gocrypto.Signer, messageHash []byte) (*big.Int, *big.Int, byte, error) {
+	r, s, err := algorithm.Sign(privateKey, messageHash) // Sign
+	if err != nil {                                      // Check for errors
+		return nil, nil, 0, err // Return found error
+	}
+
+	marshaledPublicKey, _ := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+
+	for recoveryID := byte(0); recoveryID <= maxRecoveryID; recoveryID++ { // Iterate through recovery IDs
+		recovered, err := algorithm.RecoverPublicKey(messageHash, r, s, recoveryID) // Recover public key
+		if err == nil && bytes.Equal(recovered, marshaledPublicKey) {               // Check matching public key
+			return r, s, recoveryID, nil // Return signature
+		}
+	}
+
+	return nil, nil, 0, ErrInvalidRecoverableSignature // Return error
+}
+
+/* END INTERNAL METHODS */
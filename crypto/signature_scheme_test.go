@@ -0,0 +1,150 @@
+// Package crypto provides cryptography helper methods.
+package crypto
+
+import (
+	"bytes"
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestSignatureSchemeFromString tests the functionality of the SignatureSchemeFromString() helper method.
+func TestSignatureSchemeFromString(t *testing.T) {
+	for _, scheme := range []SignatureScheme{P521, Ed25519, Secp256k1} { // Iterate through schemes
+		if readScheme, err := SignatureSchemeFromString(scheme.String()); err != nil || readScheme != scheme { // Check invalid scheme
+			t.Fatalf("should have read scheme %s; got %s", scheme, readScheme) // Panic
+		}
+	}
+
+	if _, err := SignatureSchemeFromString("rsa"); err != ErrUnknownSignatureScheme { // Read unknown scheme
+		t.Fatalf("should have returned ErrUnknownSignatureScheme; got %v", err) // Panic
+	}
+}
+
+// TestAddressFromSigner tests the functionality of the AddressFromSigner() helper method.
+func TestAddressFromSigner(t *testing.T) {
+	addresses := make(map[common.Address]bool) // Init derived address set
+
+	for _, scheme := range []SignatureScheme{P521, Ed25519, Secp256k1} { // Iterate through schemes
+		privateKey, err := GenerateKey(scheme) // Generate private key
+		if err != nil {                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if keyScheme, err := SignatureSchemeOfKey(privateKey); err != nil || keyScheme != scheme { // Check invalid key scheme
+			t.Fatalf("generated key should belong to scheme %s; got %s", scheme, keyScheme) // Panic
+		}
+
+		address, err := AddressFromSigner(privateKey) // Derive address
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if ecdsaPrivateKey, ok := privateKey.(*ecdsa.PrivateKey); ok && *AddressFromPrivateKey(ecdsaPrivateKey) != *address { // Check ecdsa address mismatch
+			t.Fatalf("AddressFromPrivateKey should derive %s addresses as defined by the scheme", scheme) // Panic
+		}
+
+		addresses[*address] = true // Add address
+	}
+
+	if len(addresses) != 3 { // Check address collision
+		t.Fatal("each key should have a distinct address") // Panic
+	}
+}
+
+// opaqueKey hides the concrete type of a private key, as a key held by a hardware device or an external process would.
+type opaqueKey struct {
+	gocrypto.Signer
+}
+
+// TestSignWithOpaqueKey tests the functionality of the Sign() and SignRecoverable() methods of every signature algorithm with a
+// private key of an unknown type.
+func TestSignWithOpaqueKey(t *testing.T) {
+	for _, scheme := range []SignatureScheme{P521, Ed25519, Secp256k1} { // Iterate through schemes
+		algorithm, _ := GetSignatureAlgorithm(scheme) // Get algorithm
+
+		privateKey, err := algorithm.GenerateKey() // Generate private key
+		if err != nil {                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		marshaledPublicKey, _ := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+
+		publicKey, err := algorithm.UnmarshalPublicKey(marshaledPublicKey) // Unmarshal public key
+		if err != nil {                                                    // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if remarshaled, _ := algorithm.MarshalPublicKey(publicKey); !bytes.Equal(remarshaled, marshaledPublicKey) { // Check public key mismatch
+			t.Fatalf("should have unmarshaled %s public key", scheme) // Panic
+		}
+
+		for i := 0; i < 8; i++ { // Sign several messages (covering both S halves)
+			messageHash := Sha3([]byte{byte(i)}).Bytes() // Get message hash
+
+			r, s, err := algorithm.Sign(opaqueKey{privateKey}, messageHash) // Sign
+			if err != nil {                                                 // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if !algorithm.Verify(marshaledPublicKey, messageHash, r, s) { // Check invalid signature
+				t.Fatalf("opaque %s key should produce valid signatures", scheme) // Panic
+			}
+
+			recoverableAlgorithm, err := GetRecoverableSignatureAlgorithm(scheme) // Get recoverable algorithm
+			if err != nil {                                                       // Check unrecoverable
+				continue // Continue
+			}
+
+			r, s, recoveryID, err := recoverableAlgorithm.SignRecoverable(opaqueKey{privateKey}, messageHash) // Sign recoverable
+			if err != nil {                                                                                   // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if recovered, err := recoverableAlgorithm.RecoverPublicKey(messageHash, r, s, recoveryID); err != nil || !bytes.Equal(recovered, marshaledPublicKey) { // Check invalid recovered key
+				t.Fatalf("should have recovered opaque %s signer public key", scheme) // Panic
+			}
+		}
+	}
+}
+
+// TestVerifyHighS tests that the Verify() method of every ECDSA signature algorithm rejects signatures with a high S value (the
+// second form of each signature produced by Sign()).
+func TestVerifyHighS(t *testing.T) {
+	for scheme, halfOrder := range map[SignatureScheme]*big.Int{P521: p521HalfOrder, Secp256k1: secp256k1HalfOrder} { // Iterate through ECDSA schemes
+		algorithm, _ := GetSignatureAlgorithm(scheme) // Get algorithm
+
+		order := new(big.Int).Add(new(big.Int).Lsh(halfOrder, 1), big.NewInt(1)) // Get curve order
+
+		privateKey, err := algorithm.GenerateKey() // Generate private key
+		if err != nil {                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		marshaledPublicKey, _ := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+
+		for i := 0; i < 8; i++ { // Sign several messages (covering both S halves)
+			messageHash := Sha3([]byte{byte(i)}).Bytes() // Get message hash
+
+			r, s, err := algorithm.Sign(privateKey, messageHash) // Sign
+			if err != nil {                                      // Check for errors
+				t.Fatal(err) // Panic
+			}
+
+			if s.Cmp(halfOrder) > 0 || !algorithm.Verify(marshaledPublicKey, messageHash, r, s) { // Check not low S, or invalid signature
+				t.Fatalf("%s key should produce valid low S signatures", scheme) // Panic
+			}
+
+			if algorithm.Verify(marshaledPublicKey, messageHash, r, new(big.Int).Sub(order, s)) { // Check high S accepted
+				t.Fatalf("%s signature with a high S value should be rejected", scheme) // Panic
+			}
+		}
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -2,6 +2,7 @@ module github.com/polaris-project/go-polaris
 
 require (
 	github.com/boltdb/bolt v1.3.1
+	github.com/btcsuite/btcd v0.0.0-20190213025234-306aecffea32
 	github.com/golang/protobuf v1.3.0
 	github.com/juju/ansiterm v0.0.0-20180109212912-720a0952cc2a // indirect
 	github.com/juju/loggo v0.0.0-20190212223446-d976af380377
@@ -16,6 +17,7 @@ require (
 	github.com/lunixbochs/vtclean v1.0.0 // indirect
 	github.com/multiformats/go-multiaddr v0.0.1
 	github.com/twitchtv/twirp v5.5.2+incompatible
+	github.com/tyler-smith/go-bip39 v1.0.1-0.20181017060643-dbb3b84ba2ef
 	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550
 	golang.org/x/net v0.0.0-20200226121028-0de0cce0169b // indirect
 	golang.org/x/sync v0.0.0-20190911185100-cd5d95a43a6e // indirect
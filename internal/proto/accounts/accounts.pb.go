@@ -25,6 +25,16 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type GeneralRequest struct {
 	PrivatePublicKey     string   `protobuf:"bytes,1,opt,name=privatePublicKey,proto3" json:"privatePublicKey,omitempty"`
+	Scheme               string   `protobuf:"bytes,2,opt,name=scheme,proto3" json:"scheme,omitempty"`
+	Threshold            uint32   `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	PublicKeys           []string `protobuf:"bytes,4,rep,name=publicKeys,proto3" json:"publicKeys,omitempty"`
+	Passphrase           string   `protobuf:"bytes,5,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Timeout              uint32   `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Path                 string   `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	Mnemonic             string   `protobuf:"bytes,8,opt,name=mnemonic,proto3" json:"mnemonic,omitempty"`
+	Wallet               string   `protobuf:"bytes,9,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Count                uint32   `protobuf:"varint,10,opt,name=count,proto3" json:"count,omitempty"`
+	Label                string   `protobuf:"bytes,11,opt,name=label,proto3" json:"label,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -66,6 +76,76 @@ func (m *GeneralRequest) GetPrivatePublicKey() string {
 	return ""
 }
 
+func (m *GeneralRequest) GetScheme() string {
+	if m != nil {
+		return m.Scheme
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetThreshold() uint32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetPublicKeys() []string {
+	if m != nil {
+		return m.PublicKeys
+	}
+	return nil
+}
+
+func (m *GeneralRequest) GetPassphrase() string {
+	if m != nil {
+		return m.Passphrase
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetTimeout() uint32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetMnemonic() string {
+	if m != nil {
+		return m.Mnemonic
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetWallet() string {
+	if m != nil {
+		return m.Wallet
+	}
+	return ""
+}
+
+func (m *GeneralRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
 type GeneralResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -117,19 +197,34 @@ func init() {
 func init() { proto.RegisterFile("accounts.proto", fileDescriptor_e1e7723af4c007b7) }
 
 var fileDescriptor_e1e7723af4c007b7 = []byte{
-	// 220 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4b, 0x4c, 0x4e, 0xce,
-	0x2f, 0xcd, 0x2b, 0x29, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x80, 0xf1, 0x95, 0x6c,
-	0xb8, 0xf8, 0xdc, 0x53, 0xf3, 0x52, 0x8b, 0x12, 0x73, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b,
-	0x84, 0xb4, 0xb8, 0x04, 0x0a, 0x8a, 0x32, 0xcb, 0x12, 0x4b, 0x52, 0x03, 0x4a, 0x93, 0x72, 0x32,
-	0x93, 0xbd, 0x53, 0x2b, 0x25, 0x18, 0x15, 0x18, 0x35, 0x38, 0x83, 0x30, 0xc4, 0x95, 0xb4, 0xb9,
-	0xf8, 0xe1, 0xba, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0x24, 0xb8, 0xd8, 0x73, 0x53, 0x8b,
-	0x8b, 0x13, 0xd3, 0x53, 0xa1, 0xba, 0x60, 0x5c, 0xa3, 0xb7, 0xcc, 0x5c, 0x1c, 0x8e, 0x50, 0x7b,
-	0x85, 0x9c, 0xb9, 0xb8, 0xfc, 0x52, 0xcb, 0xa1, 0x5c, 0x21, 0x09, 0x3d, 0xb8, 0x03, 0x51, 0x5d,
-	0x23, 0x25, 0x89, 0x45, 0x06, 0x62, 0x93, 0x12, 0x83, 0x90, 0x3b, 0xc8, 0xf1, 0x25, 0x8e, 0x39,
-	0x39, 0x70, 0x63, 0xc9, 0x37, 0x08, 0x6a, 0x84, 0x5b, 0x51, 0x7e, 0xae, 0x77, 0x6a, 0x25, 0xb9,
-	0x06, 0x39, 0x70, 0xb1, 0x3b, 0xa6, 0xa4, 0x14, 0xa5, 0x16, 0x93, 0xed, 0x14, 0x27, 0x2e, 0x4e,
-	0x78, 0xf8, 0x92, 0x6b, 0x86, 0x33, 0x17, 0x57, 0x00, 0x24, 0xaa, 0x28, 0x30, 0xc4, 0x9e, 0x8b,
-	0x2d, 0xb8, 0xa4, 0x28, 0x33, 0x2f, 0x9d, 0x4c, 0x03, 0x92, 0xd8, 0xc0, 0x69, 0xcd, 0x18, 0x10,
-	0x00, 0x00, 0xff, 0xff, 0xa2, 0x99, 0x6c, 0x04, 0x7d, 0x02, 0x00, 0x00,
+	// 458 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x95, 0xdf, 0x6e, 0xd3, 0x30,
+	0x14, 0xc6, 0xe9, 0xd6, 0xa5, 0xcd, 0x41, 0x1d, 0xc8, 0xa0, 0x61, 0x26, 0x84, 0xaa, 0x5e, 0x55,
+	0x20, 0xed, 0x02, 0xae, 0xd1, 0xe8, 0x86, 0x3a, 0xa1, 0x8d, 0xae, 0x0a, 0x42, 0xbb, 0x76, 0x93,
+	0xa3, 0x26, 0xc2, 0x7f, 0x82, 0xed, 0xb4, 0xda, 0x53, 0xf0, 0x4a, 0x3c, 0x1a, 0xaa, 0x9d, 0x64,
+	0x9b, 0xe0, 0xca, 0xd9, 0x5d, 0xbf, 0xcf, 0xf6, 0xaf, 0x9f, 0xcf, 0x39, 0x56, 0xe0, 0x90, 0xa5,
+	0xa9, 0xaa, 0xa4, 0x35, 0x27, 0xa5, 0x56, 0x56, 0x91, 0x61, 0xa3, 0x27, 0x7f, 0xf6, 0xe0, 0xf0,
+	0x02, 0x25, 0x6a, 0xc6, 0x13, 0xfc, 0x55, 0xa1, 0xb1, 0xe4, 0x1d, 0x3c, 0x2f, 0x75, 0xb1, 0x61,
+	0x16, 0x97, 0xd5, 0x8a, 0x17, 0xe9, 0x25, 0xde, 0xd2, 0xde, 0xb8, 0x37, 0x8d, 0x93, 0x7f, 0x7c,
+	0x72, 0x04, 0x91, 0x49, 0x73, 0x14, 0x48, 0xf7, 0xdc, 0x8e, 0x5a, 0x91, 0x37, 0x10, 0xdb, 0x5c,
+	0xa3, 0xc9, 0x15, 0xcf, 0xe8, 0xfe, 0xb8, 0x37, 0x1d, 0x25, 0x77, 0x06, 0x79, 0x0b, 0x50, 0x36,
+	0x08, 0x43, 0xfb, 0xe3, 0xfd, 0x69, 0x9c, 0xdc, 0x73, 0xdc, 0x3a, 0x33, 0xa6, 0xcc, 0x35, 0x33,
+	0x48, 0x0f, 0x1c, 0xf9, 0x9e, 0x43, 0x28, 0x0c, 0x6c, 0x21, 0x50, 0x55, 0x96, 0x46, 0x8e, 0xdd,
+	0x48, 0x42, 0xa0, 0x5f, 0x32, 0x9b, 0xd3, 0x81, 0x3b, 0xe3, 0x7e, 0x93, 0x63, 0x18, 0x0a, 0x89,
+	0x42, 0xc9, 0x22, 0xa5, 0x43, 0xe7, 0xb7, 0x7a, 0x97, 0x7f, 0xcb, 0x38, 0x47, 0x4b, 0x63, 0x9f,
+	0xdf, 0x2b, 0xf2, 0x12, 0x0e, 0x5c, 0x81, 0x28, 0x38, 0xbe, 0x17, 0x3b, 0x97, 0xb3, 0x15, 0x72,
+	0xfa, 0xd4, 0x6d, 0xf6, 0x62, 0xf2, 0x1e, 0x9e, 0xb5, 0x15, 0x34, 0xa5, 0x92, 0x3e, 0xa0, 0x40,
+	0x63, 0xd8, 0x1a, 0xeb, 0xca, 0x35, 0xf2, 0xc3, 0x6f, 0x80, 0xe1, 0xac, 0x2e, 0x3e, 0x39, 0x07,
+	0x58, 0xe0, 0xb6, 0x96, 0x84, 0x9e, 0xb4, 0x5d, 0x7a, 0xd8, 0x91, 0xe3, 0xd7, 0xff, 0x59, 0xf1,
+	0xff, 0x34, 0x79, 0x42, 0x2e, 0x76, 0x0d, 0xb4, 0x33, 0xce, 0x5b, 0x6c, 0x38, 0xa8, 0x46, 0xcc,
+	0xb5, 0x12, 0xbb, 0xee, 0x06, 0x82, 0x3e, 0xc3, 0x60, 0x96, 0x65, 0x1a, 0x4d, 0x70, 0x94, 0x33,
+	0x88, 0xef, 0x66, 0x2c, 0x90, 0x71, 0x0e, 0xb0, 0xf4, 0xe3, 0xda, 0x01, 0x72, 0x0a, 0xd1, 0x77,
+	0xab, 0x0b, 0xb9, 0x0e, 0x05, 0x5c, 0x02, 0x59, 0xe0, 0xf6, 0x5b, 0xc5, 0x6d, 0x61, 0x8a, 0x75,
+	0xc7, 0x56, 0x9f, 0x42, 0xf4, 0x43, 0x72, 0x95, 0xfe, 0x0c, 0x05, 0x7c, 0x82, 0xfe, 0x55, 0x87,
+	0xe3, 0x73, 0x18, 0x7d, 0x15, 0xa5, 0xd2, 0xb6, 0xe3, 0x3d, 0xce, 0x20, 0x5e, 0xe0, 0xf6, 0xc6,
+	0x3f, 0xb5, 0xf0, 0x2c, 0x09, 0x1a, 0xab, 0x34, 0x76, 0xe6, 0x7c, 0x41, 0x5d, 0x6c, 0xb0, 0xe3,
+	0x9d, 0xe6, 0x30, 0xf2, 0xcf, 0xd0, 0xc7, 0x09, 0x1e, 0xfd, 0x2b, 0x78, 0x31, 0xcb, 0xb2, 0x1b,
+	0x66, 0xd3, 0xfc, 0x5a, 0xf2, 0xdb, 0x8e, 0xa9, 0x96, 0xf0, 0xaa, 0x49, 0xf5, 0x10, 0x18, 0x9c,
+	0xef, 0x1a, 0x8e, 0x12, 0x14, 0x6a, 0x83, 0x8f, 0x14, 0x71, 0x15, 0xb9, 0x4f, 0xd2, 0xc7, 0xbf,
+	0x03, 0x00, 0x53, 0x73, 0xf0, 0x75, 0xa4, 0x06, 0x00, 0x00,
 }
@@ -133,23 +133,24 @@ func init() {
 func init() { proto.RegisterFile("dag.proto", fileDescriptor_228b96b95413374c) }
 
 var fileDescriptor_228b96b95413374c = []byte{
-	// 276 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0xd2, 0xc1, 0x4e, 0xc3, 0x30,
-	0x0c, 0x00, 0x50, 0xc6, 0xd0, 0xd0, 0x8c, 0xc4, 0xa4, 0x30, 0x41, 0xc4, 0x09, 0xf5, 0x34, 0x09,
-	0x69, 0x07, 0x10, 0x17, 0x6e, 0x6b, 0x81, 0x72, 0x81, 0xc3, 0xe0, 0x07, 0x4c, 0x63, 0x75, 0xd5,
-	0x42, 0x32, 0xe2, 0x4c, 0xd3, 0xfe, 0x86, 0x4f, 0x45, 0x69, 0x3b, 0xa0, 0x70, 0xca, 0xad, 0x76,
-	0x9d, 0x67, 0x3b, 0x0a, 0x0c, 0x15, 0x96, 0xd3, 0x95, 0xb3, 0xde, 0x8a, 0xbe, 0xc2, 0x32, 0x31,
-	0x70, 0x9c, 0x93, 0x21, 0x87, 0x7a, 0x4e, 0x1f, 0x6b, 0x62, 0x2f, 0x24, 0x1c, 0x1a, 0xf2, 0x1b,
-	0xeb, 0x96, 0xb2, 0x77, 0xd1, 0x9b, 0x0c, 0xe7, 0xbb, 0x50, 0x4c, 0x60, 0xe4, 0x1d, 0x1a, 0xc6,
-	0xc2, 0x57, 0xd6, 0x3c, 0x22, 0x2f, 0xe4, 0x7e, 0x5d, 0xf1, 0x37, 0x1d, 0x0c, 0x54, 0xca, 0x11,
-	0xb3, 0xec, 0x37, 0x46, 0x1b, 0x26, 0x97, 0x30, 0xfa, 0xee, 0xc7, 0x2b, 0x6b, 0x98, 0x42, 0xf1,
-	0x3b, 0x31, 0x63, 0x49, 0xbb, 0x86, 0x6d, 0x78, 0xf5, 0x79, 0x00, 0xfd, 0x3b, 0x2c, 0xc5, 0x0d,
-	0x0c, 0x9e, 0x69, 0x13, 0xbe, 0x4e, 0xa6, 0x61, 0xfe, 0xee, 0xc4, 0xe7, 0xe3, 0x6e, 0xb2, 0x61,
-	0x93, 0x3d, 0x71, 0x0b, 0x47, 0x4f, 0xb8, 0xa4, 0xf0, 0x83, 0x2b, 0x8e, 0x3b, 0x9b, 0xc1, 0x38,
-	0x27, 0xff, 0xfa, 0xb3, 0x57, 0xba, 0xad, 0x37, 0x8b, 0x42, 0xee, 0xe1, 0xb4, 0x8b, 0x64, 0x8b,
-	0x4a, 0x2b, 0x47, 0x26, 0x8e, 0xc9, 0x41, 0x76, 0x19, 0x4e, 0xb7, 0xb3, 0xe6, 0x3e, 0xe3, 0xa0,
-	0x07, 0x38, 0xfb, 0x07, 0xbd, 0x90, 0x51, 0xe4, 0xe2, 0x9c, 0x19, 0x88, 0x9c, 0x7c, 0x4a, 0xfc,
-	0xdb, 0x8a, 0x1e, 0x25, 0x43, 0x5d, 0xac, 0x35, 0x7a, 0x6a, 0x77, 0x49, 0x51, 0xa3, 0x29, 0x28,
-	0xca, 0x79, 0x1b, 0xd4, 0x6f, 0xf9, 0xfa, 0x2b, 0x00, 0x00, 0xff, 0xff, 0xbc, 0x7a, 0x78, 0x01,
-	0xd8, 0x02, 0x00, 0x00,
+	// 289 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0xd3, 0xcd, 0x4a, 0xc3, 0x40,
+	0x10, 0x00, 0x60, 0x6b, 0xa4, 0xd2, 0x11, 0x2c, 0xac, 0x45, 0x43, 0x4f, 0x92, 0x53, 0x41, 0xe8,
+	0x41, 0xf1, 0xe2, 0xad, 0x89, 0x1a, 0x3d, 0xa8, 0x50, 0x05, 0xcf, 0x63, 0x76, 0x48, 0x42, 0xd7,
+	0x4d, 0xdd, 0xd9, 0x52, 0xf2, 0xcc, 0xbe, 0x84, 0xe4, 0xa7, 0xd5, 0xd8, 0xd3, 0xde, 0x32, 0x93,
+	0xc9, 0x37, 0x3f, 0x10, 0x18, 0x48, 0x4c, 0xa7, 0x4b, 0x53, 0xd8, 0x42, 0x78, 0x12, 0xd3, 0x40,
+	0xc3, 0x71, 0x4c, 0x9a, 0x0c, 0xaa, 0x39, 0x7d, 0xad, 0x88, 0xad, 0xf0, 0xe1, 0x50, 0x93, 0x5d,
+	0x17, 0x66, 0xe1, 0xf7, 0xce, 0x7b, 0x93, 0xc1, 0x7c, 0x13, 0x8a, 0x09, 0x0c, 0xad, 0x41, 0xcd,
+	0x98, 0xd8, 0xbc, 0xd0, 0x0f, 0xc8, 0x99, 0xbf, 0x5f, 0x57, 0xfc, 0x4f, 0x57, 0x06, 0x4a, 0x69,
+	0x88, 0xd9, 0xf7, 0x1a, 0xa3, 0x0d, 0x83, 0x0b, 0x18, 0x6e, 0xfb, 0xf1, 0xb2, 0xd0, 0x4c, 0x55,
+	0xf1, 0x27, 0x31, 0x63, 0x4a, 0x9b, 0x86, 0x6d, 0x78, 0xf9, 0x7d, 0x00, 0xde, 0x2d, 0xa6, 0xe2,
+	0x1a, 0xfa, 0xcf, 0xb4, 0xae, 0x9e, 0x4e, 0xa6, 0xd5, 0xfc, 0xdd, 0x89, 0xc7, 0xa3, 0x6e, 0xb2,
+	0x61, 0x83, 0x3d, 0x71, 0x03, 0x47, 0x4f, 0xb8, 0xa0, 0xea, 0x05, 0xe7, 0xec, 0xf6, 0x6d, 0x04,
+	0xa3, 0x98, 0xec, 0xdb, 0xef, 0x5e, 0x61, 0x59, 0x6f, 0xe6, 0x84, 0xdc, 0xc1, 0x69, 0x17, 0x89,
+	0xb2, 0x5c, 0x49, 0x43, 0xda, 0x8d, 0x89, 0xc1, 0xef, 0x32, 0x1c, 0x96, 0xb3, 0xe6, 0x9e, 0x6e,
+	0xd0, 0x3d, 0x9c, 0xed, 0x40, 0xaf, 0xa4, 0x25, 0x19, 0x37, 0x67, 0x06, 0x22, 0x26, 0x1b, 0x12,
+	0xff, 0xb5, 0x9c, 0x47, 0x89, 0x50, 0x25, 0x2b, 0x85, 0x96, 0xda, 0x5d, 0x42, 0x54, 0xa8, 0x13,
+	0x72, 0x73, 0x1e, 0x61, 0xbc, 0x75, 0xde, 0xd1, 0x26, 0xd9, 0x8b, 0x56, 0x65, 0x2b, 0xb9, 0x5d,
+	0xe7, 0xa3, 0x5f, 0xff, 0x16, 0x57, 0x3f, 0x03, 0x00, 0x9e, 0x4c, 0xcd, 0xb3, 0x23, 0x03, 0x00,
+	0x00,
 }
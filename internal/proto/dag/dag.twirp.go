@@ -54,6 +54,8 @@ type Dag interface {
 	GetBestTransaction(context.Context, *GeneralRequest) (*GeneralResponse, error)
 
 	CalculateAddressBalance(context.Context, *GeneralRequest) (*GeneralResponse, error)
+
+	CalculateWatchOnlyBalances(context.Context, *GeneralRequest) (*GeneralResponse, error)
 }
 
 // ===================
@@ -62,14 +64,14 @@ type Dag interface {
 
 type dagProtobufClient struct {
 	client HTTPClient
-	urls   [8]string
+	urls   [9]string
 }
 
 // NewDagProtobufClient creates a Protobuf client that implements the Dag interface.
 // It communicates using Protobuf and can be configured with a custom HTTPClient.
 func NewDagProtobufClient(addr string, client HTTPClient) Dag {
 	prefix := urlBase(addr) + DagPathPrefix
-	urls := [8]string{
+	urls := [9]string{
 		prefix + "NewDag",
 		prefix + "MakeGenesis",
 		prefix + "GetTransactionByHash",
@@ -78,6 +80,7 @@ func NewDagProtobufClient(addr string, client HTTPClient) Dag {
 		prefix + "GetTransactionsBySender",
 		prefix + "GetBestTransaction",
 		prefix + "CalculateAddressBalance",
+		prefix + "CalculateWatchOnlyBalances",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &dagProtobufClient{
@@ -187,20 +190,32 @@ func (c *dagProtobufClient) CalculateAddressBalance(ctx context.Context, in *Gen
 	return out, nil
 }
 
+func (c *dagProtobufClient) CalculateWatchOnlyBalances(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "dag")
+	ctx = ctxsetters.WithServiceName(ctx, "Dag")
+	ctx = ctxsetters.WithMethodName(ctx, "CalculateWatchOnlyBalances")
+	out := new(GeneralResponse)
+	err := doProtobufRequest(ctx, c.client, c.urls[8], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ===============
 // Dag JSON Client
 // ===============
 
 type dagJSONClient struct {
 	client HTTPClient
-	urls   [8]string
+	urls   [9]string
 }
 
 // NewDagJSONClient creates a JSON client that implements the Dag interface.
 // It communicates using JSON and can be configured with a custom HTTPClient.
 func NewDagJSONClient(addr string, client HTTPClient) Dag {
 	prefix := urlBase(addr) + DagPathPrefix
-	urls := [8]string{
+	urls := [9]string{
 		prefix + "NewDag",
 		prefix + "MakeGenesis",
 		prefix + "GetTransactionByHash",
@@ -209,6 +224,7 @@ func NewDagJSONClient(addr string, client HTTPClient) Dag {
 		prefix + "GetTransactionsBySender",
 		prefix + "GetBestTransaction",
 		prefix + "CalculateAddressBalance",
+		prefix + "CalculateWatchOnlyBalances",
 	}
 	if httpClient, ok := client.(*http.Client); ok {
 		return &dagJSONClient{
@@ -318,6 +334,18 @@ func (c *dagJSONClient) CalculateAddressBalance(ctx context.Context, in *General
 	return out, nil
 }
 
+func (c *dagJSONClient) CalculateWatchOnlyBalances(ctx context.Context, in *GeneralRequest) (*GeneralResponse, error) {
+	ctx = ctxsetters.WithPackageName(ctx, "dag")
+	ctx = ctxsetters.WithServiceName(ctx, "Dag")
+	ctx = ctxsetters.WithMethodName(ctx, "CalculateWatchOnlyBalances")
+	out := new(GeneralResponse)
+	err := doJSONRequest(ctx, c.client, c.urls[8], in, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ==================
 // Dag Server Handler
 // ==================
@@ -390,6 +418,9 @@ func (s *dagServer) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 	case "/twirp/dag.Dag/CalculateAddressBalance":
 		s.serveCalculateAddressBalance(ctx, resp, req)
 		return
+	case "/twirp/dag.Dag/CalculateWatchOnlyBalances":
+		s.serveCalculateWatchOnlyBalances(ctx, resp, req)
+		return
 	default:
 		msg := fmt.Sprintf("no handler for path %q", req.URL.Path)
 		err = badRouteError(msg, req.Method, req.URL.Path)
@@ -1550,6 +1581,150 @@ func (s *dagServer) serveCalculateAddressBalanceProtobuf(ctx context.Context, re
 	callResponseSent(ctx, s.hooks)
 }
 
+func (s *dagServer) serveCalculateWatchOnlyBalances(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	header := req.Header.Get("Content-Type")
+	i := strings.Index(header, ";")
+	if i == -1 {
+		i = len(header)
+	}
+	switch strings.TrimSpace(strings.ToLower(header[:i])) {
+	case "application/json":
+		s.serveCalculateWatchOnlyBalancesJSON(ctx, resp, req)
+	case "application/protobuf":
+		s.serveCalculateWatchOnlyBalancesProtobuf(ctx, resp, req)
+	default:
+		msg := fmt.Sprintf("unexpected Content-Type: %q", req.Header.Get("Content-Type"))
+		twerr := badRouteError(msg, req.Method, req.URL.Path)
+		s.writeError(ctx, resp, twerr)
+	}
+}
+
+func (s *dagServer) serveCalculateWatchOnlyBalancesJSON(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CalculateWatchOnlyBalances")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	reqContent := new(GeneralRequest)
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err = unmarshaler.Unmarshal(req.Body, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request json")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Dag.CalculateWatchOnlyBalances(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling CalculateWatchOnlyBalances. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	var buf bytes.Buffer
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	if err = marshaler.Marshal(&buf, respContent); err != nil {
+		err = wrapErr(err, "failed to marshal json response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusOK)
+
+	respBytes := buf.Bytes()
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
+func (s *dagServer) serveCalculateWatchOnlyBalancesProtobuf(ctx context.Context, resp http.ResponseWriter, req *http.Request) {
+	var err error
+	ctx = ctxsetters.WithMethodName(ctx, "CalculateWatchOnlyBalances")
+	ctx, err = callRequestRouted(ctx, s.hooks)
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+
+	buf, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		err = wrapErr(err, "failed to read request body")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+	reqContent := new(GeneralRequest)
+	if err = proto.Unmarshal(buf, reqContent); err != nil {
+		err = wrapErr(err, "failed to parse request proto")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	// Call service method
+	var respContent *GeneralResponse
+	func() {
+		defer func() {
+			// In case of a panic, serve a 500 error and then panic.
+			if r := recover(); r != nil {
+				s.writeError(ctx, resp, twirp.InternalError("Internal service panic"))
+				panic(r)
+			}
+		}()
+		respContent, err = s.Dag.CalculateWatchOnlyBalances(ctx, reqContent)
+	}()
+
+	if err != nil {
+		s.writeError(ctx, resp, err)
+		return
+	}
+	if respContent == nil {
+		s.writeError(ctx, resp, twirp.InternalError("received a nil *GeneralResponse and nil error while calling CalculateWatchOnlyBalances. nil responses are not supported"))
+		return
+	}
+
+	ctx = callResponsePrepared(ctx, s.hooks)
+
+	respBytes, err := proto.Marshal(respContent)
+	if err != nil {
+		err = wrapErr(err, "failed to marshal proto response")
+		s.writeError(ctx, resp, twirp.InternalErrorWith(err))
+		return
+	}
+
+	ctx = ctxsetters.WithStatusCode(ctx, http.StatusOK)
+	resp.Header().Set("Content-Type", "application/protobuf")
+	resp.WriteHeader(http.StatusOK)
+	if n, err := resp.Write(respBytes); err != nil {
+		msg := fmt.Sprintf("failed to write response, %d of %d bytes written: %s", n, len(respBytes), err.Error())
+		twerr := twirp.NewError(twirp.Unknown, msg)
+		callError(ctx, s.hooks, twerr)
+	}
+	callResponseSent(ctx, s.hooks)
+}
+
 func (s *dagServer) ServiceDescriptor() ([]byte, int) {
 	return twirpFileDescriptor0, 0
 }
@@ -1980,23 +2155,24 @@ func callError(ctx context.Context, h *twirp.ServerHooks, err twirp.Error) conte
 }
 
 var twirpFileDescriptor0 = []byte{
-	// 276 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0xd2, 0xc1, 0x4e, 0xc3, 0x30,
-	0x0c, 0x00, 0x50, 0xc6, 0xd0, 0xd0, 0x8c, 0xc4, 0xa4, 0x30, 0x41, 0xc4, 0x09, 0xf5, 0x34, 0x09,
-	0x69, 0x07, 0x10, 0x17, 0x6e, 0x6b, 0x81, 0x72, 0x81, 0xc3, 0xe0, 0x07, 0x4c, 0x63, 0x75, 0xd5,
-	0x42, 0x32, 0xe2, 0x4c, 0xd3, 0xfe, 0x86, 0x4f, 0x45, 0x69, 0x3b, 0xa0, 0x70, 0xca, 0xad, 0x76,
-	0x9d, 0x67, 0x3b, 0x0a, 0x0c, 0x15, 0x96, 0xd3, 0x95, 0xb3, 0xde, 0x8a, 0xbe, 0xc2, 0x32, 0x31,
-	0x70, 0x9c, 0x93, 0x21, 0x87, 0x7a, 0x4e, 0x1f, 0x6b, 0x62, 0x2f, 0x24, 0x1c, 0x1a, 0xf2, 0x1b,
-	0xeb, 0x96, 0xb2, 0x77, 0xd1, 0x9b, 0x0c, 0xe7, 0xbb, 0x50, 0x4c, 0x60, 0xe4, 0x1d, 0x1a, 0xc6,
-	0xc2, 0x57, 0xd6, 0x3c, 0x22, 0x2f, 0xe4, 0x7e, 0x5d, 0xf1, 0x37, 0x1d, 0x0c, 0x54, 0xca, 0x11,
-	0xb3, 0xec, 0x37, 0x46, 0x1b, 0x26, 0x97, 0x30, 0xfa, 0xee, 0xc7, 0x2b, 0x6b, 0x98, 0x42, 0xf1,
-	0x3b, 0x31, 0x63, 0x49, 0xbb, 0x86, 0x6d, 0x78, 0xf5, 0x79, 0x00, 0xfd, 0x3b, 0x2c, 0xc5, 0x0d,
-	0x0c, 0x9e, 0x69, 0x13, 0xbe, 0x4e, 0xa6, 0x61, 0xfe, 0xee, 0xc4, 0xe7, 0xe3, 0x6e, 0xb2, 0x61,
-	0x93, 0x3d, 0x71, 0x0b, 0x47, 0x4f, 0xb8, 0xa4, 0xf0, 0x83, 0x2b, 0x8e, 0x3b, 0x9b, 0xc1, 0x38,
-	0x27, 0xff, 0xfa, 0xb3, 0x57, 0xba, 0xad, 0x37, 0x8b, 0x42, 0xee, 0xe1, 0xb4, 0x8b, 0x64, 0x8b,
-	0x4a, 0x2b, 0x47, 0x26, 0x8e, 0xc9, 0x41, 0x76, 0x19, 0x4e, 0xb7, 0xb3, 0xe6, 0x3e, 0xe3, 0xa0,
-	0x07, 0x38, 0xfb, 0x07, 0xbd, 0x90, 0x51, 0xe4, 0xe2, 0x9c, 0x19, 0x88, 0x9c, 0x7c, 0x4a, 0xfc,
-	0xdb, 0x8a, 0x1e, 0x25, 0x43, 0x5d, 0xac, 0x35, 0x7a, 0x6a, 0x77, 0x49, 0x51, 0xa3, 0x29, 0x28,
-	0xca, 0x79, 0x1b, 0xd4, 0x6f, 0xf9, 0xfa, 0x2b, 0x00, 0x00, 0xff, 0xff, 0xbc, 0x7a, 0x78, 0x01,
-	0xd8, 0x02, 0x00, 0x00,
+	// 289 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0xd3, 0xcd, 0x4a, 0xc3, 0x40,
+	0x10, 0x00, 0x60, 0x6b, 0xa4, 0xd2, 0x11, 0x2c, 0xac, 0x45, 0x43, 0x4f, 0x92, 0x53, 0x41, 0xe8,
+	0x41, 0xf1, 0xe2, 0xad, 0x89, 0x1a, 0x3d, 0xa8, 0x50, 0x05, 0xcf, 0x63, 0x76, 0x48, 0x42, 0xd7,
+	0x4d, 0xdd, 0xd9, 0x52, 0xf2, 0xcc, 0xbe, 0x84, 0xe4, 0xa7, 0xd5, 0xd8, 0xd3, 0xde, 0x32, 0x93,
+	0xc9, 0x37, 0x3f, 0x10, 0x18, 0x48, 0x4c, 0xa7, 0x4b, 0x53, 0xd8, 0x42, 0x78, 0x12, 0xd3, 0x40,
+	0xc3, 0x71, 0x4c, 0x9a, 0x0c, 0xaa, 0x39, 0x7d, 0xad, 0x88, 0xad, 0xf0, 0xe1, 0x50, 0x93, 0x5d,
+	0x17, 0x66, 0xe1, 0xf7, 0xce, 0x7b, 0x93, 0xc1, 0x7c, 0x13, 0x8a, 0x09, 0x0c, 0xad, 0x41, 0xcd,
+	0x98, 0xd8, 0xbc, 0xd0, 0x0f, 0xc8, 0x99, 0xbf, 0x5f, 0x57, 0xfc, 0x4f, 0x57, 0x06, 0x4a, 0x69,
+	0x88, 0xd9, 0xf7, 0x1a, 0xa3, 0x0d, 0x83, 0x0b, 0x18, 0x6e, 0xfb, 0xf1, 0xb2, 0xd0, 0x4c, 0x55,
+	0xf1, 0x27, 0x31, 0x63, 0x4a, 0x9b, 0x86, 0x6d, 0x78, 0xf9, 0x7d, 0x00, 0xde, 0x2d, 0xa6, 0xe2,
+	0x1a, 0xfa, 0xcf, 0xb4, 0xae, 0x9e, 0x4e, 0xa6, 0xd5, 0xfc, 0xdd, 0x89, 0xc7, 0xa3, 0x6e, 0xb2,
+	0x61, 0x83, 0x3d, 0x71, 0x03, 0x47, 0x4f, 0xb8, 0xa0, 0xea, 0x05, 0xe7, 0xec, 0xf6, 0x6d, 0x04,
+	0xa3, 0x98, 0xec, 0xdb, 0xef, 0x5e, 0x61, 0x59, 0x6f, 0xe6, 0x84, 0xdc, 0xc1, 0x69, 0x17, 0x89,
+	0xb2, 0x5c, 0x49, 0x43, 0xda, 0x8d, 0x89, 0xc1, 0xef, 0x32, 0x1c, 0x96, 0xb3, 0xe6, 0x9e, 0x6e,
+	0xd0, 0x3d, 0x9c, 0xed, 0x40, 0xaf, 0xa4, 0x25, 0x19, 0x37, 0x67, 0x06, 0x22, 0x26, 0x1b, 0x12,
+	0xff, 0xb5, 0x9c, 0x47, 0x89, 0x50, 0x25, 0x2b, 0x85, 0x96, 0xda, 0x5d, 0x42, 0x54, 0xa8, 0x13,
+	0x72, 0x73, 0x1e, 0x61, 0xbc, 0x75, 0xde, 0xd1, 0x26, 0xd9, 0x8b, 0x56, 0x65, 0x2b, 0xb9, 0x5d,
+	0xe7, 0xa3, 0x5f, 0xff, 0x16, 0x57, 0x3f, 0x03, 0x00, 0x9e, 0x4c, 0xcd, 0xb3, 0x23, 0x03, 0x00,
+	0x00,
 }
@@ -32,6 +32,8 @@ type GeneralRequest struct {
 	GasLimit             uint64   `protobuf:"varint,6,opt,name=gasLimit,proto3" json:"gasLimit,omitempty"`
 	GasPrice             uint64   `protobuf:"varint,7,opt,name=gasPrice,proto3" json:"gasPrice,omitempty"`
 	Payload              []byte   `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	ValidUntil           int64    `protobuf:"varint,9,opt,name=validUntil,proto3" json:"validUntil,omitempty"`
+	Compact              bool     `protobuf:"varint,10,opt,name=compact,proto3" json:"compact,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -122,6 +124,20 @@ func (m *GeneralRequest) GetPayload() []byte {
 	return nil
 }
 
+func (m *GeneralRequest) GetValidUntil() int64 {
+	if m != nil {
+		return m.ValidUntil
+	}
+	return 0
+}
+
+func (m *GeneralRequest) GetCompact() bool {
+	if m != nil {
+		return m.Compact
+	}
+	return false
+}
+
 type GeneralResponse struct {
 	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -173,26 +189,28 @@ func init() {
 func init() { proto.RegisterFile("transaction.proto", fileDescriptor_2cc4e03d2c28c490) }
 
 var fileDescriptor_2cc4e03d2c28c490 = []byte{
-	// 325 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xcd, 0x6a, 0xf2, 0x40,
-	0x14, 0x86, 0xbf, 0x7c, 0x6a, 0xd4, 0x63, 0x51, 0x3a, 0x2d, 0x65, 0xb0, 0x5d, 0x04, 0x57, 0x81,
-	0x82, 0x0b, 0x7b, 0x09, 0xa5, 0x3f, 0x94, 0x2a, 0x32, 0x8a, 0xfb, 0x63, 0x9c, 0xc6, 0x81, 0x71,
-	0xc6, 0xce, 0x4c, 0x28, 0xde, 0x4e, 0xaf, 0xb0, 0x97, 0x50, 0x9c, 0x68, 0x9a, 0x96, 0xee, 0xd2,
-	0x5d, 0x9e, 0xf3, 0x86, 0x37, 0xcf, 0x39, 0x10, 0x38, 0x75, 0x06, 0x95, 0xc5, 0xc4, 0x09, 0xad,
-	0x86, 0x5b, 0xa3, 0x9d, 0x26, 0x9d, 0xd2, 0x68, 0xf0, 0x11, 0x40, 0xf7, 0x81, 0x2b, 0x6e, 0x50,
-	0x32, 0xfe, 0x9a, 0x71, 0xeb, 0xc8, 0x39, 0x34, 0x94, 0x56, 0x09, 0xa7, 0x41, 0x14, 0xc4, 0x75,
-	0x96, 0x03, 0xb9, 0x80, 0x10, 0x37, 0x3a, 0x53, 0x8e, 0xfe, 0x8f, 0x82, 0xf8, 0x84, 0x1d, 0x88,
-	0x50, 0x68, 0xe2, 0x6a, 0x65, 0xb8, 0xb5, 0xb4, 0x16, 0x05, 0x71, 0x9b, 0x1d, 0x91, 0xf4, 0xa1,
-	0x75, 0x78, 0x1c, 0xd1, 0xba, 0x8f, 0x0a, 0x26, 0x31, 0xf4, 0x4a, 0x16, 0x8f, 0x68, 0xd7, 0xb4,
-	0x11, 0xd5, 0xe2, 0x36, 0xfb, 0x39, 0xde, 0xb7, 0xa4, 0x68, 0x9f, 0xc5, 0x46, 0x38, 0x1a, 0x7a,
-	0xa1, 0x82, 0x0f, 0xd9, 0xd4, 0x88, 0x84, 0xd3, 0x66, 0x91, 0x79, 0xde, 0x7b, 0x6d, 0x71, 0x27,
-	0x35, 0xae, 0x68, 0xcb, 0x0b, 0x1f, 0x71, 0x70, 0x0d, 0xbd, 0x62, 0x63, 0xbb, 0xd5, 0xca, 0xfa,
-	0x97, 0x37, 0xdc, 0x5a, 0x4c, 0xf3, 0xa5, 0xdb, 0xec, 0x88, 0xa3, 0xf7, 0x3a, 0x74, 0xe6, 0x5f,
-	0x4a, 0x64, 0x0c, 0xdd, 0x09, 0x7f, 0x2b, 0x4f, 0x2e, 0x87, 0xe5, 0x13, 0x7f, 0xbf, 0x65, 0xff,
-	0xea, 0xf7, 0x30, 0xff, 0xec, 0xe0, 0x1f, 0x61, 0x70, 0x76, 0x8b, 0x32, 0xc9, 0x24, 0x3a, 0x3e,
-	0xd7, 0x0e, 0xe5, 0x02, 0x65, 0xc6, 0xab, 0x75, 0x4e, 0xa0, 0x37, 0x13, 0xa9, 0xfa, 0x33, 0xc7,
-	0x7b, 0x68, 0x4e, 0xb3, 0xa5, 0x14, 0x76, 0x5d, 0xad, 0xe7, 0x09, 0x3a, 0x7b, 0xaf, 0x71, 0x7e,
-	0xd9, 0x6a, 0x5d, 0x77, 0x10, 0x2e, 0xb8, 0x11, 0x2f, 0xbb, 0xca, 0x35, 0x33, 0x67, 0x84, 0x4a,
-	0x2b, 0xd5, 0x2c, 0x43, 0xff, 0x63, 0xdd, 0x7c, 0x06, 0x00, 0x00, 0xff, 0xff, 0xfb, 0x8a, 0x42,
-	0x1d, 0x6d, 0x03, 0x00, 0x00,
+	// 367 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0xdf, 0xea, 0xd3, 0x30,
+	0x14, 0xc7, 0xed, 0xfe, 0x74, 0xeb, 0x99, 0xac, 0x18, 0x45, 0xc2, 0x14, 0x29, 0xbb, 0x2a, 0x08,
+	0xbb, 0x98, 0x8f, 0x20, 0xfe, 0x41, 0xdc, 0x18, 0xd9, 0xdc, 0xfd, 0x59, 0x1b, 0xbb, 0x40, 0x9a,
+	0xd4, 0x24, 0x55, 0xf6, 0x4a, 0x3e, 0x9e, 0x4f, 0x20, 0x6d, 0xd7, 0x5a, 0xc5, 0xbb, 0xfe, 0xee,
+	0xfa, 0x39, 0x5f, 0xfa, 0xc9, 0xb7, 0x39, 0x14, 0x9e, 0x38, 0x83, 0xca, 0x62, 0xe2, 0x84, 0x56,
+	0x9b, 0xc2, 0x68, 0xa7, 0xc9, 0xa2, 0x37, 0x5a, 0xff, 0x1c, 0xc1, 0xf2, 0x03, 0x57, 0xdc, 0xa0,
+	0x64, 0xfc, 0x5b, 0xc9, 0xad, 0x23, 0xcf, 0x60, 0xaa, 0xb4, 0x4a, 0x38, 0xf5, 0x22, 0x2f, 0x9e,
+	0xb0, 0x06, 0xc8, 0x73, 0xf0, 0x31, 0xd7, 0xa5, 0x72, 0x74, 0x14, 0x79, 0xf1, 0x63, 0x76, 0x27,
+	0x42, 0x61, 0x86, 0x69, 0x6a, 0xb8, 0xb5, 0x74, 0x1c, 0x79, 0x71, 0xc0, 0x5a, 0x24, 0x2b, 0x98,
+	0xdf, 0x1f, 0xb7, 0x74, 0x52, 0x47, 0x1d, 0x93, 0x18, 0xc2, 0x5e, 0x8b, 0x8f, 0x68, 0xaf, 0x74,
+	0x1a, 0x8d, 0xe3, 0x80, 0xfd, 0x3b, 0xae, 0x2c, 0x19, 0xda, 0xcf, 0x22, 0x17, 0x8e, 0xfa, 0x75,
+	0xa1, 0x8e, 0xef, 0xd9, 0xc1, 0x88, 0x84, 0xd3, 0x59, 0x97, 0xd5, 0x5c, 0xf5, 0x2a, 0xf0, 0x26,
+	0x35, 0xa6, 0x74, 0x5e, 0x17, 0x6e, 0x91, 0xbc, 0x02, 0xf8, 0x8e, 0x52, 0xa4, 0x5f, 0x94, 0x13,
+	0x92, 0x06, 0x91, 0x17, 0x8f, 0x59, 0x6f, 0x52, 0xbd, 0x99, 0xe8, 0xbc, 0xc0, 0xc4, 0x51, 0x88,
+	0xbc, 0x78, 0xce, 0x5a, 0x5c, 0xbf, 0x86, 0xb0, 0xbb, 0x2b, 0x5b, 0x68, 0x65, 0xeb, 0x63, 0x72,
+	0x6e, 0x2d, 0x66, 0xcd, 0x75, 0x05, 0xac, 0xc5, 0xed, 0xaf, 0x09, 0x2c, 0x4e, 0x7f, 0x3e, 0x86,
+	0xec, 0x60, 0xb9, 0xe7, 0x3f, 0xfa, 0x93, 0x17, 0x9b, 0xfe, 0x72, 0xfe, 0xde, 0xc2, 0xea, 0xe5,
+	0xff, 0xc3, 0xe6, 0xd8, 0xf5, 0x23, 0xc2, 0xe0, 0xe9, 0x5b, 0x94, 0x49, 0x29, 0xd1, 0xf1, 0x93,
+	0x76, 0x28, 0xcf, 0x28, 0x4b, 0x3e, 0xcc, 0xb9, 0x87, 0xf0, 0x28, 0x32, 0xf5, 0x60, 0x1d, 0xf7,
+	0x10, 0xee, 0xb8, 0xc9, 0x78, 0x25, 0x45, 0x57, 0x1a, 0x6e, 0x87, 0xf9, 0xde, 0xc3, 0xec, 0x50,
+	0x5e, 0xa4, 0xb0, 0xd7, 0x61, 0x9e, 0x4f, 0xb0, 0xa8, 0x2a, 0xed, 0x9a, 0x4d, 0x0d, 0x73, 0xbd,
+	0x03, 0xff, 0xcc, 0x8d, 0xf8, 0x7a, 0x1b, 0xac, 0x39, 0x3a, 0x23, 0x54, 0x36, 0x48, 0x73, 0xf1,
+	0xeb, 0x5f, 0xfc, 0xcd, 0xef, 0x01, 0x00, 0x16, 0x40, 0xd5, 0xbe, 0xf7, 0x03, 0x00, 0x00,
 }
@@ -3,18 +3,26 @@ package accounts
 
 import (
 	"context"
-	"crypto/elliptic"
+	gocrypto "crypto"
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/pem"
+	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/btcsuite/btcd/btcec"
 	account "github.com/polaris-project/go-polaris/accounts"
 	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
 
 	accountsProto "github.com/polaris-project/go-polaris/internal/proto/accounts"
 )
 
+// secp256k1PemType is the PEM block type of a raw secp256k1 private key (which x509 cannot marshal).
+const secp256k1PemType = "SECP256K1 PRIVATE KEY"
+
 // Server represents a Polaris RPC server.
 type Server struct{}
 
@@ -22,12 +30,22 @@ type Server struct{}
 
 // NewAccount handles the NewAccount request method.
 func (server *Server) NewAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
-	account, err := account.NewAccount() // Initialize new account
-	if err != nil {                      // Check for errors
+	scheme := crypto.P521 // Init scheme
+
+	if request.Scheme != "" { // Check scheme specified
+		var err error // Init error buffer
+
+		if scheme, err = crypto.SignatureSchemeFromString(request.Scheme); err != nil { // Get scheme
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+	}
+
+	account, err := account.NewAccountWithScheme(scheme) // Initialize new account
+	if err != nil {                                      // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = account.WriteToMemory() // Write account to persistent memory
+	err = account.WriteEncryptedToMemory(request.Passphrase) // Write encrypted account to persistent memory
 
 	if err != nil { // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
@@ -56,45 +74,65 @@ func (server *Server) AccountFromKey(ctx context.Context, request *accountsProto
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	block, _ := pem.Decode(decodedBytes) // Decode private key pem
+	privateKey, err := parsePrivateKey(decodedBytes) // Parse private key
+	if err != nil {                                  // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
 
-	privateKey, err := x509.ParseECPrivateKey(block.Bytes) // Parse PEM block
-	if err != nil {                                        // Check for errors
+	account, err := account.AccountFromSigner(privateKey) // Initialize account
+	if err != nil {                                       // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = account.WriteEncryptedToMemory(request.Passphrase) // Write encrypted account to persistent memory
+
+	if err != nil { // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(account.AccountFromKey(privateKey).Address().Bytes())}, nil // Return account address
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(account.Address().Bytes())}, nil // Return account address
 }
 
 // Address handles the Address request method.
 func (server *Server) Address(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
-	addressBytes, err := hex.DecodeString(request.PrivatePublicKey) // Decode address
-	if err != nil {                                                 // Check for errors
+	address, err := account.ResolveAddress(request.PrivatePublicKey) // Resolve address
+	if err != nil {                                                  // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := account.ReadAccountFromMemory(common.NewAddress(addressBytes)) // Read account
-	if err != nil {                                                                // Check for errors
+	if _, err = account.ReadWatchOnlyAccountFromMemory(address); err == nil { // Check is watch-only account
+		return &accountsProto.GeneralResponse{Message: hex.EncodeToString(address.Bytes())}, nil // Return account address
+	}
+
+	scheme, publicKeyBytes, err := readPublicKey(address) // Read account public key
+	if err != nil {                                       // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(account.Address().Bytes())}, nil // Return account address
+	algorithm, err := crypto.GetSignatureAlgorithm(scheme) // Get signature algorithm
+	if err != nil {                                        // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	if address, err = algorithm.Address(publicKeyBytes); err != nil { // Derive address
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(address.Bytes())}, nil // Return account address
 }
 
 // PublicKey handles the PublicKey request method.
 func (server *Server) PublicKey(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
-	addressBytes, err := hex.DecodeString(request.PrivatePublicKey) // Decode address
-	if err != nil {                                                 // Check for errors
+	address, err := account.ResolveAddress(request.PrivatePublicKey) // Resolve address
+	if err != nil {                                                  // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := account.ReadAccountFromMemory(common.NewAddress(addressBytes)) // Read account
-	if err != nil {                                                                // Check for errors
+	_, publicKeyBytes, err := readPublicKey(address) // Read account public key
+	if err != nil {                                  // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	publicKeyBytes := elliptic.Marshal(elliptic.P521(), account.PublicKey().X, account.PublicKey().Y) // Marshal public key
-
 	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(publicKeyBytes)}, nil // Return marshaled account public key
 }
 
@@ -110,13 +148,11 @@ func (server *Server) PrivateKey(ctx context.Context, request *accountsProto.Gen
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	marshaledPrivateKey, err := x509.MarshalECPrivateKey(account.PrivateKey()) // Marshal private key
-	if err != nil {                                                            // Check for errors
+	pemEncoded, err := marshalPrivateKey(account) // Marshal private key
+	if err != nil {                               // Check for errors
 		return &accountsProto.GeneralResponse{}, err // Return found error
 	}
 
-	pemEncoded := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: marshaledPrivateKey}) // Encode to memory
-
 	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(pemEncoded)}, nil // Return hex encoded private key
 }
 
@@ -135,4 +171,319 @@ func (server *Server) String(ctx context.Context, request *accountsProto.General
 	return &accountsProto.GeneralResponse{Message: account.String()}, nil // Return account string
 }
 
+// NewMultisigAccount handles the NewMultisigAccount request method.
+func (server *Server) NewMultisigAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	var keys []crypto.MultisigKey // Init key buffer
+
+	for _, publicKey := range request.PublicKeys { // Iterate through public keys
+		key, err := parseMultisigKey(publicKey) // Parse key
+		if err != nil {                         // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		keys = append(keys, key) // Append key
+	}
+
+	account, err := account.NewMultisigAccount(request.Threshold, keys) // Initialize multi-signature account
+	if err != nil {                                                     // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = account.WriteToMemory() // Write account to persistent memory
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(account.Address().Bytes())}, nil // Return account address
+}
+
+// Unlock handles the Unlock request method.
+func (server *Server) Unlock(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	addressBytes, err := hex.DecodeString(request.PrivatePublicKey) // Decode address
+	if err != nil {                                                 // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = account.Unlock(common.NewAddress(addressBytes), request.Passphrase, time.Duration(request.Timeout)*time.Second) // Unlock account
+
+	if err != nil { // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("unlocked account %s", hex.EncodeToString(addressBytes))}, nil // Return success
+}
+
+// Lock handles the Lock request method.
+func (server *Server) Lock(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	addressBytes, err := hex.DecodeString(request.PrivatePublicKey) // Decode address
+	if err != nil {                                                 // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	account.Lock(common.NewAddress(addressBytes)) // Lock account
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("locked account %s", hex.EncodeToString(addressBytes))}, nil // Return success
+}
+
+// ImportAccount handles the ImportAccount request method.
+func (server *Server) ImportAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	address, err := account.ImportPlaintextAccount(request.Path, request.Passphrase) // Import account
+	if err != nil {                                                                  // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(address.Bytes())}, nil // Return account address
+}
+
+// NewWallet handles the NewWallet request method.
+func (server *Server) NewWallet(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	mnemonic, err := account.NewMnemonic() // Generate mnemonic
+	if err != nil {                        // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	wallet, err := writeWallet(mnemonic, request) // Write wallet to persistent memory
+	if err != nil {                               // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("wallet: %s\nmnemonic: %s", wallet.ID, mnemonic)}, nil // Return wallet ID and mnemonic
+}
+
+// RestoreWallet handles the RestoreWallet request method.
+func (server *Server) RestoreWallet(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	wallet, err := writeWallet(request.Mnemonic, request) // Write wallet to persistent memory
+	if err != nil {                                       // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	var addressStrings []string // Init string buffer
+
+	for i := uint32(0); i < request.Count; i++ { // Derive accounts
+		account, err := wallet.DeriveNextAccount(request.Passphrase) // Derive account
+		if err != nil {                                              // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		addressStrings = append(addressStrings, hex.EncodeToString(account.Address().Bytes())) // Append hex encoded address
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("wallet: %s\naccounts: %s", wallet.ID, strings.Join(addressStrings, ", "))}, nil // Return wallet ID and accounts
+}
+
+// DeriveAccount handles the DeriveAccount request method.
+func (server *Server) DeriveAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	wallet, err := account.ReadEncryptedWalletFromMemory(request.Wallet) // Read wallet
+	if err != nil {                                                      // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	account, err := wallet.DeriveNextAccount(request.Passphrase) // Derive account
+	if err != nil {                                              // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(account.Address().Bytes())}, nil // Return account address
+}
+
+// GetAllWallets handles the GetAllWallets request method.
+func (server *Server) GetAllWallets(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	return &accountsProto.GeneralResponse{Message: strings.Join(account.GetAllWallets(), ", ")}, nil // Return wallets
+}
+
+// AddWatchOnlyAccount handles the AddWatchOnlyAccount request method.
+func (server *Server) AddWatchOnlyAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	var watchOnlyAccount *account.WatchOnlyAccount // Init account buffer
+
+	if addressBytes, err := hex.DecodeString(request.PrivatePublicKey); err == nil && len(addressBytes) == common.AddressLength { // Check is address
+		if watchOnlyAccount, err = account.NewWatchOnlyAccount(common.NewAddress(addressBytes), request.Label); err != nil { // Initialize account
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+	} else {
+		key, err := parseMultisigKey(request.PrivatePublicKey) // Parse public key
+		if err != nil {                                        // Check for errors
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+
+		if watchOnlyAccount, err = account.NewWatchOnlyAccountFromPublicKey(key.Scheme, key.MarshaledPublicKey, request.Label); err != nil { // Initialize account
+			return &accountsProto.GeneralResponse{}, err // Return found error
+		}
+	}
+
+	if err := watchOnlyAccount.WriteToMemory(); err != nil { // Write account to persistent memory
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: hex.EncodeToString(watchOnlyAccount.Address.Bytes())}, nil // Return account address
+}
+
+// GetAllWatchOnlyAccounts handles the GetAllWatchOnlyAccounts request method.
+func (server *Server) GetAllWatchOnlyAccounts(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	var accountStrings []string // Init string buffer
+
+	for _, watchOnlyAccount := range account.GetAllWatchOnlyAccounts() { // Iterate through accounts
+		accountStrings = append(accountStrings, fmt.Sprintf("%s: %s", watchOnlyAccount.Label, hex.EncodeToString(watchOnlyAccount.Address.Bytes()))) // Append label and hex encoded address
+	}
+
+	return &accountsProto.GeneralResponse{Message: strings.Join(accountStrings, ", ")}, nil // Return accounts
+}
+
+// RemoveWatchOnlyAccount handles the RemoveWatchOnlyAccount request method.
+func (server *Server) RemoveWatchOnlyAccount(ctx context.Context, request *accountsProto.GeneralRequest) (*accountsProto.GeneralResponse, error) {
+	address, err := account.ResolveAddress(request.PrivatePublicKey) // Resolve address
+	if err != nil {                                                  // Check for errors
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	if err = account.RemoveWatchOnlyAccount(address); err != nil { // Remove account
+		return &accountsProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &accountsProto.GeneralResponse{Message: fmt.Sprintf("removed watch-only account %s", hex.EncodeToString(address.Bytes()))}, nil // Return success
+}
+
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// parseMultisigKey parses a hex encoded public key (as returned by PublicKey), optionally prefixed with the name of its signature
+// scheme and a colon (e.g. "ed25519:<key>"). Keys without a prefix are P-521 keys.
+func parseMultisigKey(publicKey string) (crypto.MultisigKey, error) {
+	scheme := crypto.P521 // Init scheme
+
+	if separator := strings.Index(publicKey, ":"); separator >= 0 { // Check has scheme prefix
+		var err error // Init error buffer
+
+		if scheme, err = crypto.SignatureSchemeFromString(publicKey[:separator]); err != nil { // Get scheme
+			return crypto.MultisigKey{}, err // Return found error
+		}
+
+		publicKey = publicKey[separator+1:] // Trim prefix
+	}
+
+	marshaledPublicKey, err := hex.DecodeString(publicKey) // Decode public key
+	if err != nil {                                        // Check for errors
+		return crypto.MultisigKey{}, err // Return found error
+	}
+
+	return crypto.MultisigKey{Scheme: scheme, MarshaledPublicKey: marshaledPublicKey}, nil // Return key
+}
+
+// writeWallet initializes a wallet of the requested signature scheme (or P-521) from a given mnemonic, and writes it to persistent
+// memory, encrypted with the requested passphrase.
+func writeWallet(mnemonic string, request *accountsProto.GeneralRequest) (*account.EncryptedWallet, error) {
+	scheme := crypto.P521 // Init scheme
+
+	if request.Scheme != "" { // Check scheme specified
+		var err error // Init error buffer
+
+		if scheme, err = crypto.SignatureSchemeFromString(request.Scheme); err != nil { // Get scheme
+			return nil, err // Return found error
+		}
+	}
+
+	wallet, err := account.NewWalletFromMnemonic(mnemonic, scheme) // Initialize wallet
+	if err != nil {                                                // Check for errors
+		return nil, err // Return found error
+	}
+
+	encryptedWallet, err := wallet.Encrypt(request.Passphrase) // Encrypt wallet
+	if err != nil {                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	return encryptedWallet, encryptedWallet.WriteToMemory() // Write wallet to persistent memory
+}
+
+// readPublicKey reads the signature scheme and marshaled public key of the account with a given address, without decrypting the
+// account if it is encrypted.
+func readPublicKey(address *common.Address) (crypto.SignatureScheme, []byte, error) {
+	encryptedAccount, err := account.ReadEncryptedAccountFromMemory(address) // Read encrypted account
+	if err == nil {                                                          // Check is encrypted
+		publicKeyBytes, err := hex.DecodeString(encryptedAccount.PublicKey) // Decode public key
+
+		return encryptedAccount.Scheme, publicKeyBytes, err // Return public key
+	}
+
+	if os.IsNotExist(err) { // Check no account
+		watchOnlyAccount, watchOnlyErr := account.ReadWatchOnlyAccountFromMemory(address) // Read watch-only account
+		if watchOnlyErr == nil && watchOnlyAccount.MarshaledPublicKey != nil {            // Check has public key
+			return watchOnlyAccount.Scheme, watchOnlyAccount.MarshaledPublicKey, nil // Return public key
+		}
+	}
+
+	if err != account.ErrAccountNotEncrypted { // Check for errors
+		return 0, nil, err // Return found error
+	}
+
+	account, err := account.ReadAccountFromMemory(address) // Read account
+	if err != nil {                                        // Check for errors
+		return 0, nil, err // Return found error
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(account.Scheme) // Get signature algorithm
+	if err != nil {                                                // Check for errors
+		return 0, nil, err // Return found error
+	}
+
+	publicKeyBytes, err := algorithm.MarshalPublicKey(account.Signer().Public()) // Marshal public key
+
+	return account.Scheme, publicKeyBytes, err // Return public key
+}
+
+// marshalPrivateKey PEM-encodes the private key of a given account: as an EC private key (P-521), a PKCS #8 private key (Ed25519),
+// or a raw secp256k1 private key.
+func marshalPrivateKey(account *account.Account) ([]byte, error) {
+	var marshaledPrivateKey []byte // Init marshaled private key buffer
+
+	var err error // Init error buffer
+
+	switch account.Scheme { // Handle schemes
+	case crypto.Secp256k1:
+		return pem.EncodeToMemory(&pem.Block{Type: secp256k1PemType, Bytes: (*btcec.PrivateKey)(account.PrivateKey()).Serialize()}), nil // Return encoded private key
+	case crypto.Ed25519:
+		marshaledPrivateKey, err = x509.MarshalPKCS8PrivateKey(account.Signer()) // Marshal private key
+	default:
+		marshaledPrivateKey, err = x509.MarshalECPrivateKey(account.PrivateKey()) // Marshal private key
+	}
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: marshaledPrivateKey}), nil // Return encoded private key
+}
+
+// parsePrivateKey decodes a private key PEM-encoded via marshalPrivateKey.
+func parsePrivateKey(pemEncoded []byte) (gocrypto.Signer, error) {
+	block, _ := pem.Decode(pemEncoded) // Decode private key pem
+
+	if block == nil { // Check invalid pem
+		return nil, crypto.ErrUnsupportedKey // Return error
+	}
+
+	if block.Type == secp256k1PemType { // Check is secp256k1
+		privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), block.Bytes) // Parse private key
+
+		return privateKey.ToECDSA(), nil // Return private key
+	}
+
+	if privateKey, err := x509.ParseECPrivateKey(block.Bytes); err == nil { // Parse EC private key
+		return privateKey, nil // Return private key
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes) // Parse PKCS #8 private key
+	if err != nil {                                           // Check for errors
+		return nil, err // Return found error
+	}
+
+	signer, ok := privateKey.(gocrypto.Signer) // Get signer
+	if !ok {                                   // Check not a signer
+		return nil, crypto.ErrUnsupportedKey // Return error
+	}
+
+	return signer, nil // Return private key
+}
+
+/* END INTERNAL METHODS */
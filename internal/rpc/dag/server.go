@@ -4,10 +4,12 @@ package dag
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"strings"
 
 	"github.com/polaris-project/go-polaris/p2p"
 
+	"github.com/polaris-project/go-polaris/accounts"
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
 	dagProto "github.com/polaris-project/go-polaris/internal/proto/dag"
@@ -104,13 +106,13 @@ func (server *Server) GetTransactionChildren(ctx context.Context, request *dagPr
 func (server *Server) GetTransactionsByAddress(ctx context.Context, request *dagProto.GeneralRequest) (*dagProto.GeneralResponse, error) {
 	dag := (*p2p.WorkingClient.Validator).GetWorkingDag() // Get working dag
 
-	addressBytes, err := hex.DecodeString(request.Address) // Decode address value
-	if err != nil {                                        // Check for errors
+	address, err := accounts.ResolveAddress(request.Address) // Resolve address
+	if err != nil {                                          // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
-	transactions, err := dag.GetTransactionsByAddress(common.NewAddress(addressBytes)) // Query tx
-	if err != nil {                                                                    // Check for errors
+	transactions, err := dag.GetTransactionsByAddress(address) // Query tx
+	if err != nil {                                            // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -127,13 +129,13 @@ func (server *Server) GetTransactionsByAddress(ctx context.Context, request *dag
 func (server *Server) GetTransactionsBySender(ctx context.Context, request *dagProto.GeneralRequest) (*dagProto.GeneralResponse, error) {
 	dag := (*p2p.WorkingClient.Validator).GetWorkingDag() // Get working dag
 
-	addressBytes, err := hex.DecodeString(request.Address) // Decode address hex value
-	if err != nil {                                        // Check for errors
+	address, err := accounts.ResolveAddress(request.Address) // Resolve address
+	if err != nil {                                          // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
-	transactions, err := dag.GetTransactionsBySender(common.NewAddress(addressBytes)) // Query tx
-	if err != nil {                                                                   // Check for errors
+	transactions, err := dag.GetTransactionsBySender(address) // Query tx
+	if err != nil {                                           // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -162,17 +164,35 @@ func (server *Server) GetBestTransaction(ctx context.Context, request *dagProto.
 func (server *Server) CalculateAddressBalance(ctx context.Context, request *dagProto.GeneralRequest) (*dagProto.GeneralResponse, error) {
 	dag := (*p2p.WorkingClient.Validator).GetWorkingDag() // Get working dag
 
-	addressBytes, err := hex.DecodeString(request.Address) // Decode address hex value
-	if err != nil {                                        // Check for errors
+	address, err := accounts.ResolveAddress(request.Address) // Resolve address
+	if err != nil {                                          // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
-	balance, err := dag.CalculateAddressBalance(common.NewAddress(addressBytes)) // Calculate balance
-	if err != nil {                                                              // Check for errors
+	balance, err := dag.CalculateAddressBalance(address) // Calculate balance
+	if err != nil {                                      // Check for errors
 		return &dagProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &dagProto.GeneralResponse{Message: balance.String()}, nil // Return balance
+	return &dagProto.GeneralResponse{Message: common.FormatAmount(balance)}, nil // Return balance (in units)
+}
+
+// CalculateWatchOnlyBalances handles the CalculateWatchOnlyBalances request method.
+func (server *Server) CalculateWatchOnlyBalances(ctx context.Context, request *dagProto.GeneralRequest) (*dagProto.GeneralResponse, error) {
+	dag := (*p2p.WorkingClient.Validator).GetWorkingDag() // Get working dag
+
+	var balanceStrings []string // Init string value buffer
+
+	for _, account := range accounts.GetAllWatchOnlyAccounts() { // Iterate through watch-only accounts
+		balance, err := dag.CalculateAddressBalance(account.Address) // Calculate balance
+		if err != nil {                                              // Check for errors
+			return &dagProto.GeneralResponse{}, err // Return found error
+		}
+
+		balanceStrings = append(balanceStrings, fmt.Sprintf("%s (%s): %s", account.Label, hex.EncodeToString(account.Address.Bytes()), common.FormatAmount(balance))) // Append balance (in units)
+	}
+
+	return &dagProto.GeneralResponse{Message: strings.Join(balanceStrings, ", ")}, nil // Return balances
 }
 
 /* END EXPORTED METHODS */
@@ -0,0 +1,65 @@
+// Package mempool represents the mempool RPC server.
+package mempool
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/polaris-project/go-polaris/common"
+	mempoolProto "github.com/polaris-project/go-polaris/internal/proto/mempool"
+	"github.com/polaris-project/go-polaris/p2p"
+	"github.com/polaris-project/go-polaris/types"
+)
+
+// Server represents a Polaris RPC server.
+type Server struct{}
+
+/* BEGIN EXPORTED METHODS */
+
+// GetPending handles the GetPending request method.
+func (server *Server) GetPending(ctx context.Context, request *mempoolProto.GeneralRequest) (*mempoolProto.GeneralResponse, error) {
+	return &mempoolProto.GeneralResponse{Message: joinHashes(p2p.WorkingClient.Mempool.Pending())}, nil // Return hashes
+}
+
+// GetTransactionByHash handles the GetTransactionByHash request method.
+func (server *Server) GetTransactionByHash(ctx context.Context, request *mempoolProto.GeneralRequest) (*mempoolProto.GeneralResponse, error) {
+	transactionHashBytes, err := hex.DecodeString(request.TransactionHash) // Decode hash hex value
+	if err != nil {                                                        // Check for errors
+		return &mempoolProto.GeneralResponse{}, err // Return found error
+	}
+
+	transaction, err := p2p.WorkingClient.Mempool.GetTransaction(common.NewHash(transactionHashBytes)) // Query pending tx
+	if err != nil {                                                                                    // Check for errors
+		return &mempoolProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &mempoolProto.GeneralResponse{Message: transaction.String()}, nil // Return tx JSON string value
+}
+
+// GetTransactionsBySender handles the GetTransactionsBySender request method.
+func (server *Server) GetTransactionsBySender(ctx context.Context, request *mempoolProto.GeneralRequest) (*mempoolProto.GeneralResponse, error) {
+	addressBytes, err := hex.DecodeString(request.Address) // Decode address hex value
+	if err != nil {                                        // Check for errors
+		return &mempoolProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &mempoolProto.GeneralResponse{Message: joinHashes(p2p.WorkingClient.Mempool.GetTransactionsBySender(common.NewAddress(addressBytes)))}, nil // Return hashes
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// joinHashes joins the hex-encoded hashes of given transactions.
+func joinHashes(transactions []*types.Transaction) string {
+	var hashStrings []string // Init string value buffer
+
+	for _, transaction := range transactions { // Iterate through transactions
+		hashStrings = append(hashStrings, hex.EncodeToString(transaction.Hash.Bytes())) // Append hash
+	}
+
+	return strings.Join(hashStrings, ", ") // Return hashes
+}
+
+/* END INTERNAL METHODS */
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	"github.com/polaris-project/go-polaris/accounts"
 	"github.com/polaris-project/go-polaris/common"
@@ -25,7 +26,9 @@ var (
 )
 
 // Server represents a Polaris RPC server.
-type Server struct{}
+type Server struct {
+	Signer accounts.Signer // Source of signing keys (the keystore if nil)
+}
 
 /* BEGIN EXPORTED METHODS */
 
@@ -41,8 +44,8 @@ func (server *Server) NewTransaction(ctx context.Context, request *transactionPr
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	amount, _, err := big.ParseFloat(string(request.Amount), 10, 18, big.ToNearestEven) // Parse amount value
-	if err != nil {                                                                     // Check for errors
+	amount, err := common.ParseAmount(string(request.Amount)) // Parse amount value (in units) to base units
+	if err != nil {                                           // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -57,7 +60,15 @@ func (server *Server) NewTransaction(ctx context.Context, request *transactionPr
 		parentHashes = append(parentHashes, common.NewHash(parentHashBytes)) // Append hash
 	}
 
-	transaction := types.NewTransaction(request.Nonce, amount, common.NewAddress(senderBytes), common.NewAddress(recipientBytes), parentHashes, request.GasLimit, big.NewInt(int64(request.GasPrice)), request.Payload) // Initialize transaction
+	var validUntil time.Time // Init expiry buffer
+
+	if request.ValidUntil != 0 { // Check has expiry
+		validUntil = time.Unix(request.ValidUntil, 0) // Set expiry
+	}
+
+	network := (*p2p.WorkingClient.Validator).GetWorkingConfig().Network // Get working network ID
+
+	transaction := types.NewTransaction(request.Nonce, amount, common.NewAddress(senderBytes), common.NewAddress(recipientBytes), parentHashes, request.GasLimit, big.NewInt(int64(request.GasPrice)), request.Payload, network, validUntil) // Initialize transaction
 
 	if err != nil { // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
@@ -88,7 +99,7 @@ func (server *Server) CalculateTotalValue(ctx context.Context, request *transact
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &transactionProto.GeneralResponse{Message: transaction.CalculateTotalValue().String()}, nil // Return total value
+	return &transactionProto.GeneralResponse{Message: common.FormatAmount(transaction.CalculateTotalValue())}, nil // Return total value
 }
 
 // SignTransaction handles the SignTransaction request method.
@@ -107,12 +118,20 @@ func (server *Server) SignTransaction(ctx context.Context, request *transactionP
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(common.NewAddress(transaction.Sender.Bytes())) // Open account
-	if err != nil {                                                                               // Check for errors
+	if multisigAccount, err := accounts.ReadMultisigAccountFromMemory(transaction.Sender); err == nil { // Check sent from multi-signature address
+		return signMultisigTransaction(transaction, multisigAccount, server.signer(), request.Address) // Add signature of signer
+	}
+
+	privateKey, err := server.signer().Key(common.NewAddress(transaction.Sender.Bytes())) // Get sender key
+	if err != nil {                                                                       // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = types.SignTransaction(transaction, account.PrivateKey()) // Sign transaction
+	if request.Compact { // Check compact
+		err = types.SignTransactionCompact(transaction, privateKey) // Sign transaction
+	} else {
+		err = types.SignTransaction(transaction, privateKey) // Sign transaction
+	}
 
 	if err != nil { // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
@@ -127,6 +146,43 @@ func (server *Server) SignTransaction(ctx context.Context, request *transactionP
 	return &transactionProto.GeneralResponse{Message: hex.EncodeToString(transaction.Hash.Bytes())}, nil // Return signature
 }
 
+// MergeSignatures handles the MergeSignatures request method.
+func (server *Server) MergeSignatures(ctx context.Context, request *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
+	if len(request.TransactionHash) < 2 { // Check nothing to merge
+		return &transactionProto.GeneralResponse{}, ErrNilHashRequest // Return error
+	}
+
+	var transactions []*types.Transaction // Init transaction buffer
+
+	for _, transactionHash := range request.TransactionHash { // Iterate through transaction hashes
+		transactionHashBytes, err := hex.DecodeString(transactionHash) // Get transaction hash byte value
+		if err != nil {                                                // Check for errors
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+
+		transaction, err := types.ReadTransactionFromMemory(common.NewHash(transactionHashBytes)) // Read transaction
+		if err != nil {                                                                           // Check for errors
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+	}
+
+	for _, other := range transactions[1:] { // Iterate through copies
+		if err := types.MergeMultisigSignatures(transactions[0], other); err != nil { // Merge signatures
+			return &transactionProto.GeneralResponse{}, err // Return found error
+		}
+	}
+
+	err := transactions[0].WriteToMemory() // Write transaction to mempool
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: hex.EncodeToString(transactions[0].Hash.Bytes())}, nil // Return transaction hash
+}
+
 // Publish handles the Publish request method.
 func (server *Server) Publish(ctx context.Context, request *transactionProto.GeneralRequest) (*transactionProto.GeneralResponse, error) {
 	if len(request.TransactionHash) == 0 { // Check nothing to read
@@ -143,12 +199,6 @@ func (server *Server) Publish(ctx context.Context, request *transactionProto.Gen
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	err = (*p2p.WorkingClient.Validator).GetWorkingDag().AddTransaction(transaction) // Add transaction
-
-	if err != nil && err != types.ErrDuplicateTransaction { // Check for errors (other than duplicate transaction)
-		return &transactionProto.GeneralResponse{}, err // Return found error
-	}
-
 	publishContext, cancel := context.WithCancel(ctx) // Get context
 
 	defer cancel() // Cancel
@@ -169,8 +219,8 @@ func (server *Server) SignMessage(ctx context.Context, request *transactionProto
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	account, err := accounts.ReadAccountFromMemory(common.NewAddress(senderBytes)) // Open account
-	if err != nil {                                                                // Check for errors
+	privateKey, err := server.signer().Key(common.NewAddress(senderBytes)) // Get sender key
+	if err != nil {                                                        // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -178,8 +228,8 @@ func (server *Server) SignMessage(ctx context.Context, request *transactionProto
 		return &transactionProto.GeneralResponse{}, ErrInvalidHashRequest
 	}
 
-	signature, err := types.SignMessage(common.NewHash(request.Payload), account.PrivateKey()) // Sign message
-	if err != nil {                                                                            // Check for errors
+	signature, err := types.SignMessage(common.NewHash(request.Payload), privateKey) // Sign message
+	if err != nil {                                                                  // Check for errors
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
@@ -198,7 +248,7 @@ func (server *Server) Verify(ctx context.Context, request *transactionProto.Gene
 		return &transactionProto.GeneralResponse{}, err // Return found error
 	}
 
-	return &transactionProto.GeneralResponse{Message: strconv.FormatBool(transaction.Signature.Verify(transaction.Sender))}, nil // Return is valid
+	return &transactionProto.GeneralResponse{Message: strconv.FormatBool(transaction.VerifySignature())}, nil // Return is valid
 }
 
 // String handles the String request method.
@@ -217,3 +267,44 @@ func (server *Server) String(ctx context.Context, request *transactionProto.Gene
 }
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// signer gets the source of the server's signing keys.
+func (server *Server) signer() accounts.Signer {
+	if server.Signer == nil { // Check no signer
+		return accounts.KeystoreSigner{} // Return keystore
+	}
+
+	return server.Signer // Return signer
+}
+
+// signMultisigTransaction adds the signature of the key with a given (hex encoded) address, held by a given source of signing keys, to
+// a given transaction sent from a given multi-signature account, and writes the signed transaction to the mempool.
+func signMultisigTransaction(transaction *types.Transaction, multisigAccount *accounts.MultisigAccount, keys accounts.Signer, signer string) (*transactionProto.GeneralResponse, error) {
+	signerBytes, err := hex.DecodeString(signer) // Decode signer address hex-encoded string value
+	if err != nil {                              // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	privateKey, err := keys.Key(common.NewAddress(signerBytes)) // Get signer key
+	if err != nil {                                             // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = types.SignMultisigTransaction(transaction, multisigAccount.Policy, privateKey) // Add signature
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	err = transaction.WriteToMemory() // Write transaction to mempool
+
+	if err != nil { // Check for errors
+		return &transactionProto.GeneralResponse{}, err // Return found error
+	}
+
+	return &transactionProto.GeneralResponse{Message: hex.EncodeToString(transaction.Hash.Bytes())}, nil // Return transaction hash
+}
+
+/* END INTERNAL METHODS */
@@ -4,6 +4,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
@@ -11,7 +12,9 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -21,8 +24,10 @@ import (
 	"github.com/juju/loggo/loggocolor"
 
 	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/storage"
 	"github.com/polaris-project/go-polaris/types"
 
+	"github.com/polaris-project/go-polaris/accounts"
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/p2p"
 	"github.com/polaris-project/go-polaris/validator"
@@ -31,8 +36,22 @@ import (
 	"github.com/polaris-project/go-polaris/cli"
 )
 
-// errNoBootstrap defines an invalid bootstrap value error.
-var errNoBootstrap = errors.New("bootstrap failed: was expecting a bootstrap peer address, got 'localhost' (must be able to bootstrap dag config if no config exists locally)")
+var (
+	// errNoBootstrap defines an invalid bootstrap value error.
+	errNoBootstrap = errors.New("bootstrap failed: was expecting a bootstrap peer address, got 'localhost' (must be able to bootstrap dag config if no config exists locally)")
+
+	// errUnknownStorage defines an invalid storage backend error.
+	errUnknownStorage = errors.New("unknown storage backend (available backends: bolt, memory)")
+
+	// errUnknownCommand defines an invalid subcommand error.
+	errUnknownCommand = errors.New("unknown command (available commands: rebuild-account-state, export <file>, import <file>)")
+
+	// errNoArchivePath defines a missing archive path error.
+	errNoArchivePath = errors.New("was expecting an archive file path")
+
+	// errAccountStateMismatch defines an error describing a stored account state that did not match its rebuilt equivalent.
+	errAccountStateMismatch = errors.New("stored account state did not match rebuilt account state")
+)
 
 var (
 	dataDirFlag              = flag.String("data-dir", common.DataDir, "performs all node I/O operations in a given data directory")                            // Init data dir flag
@@ -49,6 +68,9 @@ var (
 	terminalFlag             = flag.Bool("terminal", false, "launch with terminal")                                                                             // Init terminal flag
 	rpcPortFlag              = flag.Int("rpc-port", 8000, "port to connect to via RPC")                                                                         // Init RPC port flag
 	rpcAddrFlag              = flag.String("rpc-address", "localhost", "RPC addr to connect to")                                                                // Init RPC addr flag
+	storageFlag              = flag.String("storage", "bolt", "store the dag using the given storage backend (bolt or memory; memory is discarded on exit)")    // Init storage flag
+	verifierThreadsFlag      = flag.Int("verifier-threads", runtime.NumCPU(), "verify at most the given number of transaction signatures at once")              // Init verifier threads flag
+	signerFlag               = flag.String("signer", "", "sign transactions with keys held by an external signer: a command to start, or unix:<socket path>")   // Init signer flag
 
 	logger = loggo.GetLogger("") // Get logger
 
@@ -69,8 +91,20 @@ func main() {
 		os.Exit(1) // Panic
 	}
 
-	defer dag.Close()     // Close dag
-	defer logFile.Close() // Close log file
+	defer func() { dag.Close() }() // Close dag (read when deferred calls run, as the dag is opened after main starts)
+	defer logFile.Close()          // Close log file
+
+	if flag.NArg() > 0 { // Check has command
+		err = runCommand(flag.Args()) // Run command
+
+		if err != nil { // Check for errors
+			logger.Criticalf("command %s failed: %s", flag.Arg(0), err.Error()) // Log pending panic
+
+			os.Exit(1) // Panic
+		}
+
+		return // Don't start node
+	}
 
 	if !checkNodeAlreadyUp() { // Check no node already up
 		defer cancelIntermittent() // Cancel
@@ -95,6 +129,8 @@ func setUserParams() error {
 
 	p2p.NodePort = *nodePortFlag // Set node port
 
+	types.DefaultSignatureVerifier = types.NewSignatureVerifier(*verifierThreadsFlag, types.DefaultSignatureCacheSize) // Set signature verifier
+
 	if !*disableColoredOutputFlag { // Check can log colored output
 		if !*disableLogFileFlag { // Check can have log files
 			err := common.CreateDirIfDoesNotExist(filepath.FromSlash(common.LogsDir)) // Create log dir
@@ -143,13 +179,13 @@ func startNode() error {
 		return err // Return found error
 	}
 
-	dag, err = types.NewDag(dagConfig) // Init dag
+	dag, err = newDag(dagConfig) // Init dag
 
 	if err != nil { // Check for errors
 		return err // Return found error
 	}
 
-	c := make(chan os.Signal) // Get control c
+	c := make(chan os.Signal, 1) // Get control c
 
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM) // Notify
 
@@ -213,6 +249,18 @@ func startNode() error {
 	return nil // No error occurred, return nil
 }
 
+// newDag initializes a dag with a given config, stored in the storage backend selected via the storage flag.
+func newDag(dagConfig *config.DagConfig) (*types.Dag, error) {
+	switch *storageFlag {
+	case "bolt":
+		return types.NewDag(dagConfig) // Init bolt dag
+	case "memory":
+		return types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Init ephemeral dag
+	default:
+		return &types.Dag{}, errUnknownStorage // Return error
+	}
+}
+
 // startInitialSync starts an initial sync with a given client.
 func startInitialSync(ctx context.Context, needsSync bool, client *p2p.Client) (bool, error) {
 	localBestTransaction, _ := (*client.Validator).GetWorkingDag().GetBestTransaction() // Get local best transaction
@@ -240,11 +288,28 @@ func startServingRPC(ctx context.Context, network string) error {
 		return err // Return found error
 	}
 
+	if strings.TrimSpace(*signerFlag) != "" { // Check has external signer
+		if rpcAPI.Signer, err = newExternalSigner(*signerFlag); err != nil { // Connect to external signer
+			return err // Return found error
+		}
+	}
+
 	go rpcAPI.StartServing(ctx) // Start serving
 
 	return nil // No error occurred, return nil
 }
 
+// newExternalSigner connects to the external signer listening on a given Unix socket (unix:<socket path>), or started by a given command.
+func newExternalSigner(signer string) (*accounts.ExternalSigner, error) {
+	if strings.HasPrefix(signer, "unix:") { // Check is socket
+		return accounts.DialExternalSigner(strings.TrimPrefix(signer, "unix:")) // Connect to signer
+	}
+
+	command := strings.Fields(signer) // Split command
+
+	return accounts.StartExternalSigner(command[0], command[1:]...) // Start signer
+}
+
 // getDagConfig attempts to read an existing dag config, or bootstrap one.
 func getDagConfig(ctx context.Context, host *routed.RoutedHost) (*config.DagConfig, bool, error) {
 	needsSync := false // Init buffer
@@ -268,6 +333,108 @@ func getDagConfig(ctx context.Context, host *routed.RoutedHost) (*config.DagConf
 	return dagConfig, needsSync, nil // Return found config
 }
 
+// runCommand runs a given offline maintenance command (and its arguments) against the dag db of the selected network.
+func runCommand(args []string) error {
+	defer func() {
+		dag.Close() // Close dag opened by command (if any)
+
+		dag = nil // Reset dag
+	}() // Close dag before returning, as failed commands exit without running main's deferred calls
+
+	switch args[0] {
+	case "rebuild-account-state":
+		return rebuildAccountState() // Rebuild account state
+	case "export", "import":
+		if len(args) < 2 { // Check no archive path
+			return errNoArchivePath // Return error
+		}
+
+		if args[0] == "export" { // Check is export
+			return exportDag(args[1]) // Export dag
+		}
+
+		return importDag(args[1]) // Import dag
+	default:
+		return errUnknownCommand // Return error
+	}
+}
+
+// rebuildAccountState rebuilds the account state of the selected network's dag db from scratch, reporting any address whose stored state was incorrect.
+func rebuildAccountState() error {
+	var err error // Init error buffer
+
+	dag, err = types.OpenDag(*networkFlag) // Open dag
+	if err != nil {                        // Check for errors
+		return err // Return found error
+	}
+
+	mismatched, err := dag.RebuildAccountState() // Rebuild account state
+	if err != nil {                              // Check for errors
+		return err // Return found error
+	}
+
+	for _, address := range mismatched { // Iterate through mismatched addresses
+		logger.Warningf("corrected account state of address %s", hex.EncodeToString(address.Bytes())) // Log mismatch
+	}
+
+	if len(mismatched) != 0 { // Check stored state was incorrect
+		return errAccountStateMismatch // Return error
+	}
+
+	logger.Infof("account state of network %s verified", *networkFlag) // Log verified
+
+	return nil // No error occurred, return nil
+}
+
+// exportDag writes the dag of the selected network to an archive at a given path.
+func exportDag(path string) error {
+	var err error // Init error buffer
+
+	dag, err = types.OpenDag(*networkFlag) // Open dag
+	if err != nil {                        // Check for errors
+		return err // Return found error
+	}
+
+	file, err := os.OpenFile(filepath.FromSlash(path), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644) // Create archive file
+	if err != nil {                                                                              // Check for errors
+		return err // Return found error
+	}
+
+	defer file.Close() // Close archive file
+
+	err = dag.Export(file) // Export dag
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	logger.Infof("exported dag of network %s to %s", *networkFlag, path) // Log exported
+
+	return file.Sync() // Sync archive file
+}
+
+// importDag reads a dag (and its config) from an archive at a given path, validating each of its transactions.
+func importDag(path string) error {
+	file, err := os.Open(filepath.FromSlash(path)) // Open archive file
+	if err != nil {                                // Check for errors
+		return err // Return found error
+	}
+
+	defer file.Close() // Close archive file
+
+	dag, err = types.ImportDag(file, common.DbDir, func(importedDag *types.Dag) types.TransactionValidator {
+		return validator.NewHistoricalBeaconDagValidator(importedDag.DagConfig, importedDag) // Validate via historical beacon dag validator
+	}) // Import dag
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	logger.Infof("imported dag of network %s from %s", dag.DagConfig.Identifier, path) // Log imported
+
+	return dag.DagConfig.WriteToMemory() // Write imported dag config to persistent memory
+}
+
 // checkNodeAlreadyUp checks if a node RPC API is already running.
 func checkNodeAlreadyUp() bool {
 	ln, err := net.Listen("tcp", ":"+strconv.Itoa(*apiPortFlag)) // Attempt to listen
@@ -0,0 +1,464 @@
+// Package mempool implements a memory pool of pending transactions, holding transactions that cannot yet be added to the
+// working dag (e.g. transactions with a future nonce, or whose parents have not yet arrived) until they can be.
+package mempool
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/types"
+	"github.com/polaris-project/go-polaris/validator"
+)
+
+const (
+	// DefaultMaxTransactions is the default maximum number of pending transactions held by a mempool.
+	DefaultMaxTransactions = 4096
+
+	// DefaultMaxTransactionsPerSender is the default maximum number of pending transactions held by a mempool for a single sender.
+	DefaultMaxTransactionsPerSender = 64
+
+	// DefaultMaxOrphans is the default maximum number of orphan transactions held by a mempool.
+	DefaultMaxOrphans = 256
+)
+
+var (
+	// ErrDuplicateTransaction is an error definition representing a transaction already pending (or orphaned) in the mempool, or already in the working dag.
+	ErrDuplicateTransaction = errors.New("transaction already exists in the mempool or working dag (duplicate)")
+
+	// ErrIncompleteTransaction is an error definition representing a transaction without a gas price or amount.
+	ErrIncompleteTransaction = errors.New("transaction has no gas price or amount")
+
+	// ErrNonceTooLow is an error definition representing a transaction whose nonce has already been used by its sender.
+	ErrNonceTooLow = errors.New("transaction nonce too low")
+
+	// ErrReplacementUnderpriced is an error definition representing a transaction replacing a pending transaction with the same
+	// sender and nonce without offering a higher gas price.
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+
+	// ErrSenderLimitReached is an error definition representing a sender with the maximum number of pending transactions.
+	ErrSenderLimitReached = errors.New("sender has too many pending transactions")
+
+	// ErrMempoolFull is an error definition representing a full mempool holding no transaction with a lower gas price than a new transaction.
+	ErrMempoolFull = errors.New("mempool is full")
+
+	// ErrTransactionNotFound is an error definition representing a transaction that is not pending in the mempool.
+	ErrTransactionNotFound = errors.New("transaction not found in mempool")
+)
+
+// logger is the mempool package logger.
+var logger = getLogger()
+
+// Mempool is a pool of pending transactions, keyed by sender and nonce.
+// Transactions are promoted into the working dag (highest gas price first) once their parents and all of their sender's preceding
+// nonces are in the dag. Transactions with a parent that is neither in the dag nor pending (orphans) are held in a separate,
+// bounded buffer until their parents arrive.
+type Mempool struct {
+	MaxTransactions int `json:"max_transactions"` // Maximum number of pending transactions
+
+	MaxTransactionsPerSender int `json:"max_transactions_per_sender"` // Maximum number of pending transactions per sender
+
+	MaxOrphans int `json:"max_orphans"` // Maximum number of orphan transactions
+
+	Validator *validator.Validator `json:"-"` // Validator (and working dag) transactions are validated against
+
+	transactions map[common.Hash]*types.Transaction // Pending transactions (tx hash => tx)
+
+	senders map[common.Address]map[uint64]*types.Transaction // Pending transactions by sender (sender => nonce => tx)
+
+	orphans map[common.Hash]*types.Transaction // Orphan transactions (tx hash => tx)
+
+	orphanQueue []common.Hash // Orphan transaction hashes, in order of arrival
+
+	lock sync.Mutex // Pending transactions lock
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMempool initializes a new, empty mempool validating transactions via a given validator.
+func NewMempool(validator *validator.Validator, maxTransactions int, maxTransactionsPerSender int, maxOrphans int) *Mempool {
+	return &Mempool{
+		MaxTransactions:          maxTransactions,                                        // Set max transactions
+		MaxTransactionsPerSender: maxTransactionsPerSender,                               // Set max transactions per sender
+		MaxOrphans:               maxOrphans,                                             // Set max orphans
+		Validator:                validator,                                              // Set validator
+		transactions:             make(map[common.Hash]*types.Transaction),               // Init transactions
+		senders:                  make(map[common.Address]map[uint64]*types.Transaction), // Init senders
+		orphans:                  make(map[common.Hash]*types.Transaction),               // Init orphans
+	} // Return initialized mempool
+}
+
+// AddTransaction adds a given transaction to the mempool, then promotes all executable pending transactions (including the given
+// transaction, if its parents and preceding nonces are already in the working dag) into the working dag.
+// Transactions that can never be valid (e.g. with an invalid signature, or a used nonce) are rejected.
+// If the mempool is full, the pending transaction with the lowest gas price is evicted to make room for the given transaction.
+// Orphan transactions are added to the orphan buffer (evicting the earliest received orphan if the buffer is full); their missing
+// parents can be found via MissingParents().
+func (mempool *Mempool) AddTransaction(transaction *types.Transaction) error {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	if err := mempool.checkTransaction(transaction); err != nil { // Check transaction
+		return err // Return found error
+	}
+
+	if mempool.isOrphan(transaction) { // Check missing parents
+		mempool.addOrphan(transaction) // Add orphan
+
+		return nil // No error occurred, return nil
+	}
+
+	if err := mempool.admitTransaction(transaction); err != nil { // Admit transaction
+		return err // Return found error
+	}
+
+	mempool.promoteTransactions() // Promote transactions
+
+	return nil // No error occurred, return nil
+}
+
+// Promote adds all executable pending transactions to the working dag (after adopting all orphans whose parents have arrived),
+// and drops expired or stale pending and orphan transactions.
+// Promote should be called whenever transactions are added to the working dag outside of the mempool (e.g. while syncing).
+// Returns the promoted transactions.
+func (mempool *Mempool) Promote() []*types.Transaction {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	return mempool.promoteTransactions() // Promote transactions
+}
+
+// GetTransaction gets the pending transaction with a given hash.
+func (mempool *Mempool) GetTransaction(hash common.Hash) (*types.Transaction, error) {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	transaction, ok := mempool.transactions[hash] // Get transaction
+
+	if !ok { // Check not pending
+		return &types.Transaction{}, ErrTransactionNotFound // Return error
+	}
+
+	return transaction, nil // Return transaction
+}
+
+// GetTransactionsBySender gets all pending transactions sent by a given address, in nonce order.
+func (mempool *Mempool) GetTransactionsBySender(sender *common.Address) []*types.Transaction {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	return mempool.senderTransactions(*sender) // Return sender transactions
+}
+
+// Pending gets all pending transactions, ordered by gas price (highest first), such that each sender's transactions remain in nonce order.
+func (mempool *Mempool) Pending() []*types.Transaction {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	var queues [][]*types.Transaction // Init sender queue buffer
+
+	for sender := range mempool.senders { // Iterate through senders
+		queues = append(queues, mempool.senderTransactions(sender)) // Append sender queue
+	}
+
+	return orderByGasPrice(queues) // Return ordered transactions
+}
+
+// Len gets the number of pending transactions.
+func (mempool *Mempool) Len() int {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	return len(mempool.transactions) // Return pending count
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// checkTransaction checks that a given transaction could become valid, and is neither pending, orphaned nor in the working dag.
+func (mempool *Mempool) checkTransaction(transaction *types.Transaction) error {
+	transactionValidator := *mempool.Validator // Get validator
+
+	switch {
+	case transaction.GasPrice == nil || transaction.Amount == nil: // Check no gas price or amount
+		return ErrIncompleteTransaction // Return error
+	case !transactionValidator.ValidateTransactionAmounts(transaction): // Check negative amounts
+		return validator.ErrInvalidTransactionAmount // Return error
+	case !transactionValidator.ValidateTransactionHash(transaction): // Check invalid hash
+		return validator.ErrInvalidTransactionHash // Return error
+	case !transactionValidator.ValidateTransactionNetwork(transaction): // Check invalid network
+		return validator.ErrInvalidTransactionNetwork // Return error
+	case !transactionValidator.ValidateTransactionExpiry(transaction): // Check expired
+		return validator.ErrTransactionExpired // Return error
+	case !transactionValidator.ValidateTransactionSignature(transaction): // Check invalid signature
+		return validator.ErrInvalidTransactionSignature // Return error
+	}
+
+	if _, ok := mempool.transactions[transaction.Hash]; ok { // Check already pending
+		return ErrDuplicateTransaction // Return error
+	}
+
+	if _, ok := mempool.orphans[transaction.Hash]; ok { // Check already orphaned
+		return ErrDuplicateTransaction // Return error
+	}
+
+	if _, err := transactionValidator.GetWorkingDag().GetTransactionByHash(transaction.Hash); err == nil { // Check already in dag
+		return ErrDuplicateTransaction // Return error
+	}
+
+	nextNonce, err := transactionValidator.GetWorkingDag().GetNextNonce(transaction.Sender) // Get sender's next nonce
+	if err != nil {                                                                         // Check for errors
+		return err // Return found error
+	}
+
+	if transaction.AccountNonce < nextNonce { // Check nonce already used
+		return ErrNonceTooLow // Return error
+	}
+
+	return nil // Transaction could become valid
+}
+
+// admitTransaction adds a given checked transaction to the pending transactions, replacing or evicting a pending transaction if necessary.
+func (mempool *Mempool) admitTransaction(transaction *types.Transaction) error {
+	if existing, ok := mempool.senders[*transaction.Sender][transaction.AccountNonce]; ok { // Check replacing pending transaction
+		if transaction.GasPrice.Cmp(existing.GasPrice) <= 0 { // Check not offering higher gas price
+			return ErrReplacementUnderpriced // Return error
+		}
+
+		mempool.removeTransaction(existing) // Remove replaced transaction
+	} else if len(mempool.senders[*transaction.Sender]) >= mempool.MaxTransactionsPerSender { // Check sender limit reached
+		return ErrSenderLimitReached // Return error
+	}
+
+	if len(mempool.transactions) >= mempool.MaxTransactions { // Check full
+		cheapest := mempool.cheapestTransaction() // Get eviction candidate
+
+		if cheapest == nil || cheapest.GasPrice.Cmp(transaction.GasPrice) >= 0 { // Check nothing cheaper to evict
+			return ErrMempoolFull // Return error
+		}
+
+		logger.Infof("evicting pending transaction %s", hex.EncodeToString(cheapest.Hash.Bytes())) // Log evict
+
+		mempool.removeTransaction(cheapest) // Evict transaction
+	}
+
+	if mempool.senders[*transaction.Sender] == nil { // Check no pending sender transactions
+		mempool.senders[*transaction.Sender] = make(map[uint64]*types.Transaction) // Init sender transactions
+	}
+
+	mempool.transactions[transaction.Hash] = transaction                         // Add transaction
+	mempool.senders[*transaction.Sender][transaction.AccountNonce] = transaction // Add transaction to sender queue
+
+	return nil // No error occurred, return nil
+}
+
+// promoteTransactions repeatedly adds the executable pending transaction with the highest gas price to the working dag,
+// until no pending transaction can be added. Pending transactions that can no longer become valid are dropped.
+func (mempool *Mempool) promoteTransactions() []*types.Transaction {
+	var promoted []*types.Transaction // Init promoted buffer
+
+	for {
+		mempool.dropStaleTransactions() // Drop expired and stale transactions
+
+		mempool.adoptOrphans() // Adopt orphans whose parents have arrived
+
+		added := false // Init added flag
+
+		for _, transaction := range mempool.executableTransactions() { // Iterate through executable transactions
+			err := (*mempool.Validator).ValidateTransaction(transaction) // Validate transaction
+
+			if err == nil { // Check valid
+				err = (*mempool.Validator).GetWorkingDag().AddTransaction(transaction) // Add transaction to dag
+			}
+
+			if err == validator.ErrInsufficientSenderBalance { // Check may become valid once sender has been sent funds
+				continue // Keep transaction
+			}
+
+			mempool.removeTransaction(transaction) // Remove transaction
+
+			if err != nil { // Check for errors
+				logger.Infof("dropping invalid pending transaction %s: %s", hex.EncodeToString(transaction.Hash.Bytes()), err.Error()) // Log drop
+
+				continue // Continue
+			}
+
+			promoted = append(promoted, transaction) // Append promoted
+
+			added = true // Set added
+
+			break // Recompute executable transactions against the updated dag
+		}
+
+		if !added { // Check nothing added
+			return promoted // Return promoted transactions
+		}
+	}
+}
+
+// executableTransactions gets all pending transactions whose nonce is their sender's next nonce, and whose parents are all in the
+// working dag, ordered by gas price (highest first).
+func (mempool *Mempool) executableTransactions() []*types.Transaction {
+	dag := (*mempool.Validator).GetWorkingDag() // Get working dag
+
+	var executable []*types.Transaction // Init executable buffer
+
+	for sender, transactions := range mempool.senders { // Iterate through senders
+		nextNonce, err := dag.GetNextNonce(&sender) // Get next nonce
+		if err != nil {                             // Check for errors
+			continue // Skip sender
+		}
+
+		transaction, ok := transactions[nextNonce] // Get next transaction
+
+		if ok && mempool.hasParents(transaction) { // Check executable
+			executable = append(executable, transaction) // Append transaction
+		}
+	}
+
+	sort.Slice(executable, func(i, j int) bool {
+		return higherGasPrice(executable[i], executable[j]) // Sort by gas price
+	}) // Sort executable transactions
+
+	return executable // Return executable transactions
+}
+
+// dropStaleTransactions removes all expired pending and orphan transactions, and all pending and orphan transactions whose nonce
+// has already been used.
+func (mempool *Mempool) dropStaleTransactions() {
+	dag := (*mempool.Validator).GetWorkingDag() // Get working dag
+
+	now := time.Now() // Get current time
+
+	for _, transaction := range mempool.orphans { // Iterate through orphans
+		if nextNonce, err := dag.GetNextNonce(transaction.Sender); err == nil && (transaction.AccountNonce < nextNonce || transaction.IsExpired(now)) { // Check stale
+			mempool.removeOrphan(transaction) // Remove orphan
+		}
+	}
+
+	for sender, transactions := range mempool.senders { // Iterate through senders
+		nextNonce, err := dag.GetNextNonce(&sender) // Get next nonce
+		if err != nil {                             // Check for errors
+			continue // Skip sender
+		}
+
+		for nonce, transaction := range transactions { // Iterate through sender transactions
+			if nonce < nextNonce || transaction.IsExpired(now) { // Check stale
+				mempool.removeTransaction(transaction) // Remove transaction
+			}
+		}
+	}
+}
+
+// hasParents checks whether all of a given transaction's parents are in the working dag.
+func (mempool *Mempool) hasParents(transaction *types.Transaction) bool {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if _, err := (*mempool.Validator).GetWorkingDag().GetTransactionByHash(parentHash); err != nil { // Check parent missing
+			return false // Missing parent
+		}
+	}
+
+	return true // All parents present
+}
+
+// cheapestTransaction gets the pending transaction ordered last by gas price, or nil if no transactions are pending.
+func (mempool *Mempool) cheapestTransaction() *types.Transaction {
+	var cheapest *types.Transaction // Init cheapest buffer
+
+	for _, transaction := range mempool.transactions { // Iterate through pending transactions
+		if cheapest == nil || higherGasPrice(cheapest, transaction) { // Check cheaper
+			cheapest = transaction // Set cheapest
+		}
+	}
+
+	return cheapest // Return cheapest transaction
+}
+
+// removeTransaction removes a given transaction from the pending transactions.
+func (mempool *Mempool) removeTransaction(transaction *types.Transaction) {
+	delete(mempool.transactions, transaction.Hash) // Remove transaction
+
+	if transactions, ok := mempool.senders[*transaction.Sender]; ok && transactions[transaction.AccountNonce] == transaction { // Check in sender queue
+		delete(transactions, transaction.AccountNonce) // Remove from sender queue
+
+		if len(transactions) == 0 { // Check no remaining sender transactions
+			delete(mempool.senders, *transaction.Sender) // Remove sender
+		}
+	}
+}
+
+// senderTransactions gets all pending transactions sent by a given address, in nonce order.
+func (mempool *Mempool) senderTransactions(sender common.Address) []*types.Transaction {
+	var transactions []*types.Transaction // Init transaction buffer
+
+	for _, transaction := range mempool.senders[sender] { // Iterate through sender transactions
+		transactions = append(transactions, transaction) // Append transaction
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].AccountNonce < transactions[j].AccountNonce // Sort by nonce
+	}) // Sort sender transactions
+
+	return transactions // Return sender transactions
+}
+
+// orderByGasPrice merges given nonce-ordered sender queues, repeatedly taking the queue head with the highest gas price.
+func orderByGasPrice(queues [][]*types.Transaction) []*types.Transaction {
+	var ordered []*types.Transaction // Init ordered buffer
+
+	for {
+		best := -1 // Init best queue index
+
+		for i, queue := range queues { // Iterate through queues
+			if len(queue) != 0 && (best == -1 || higherGasPrice(queue[0], queues[best][0])) { // Check better queue head
+				best = i // Set best
+			}
+		}
+
+		if best == -1 { // Check all queues empty
+			return ordered // Return ordered transactions
+		}
+
+		ordered = append(ordered, queues[best][0]) // Append queue head
+
+		queues[best] = queues[best][1:] // Pop queue head
+	}
+}
+
+// higherGasPrice checks whether a given transaction should be ordered before another: by gas price (highest first), then by
+// timestamp (earliest first), then by hash.
+func higherGasPrice(a *types.Transaction, b *types.Transaction) bool {
+	if comparison := a.GasPrice.Cmp(b.GasPrice); comparison != 0 { // Check different gas prices
+		return comparison > 0 // Higher gas price first
+	}
+
+	if !a.Timestamp.Equal(b.Timestamp) { // Check different timestamps
+		return a.Timestamp.Before(b.Timestamp) // Earlier first
+	}
+
+	return bytes.Compare(a.Hash.Bytes(), b.Hash.Bytes()) < 0 // Lower hash first
+}
+
+// getLogger gets the mempool package logger.
+func getLogger() loggo.Logger {
+	logger := loggo.GetLogger("mempool") // Get logger
+
+	loggo.ConfigureLoggers("mempool=INFO") // Configure loggers
+
+	return logger // Return logger
+}
+
+/* END INTERNAL METHODS */
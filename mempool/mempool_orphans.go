@@ -0,0 +1,150 @@
+// Package mempool implements a memory pool of pending transactions, holding transactions that cannot yet be added to the
+// working dag (e.g. transactions with a future nonce, or whose parents have not yet arrived) until they can be.
+package mempool
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/types"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// Orphans gets all orphan transactions, in order of arrival.
+func (mempool *Mempool) Orphans() []*types.Transaction {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	var orphans []*types.Transaction // Init orphan buffer
+
+	for _, hash := range mempool.orphanQueue { // Iterate through orphan hashes
+		orphans = append(orphans, mempool.orphans[hash]) // Append orphan
+	}
+
+	return orphans // Return orphans
+}
+
+// MissingParents gets the hashes of all parents of orphan transactions that are neither in the working dag nor in the mempool,
+// and should be requested from peers.
+func (mempool *Mempool) MissingParents() []common.Hash {
+	mempool.lock.Lock() // Lock
+
+	defer mempool.lock.Unlock() // Unlock
+
+	missing := make(map[common.Hash]bool) // Init missing parent set
+
+	for _, orphan := range mempool.orphans { // Iterate through orphans
+		for _, parentHash := range orphan.ParentTransactions { // Iterate through parents
+			if _, ok := mempool.orphans[parentHash]; !ok && !mempool.isKnown(parentHash) { // Check missing
+				missing[parentHash] = true // Add missing parent
+			}
+		}
+	}
+
+	var missingParents []common.Hash // Init missing parent buffer
+
+	for parentHash := range missing { // Iterate through missing parents
+		missingParents = append(missingParents, parentHash) // Append missing parent
+	}
+
+	sort.Slice(missingParents, func(i, j int) bool {
+		return bytes.Compare(missingParents[i].Bytes(), missingParents[j].Bytes()) < 0 // Sort by hash
+	}) // Sort missing parents
+
+	return missingParents // Return missing parents
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// isOrphan checks whether a given transaction has a parent that is neither in the working dag nor pending.
+func (mempool *Mempool) isOrphan(transaction *types.Transaction) bool {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if !mempool.isKnown(parentHash) { // Check missing
+			return true // Orphan
+		}
+	}
+
+	return false // Not an orphan
+}
+
+// isKnown checks whether the transaction with a given hash is pending or in the working dag.
+func (mempool *Mempool) isKnown(hash common.Hash) bool {
+	if _, ok := mempool.transactions[hash]; ok { // Check pending
+		return true // Known
+	}
+
+	_, err := (*mempool.Validator).GetWorkingDag().GetTransactionByHash(hash) // Get transaction from dag
+
+	return err == nil // Return in dag
+}
+
+// addOrphan adds a given transaction to the orphan buffer, evicting the earliest received orphans if the buffer is full.
+func (mempool *Mempool) addOrphan(transaction *types.Transaction) {
+	for len(mempool.orphans) >= mempool.MaxOrphans && len(mempool.orphanQueue) != 0 { // Evict until room for orphan
+		evicted := mempool.orphans[mempool.orphanQueue[0]] // Get earliest orphan
+
+		logger.Infof("evicting orphan transaction %s", hex.EncodeToString(evicted.Hash.Bytes())) // Log evict
+
+		mempool.removeOrphan(evicted) // Evict orphan
+	}
+
+	if mempool.MaxOrphans <= 0 { // Check orphans disabled
+		return // Drop orphan
+	}
+
+	mempool.orphans[transaction.Hash] = transaction                     // Add orphan
+	mempool.orphanQueue = append(mempool.orphanQueue, transaction.Hash) // Add orphan to queue
+}
+
+// removeOrphan removes a given transaction from the orphan buffer.
+func (mempool *Mempool) removeOrphan(transaction *types.Transaction) {
+	delete(mempool.orphans, transaction.Hash) // Remove orphan
+
+	for i, hash := range mempool.orphanQueue { // Iterate through orphan queue
+		if hash == transaction.Hash { // Check is orphan
+			mempool.orphanQueue = append(mempool.orphanQueue[:i], mempool.orphanQueue[i+1:]...) // Remove from queue
+
+			return // Done
+		}
+	}
+}
+
+// adoptOrphans moves every orphan whose parents are now pending or in the working dag into the pending transactions (re-checking
+// each adopted orphan), until no more orphans can be adopted.
+func (mempool *Mempool) adoptOrphans() {
+	for adopted := true; adopted; { // Adopt until no orphans adopted
+		adopted = false // Reset adopted flag
+
+		for _, hash := range append([]common.Hash{}, mempool.orphanQueue...) { // Iterate through orphans, earliest first
+			orphan, ok := mempool.orphans[hash] // Get orphan
+
+			if !ok || mempool.isOrphan(orphan) { // Check removed or still missing parents
+				continue // Continue
+			}
+
+			mempool.removeOrphan(orphan) // Remove from orphan buffer
+
+			err := mempool.checkTransaction(orphan) // Re-check orphan
+
+			if err == nil { // Check valid
+				err = mempool.admitTransaction(orphan) // Admit orphan
+			}
+
+			if err != nil { // Check for errors
+				logger.Infof("dropping orphan transaction %s: %s", hex.EncodeToString(orphan.Hash.Bytes()), err.Error()) // Log drop
+
+				continue // Continue
+			}
+
+			adopted = true // Set adopted
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
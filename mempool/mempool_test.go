@@ -0,0 +1,251 @@
+// Package mempool implements a memory pool of pending transactions, holding transactions that cannot yet be added to the
+// working dag (e.g. transactions with a future nonce, or whose parents have not yet arrived) until they can be.
+package mempool
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+	"github.com/polaris-project/go-polaris/types"
+	"github.com/polaris-project/go-polaris/validator"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestAddTransaction tests the functionality of the AddTransaction() mempool method.
+func TestAddTransaction(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey := newTestPrivateKey(t) // Generate private key
+
+	mempool, dag, parent := newTestMempool(t, 16, 2, 16, privateKey) // Initialize mempool
+
+	defer dag.Close() // Close dag
+
+	transaction := newTestTransaction(t, privateKey, 0, parent, 1)     // Initialize transaction
+	child := newTestTransaction(t, privateKey, 1, transaction.Hash, 1) // Initialize child of transaction
+
+	if err := mempool.AddTransaction(child); err != nil { // Add child before its parent
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := dag.GetTransactionByHash(child.Hash); err == nil || len(mempool.Orphans()) != 1 { // Check child not orphaned
+		t.Fatal("transaction with a future nonce and missing parent should be orphaned") // Panic
+	}
+
+	if err := mempool.AddTransaction(transaction); err != nil { // Add parent
+		t.Fatal(err) // Panic
+	}
+
+	for _, added := range []*types.Transaction{transaction, child} { // Iterate through added transactions
+		if _, err := dag.GetTransactionByHash(added.Hash); err != nil { // Check not promoted
+			t.Fatalf("transaction %s should have been promoted into the dag", hex.EncodeToString(added.Hash.Bytes())) // Panic
+		}
+	}
+
+	if mempool.Len() != 0 || len(mempool.Orphans()) != 0 { // Check transactions still pending
+		t.Fatalf("promoted transactions should no longer be pending; found %d pending", mempool.Len()) // Panic
+	}
+
+	if err := mempool.AddTransaction(transaction); err != ErrDuplicateTransaction { // Add duplicate
+		t.Fatalf("should have returned ErrDuplicateTransaction; got %v", err) // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, privateKey, 1, transaction.Hash, 2)); err != ErrNonceTooLow { // Add used nonce
+		t.Fatalf("should have returned ErrNonceTooLow; got %v", err) // Panic
+	}
+
+	pending := newTestTransaction(t, privateKey, 3, child.Hash, 2) // Initialize transaction with future nonce
+
+	if err := mempool.AddTransaction(pending); err != nil { // Add pending transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, privateKey, 3, child.Hash, 2)); err != ErrReplacementUnderpriced { // Replace without higher gas price
+		t.Fatalf("should have returned ErrReplacementUnderpriced; got %v", err) // Panic
+	}
+
+	replacement := newTestTransaction(t, privateKey, 3, child.Hash, 3) // Initialize replacement transaction
+
+	if err := mempool.AddTransaction(replacement); err != nil { // Replace pending transaction
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := mempool.GetTransaction(pending.Hash); err != ErrTransactionNotFound { // Check replaced transaction still pending
+		t.Fatal("replaced transaction should no longer be pending") // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, privateKey, 4, child.Hash, 1)); err != nil { // Add second pending transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, privateKey, 5, child.Hash, 1)); err != ErrSenderLimitReached { // Exceed sender limit
+		t.Fatalf("should have returned ErrSenderLimitReached; got %v", err) // Panic
+	}
+
+	if err := mempool.AddTransaction(&types.Transaction{Amount: big.NewInt(0), GasPrice: big.NewInt(0), Hash: common.NewHash([]byte("invalid"))}); err != validator.ErrInvalidTransactionHash { // Add invalid transaction
+		t.Fatalf("should have returned ErrInvalidTransactionHash; got %v", err) // Panic
+	}
+
+	expired := newTestTransaction(t, privateKey, 2, child.Hash, 1) // Initialize transaction
+
+	expired.ValidUntil = expired.Timestamp.Add(time.Millisecond) // Expire transaction shortly after creation
+	expired.Signature = nil                                      // Remove signature
+
+	if err := types.SignTransaction(expired, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	time.Sleep(2 * time.Millisecond) // Wait for transaction to expire
+
+	if err := mempool.AddTransaction(expired); err != validator.ErrTransactionExpired { // Add expired transaction
+		t.Fatalf("should have returned ErrTransactionExpired; got %v", err) // Panic
+	}
+}
+
+// TestPending tests the functionality of the Pending() mempool method, as well as mempool eviction.
+func TestPending(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey := newTestPrivateKey(t)      // Generate private key
+	otherPrivateKey := newTestPrivateKey(t) // Generate other private key
+
+	mempool, dag, parent := newTestMempool(t, 3, 4, 16, privateKey) // Initialize mempool
+
+	defer dag.Close() // Close dag
+
+	first := newTestTransaction(t, privateKey, 1, parent, 1)      // Initialize transaction with future nonce and low gas price
+	second := newTestTransaction(t, privateKey, 2, parent, 4)     // Initialize transaction with future nonce and high gas price
+	other := newTestTransaction(t, otherPrivateKey, 1, parent, 2) // Initialize other sender transaction with future nonce
+
+	for _, transaction := range []*types.Transaction{second, first, other} { // Iterate through transactions
+		if err := mempool.AddTransaction(transaction); err != nil { // Add transaction
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if pending := mempool.Pending(); len(pending) != 3 || pending[0] != other || pending[1] != first || pending[2] != second { // Check invalid ordering
+		t.Fatal("pending transactions should be ordered by gas price, keeping each sender's transactions in nonce order") // Panic
+	}
+
+	if bySender := mempool.GetTransactionsBySender(crypto.AddressFromPrivateKey(privateKey)); len(bySender) != 2 || bySender[0] != first { // Check invalid sender transactions
+		t.Fatal("sender transactions should be ordered by nonce") // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, otherPrivateKey, 2, parent, 1)); err != ErrMempoolFull { // Add cheapest transaction to full mempool
+		t.Fatalf("should have returned ErrMempoolFull; got %v", err) // Panic
+	}
+
+	if err := mempool.AddTransaction(newTestTransaction(t, otherPrivateKey, 2, parent, 3)); err != nil { // Add transaction to full mempool
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := mempool.GetTransaction(first.Hash); err != ErrTransactionNotFound || mempool.Len() != 3 { // Check cheapest not evicted
+		t.Fatal("cheapest pending transaction should have been evicted") // Panic
+	}
+}
+
+// TestMissingParents tests the functionality of the MissingParents() mempool method, as well as orphan eviction and adoption.
+func TestMissingParents(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey := newTestPrivateKey(t) // Generate private key
+
+	mempool, dag, parent := newTestMempool(t, 16, 16, 2, privateKey) // Initialize mempool
+
+	defer dag.Close() // Close dag
+
+	transaction := newTestTransaction(t, privateKey, 0, parent, 1)                       // Initialize transaction
+	child := newTestTransaction(t, privateKey, 1, transaction.Hash, 1)                   // Initialize child of transaction
+	grandchild := newTestTransaction(t, privateKey, 2, child.Hash, 1)                    // Initialize grandchild of transaction
+	unrelated := newTestTransaction(t, privateKey, 3, crypto.Sha3([]byte("unknown")), 1) // Initialize transaction with unknown parent
+
+	for _, orphan := range []*types.Transaction{unrelated, grandchild, child} { // Iterate through orphans
+		if err := mempool.AddTransaction(orphan); err != nil { // Add orphan
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if orphans := mempool.Orphans(); len(orphans) != 2 || orphans[0] != grandchild || orphans[1] != child { // Check earliest orphan not evicted
+		t.Fatal("earliest orphan should have been evicted from the full orphan buffer") // Panic
+	}
+
+	if missing := mempool.MissingParents(); len(missing) != 1 || missing[0] != transaction.Hash { // Check invalid missing parents
+		t.Fatal("only the parent of the earliest orphan should be missing") // Panic
+	}
+
+	if err := mempool.AddTransaction(transaction); err != nil { // Add missing parent
+		t.Fatal(err) // Panic
+	}
+
+	if _, err := dag.GetTransactionByHash(grandchild.Hash); err != nil || len(mempool.Orphans()) != 0 || len(mempool.MissingParents()) != 0 { // Check orphans not adopted
+		t.Fatal("orphans should have been adopted and promoted once their parents arrived") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// newTestMempool initializes a mempool with given limits over an in-memory dag allocating funds to the given private key's address.
+// Returns the mempool, the dag and the hash of the last genesis transaction.
+func newTestMempool(t *testing.T, maxTransactions int, maxTransactionsPerSender int, maxOrphans int, privateKey *ecdsa.PrivateKey) (*Mempool, *types.Dag, common.Hash) {
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(crypto.AddressFromPrivateKey(privateKey).Bytes()): big.NewInt(1000)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	dagValidator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
+
+	return NewMempool(&dagValidator, maxTransactions, maxTransactionsPerSender, maxOrphans), dag, genesisTransactions[len(genesisTransactions)-1].Hash // Return mempool
+}
+
+// newTestPrivateKey generates a new private key.
+func newTestPrivateKey(t *testing.T) *ecdsa.PrivateKey {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return privateKey // Return private key
+}
+
+// newTestTransaction initializes a new transaction with a given nonce, parent and gas price, signed by a given private key.
+func newTestTransaction(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, parent common.Hash, gasPrice int64) *types.Transaction {
+	transaction := types.NewTransaction(
+		nonce,                                    // Nonce
+		big.NewInt(1),                            // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		nil,                                      // Recipient
+		[]common.Hash{parent},                    // Parents
+		1,                                        // Gas limit
+		big.NewInt(gasPrice),                     // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Initialize transaction
+
+	if err := types.SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	return transaction // Return signed transaction
+}
+
+/* END INTERNAL METHODS TESTS */
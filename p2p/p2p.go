@@ -3,7 +3,6 @@ package p2p
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
@@ -13,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	mathRand "math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -32,6 +32,7 @@ import (
 	multiaddr "github.com/multiformats/go-multiaddr"
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
+	p2pProto "github.com/polaris-project/go-polaris/internal/proto/p2p"
 )
 
 // Stream header protocol definitions
@@ -74,6 +75,13 @@ var (
 	// NodePort is the current node port
 	NodePort = 3030
 
+	// ProtocolVersions represents the versions of the stream header protocols spoken by the current node, newest first.
+	// Streams are opened with the newest version spoken by both peers (see GetStreamHeaderProtocolIDs).
+	ProtocolVersions = []string{"1.0.0"}
+
+	// StreamTimeout is the maximum duration of a request-response exchange over a stream.
+	StreamTimeout = 30 * time.Second
+
 	// ErrTimedOut is an error definition representing a timeout.
 	ErrTimedOut = errors.New("timed out")
 )
@@ -81,6 +89,13 @@ var (
 // StreamHeaderProtocol represents the stream protocol type enum.
 type StreamHeaderProtocol int
 
+// PeerResponse represents the payload of a response received from a peer.
+type PeerResponse struct {
+	Peer peer.ID // Responding peer
+
+	Payload []byte // Response payload
+}
+
 /* BEGIN EXPORTED METHODS */
 
 // NewHost initializes a new libp2p host with the given context.
@@ -199,23 +214,34 @@ func BootstrapConfig(ctx context.Context, host *routed.RoutedHost, bootstrapAddr
 
 	readCtx, cancel := context.WithCancel(ctx) // Get context
 
-	stream, err := (*host).NewStream(readCtx, peerID, protocol.ID(GetStreamHeaderProtocolPath(network, RequestConfig))) // Initialize new stream
-	if err != nil {                                                                                                     // Check for errors
+	stream, err := (*host).NewStream(readCtx, peerID, GetStreamHeaderProtocolIDs(GetStreamHeaderProtocolPath(network, RequestConfig))...) // Initialize new stream
+	if err != nil {                                                                                                                       // Check for errors
+		cancel() // Cancel
+
+		return &config.DagConfig{}, err // Return found error
+	}
+
+	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer from stream
+
+	if err = writeProto(readWriter, RequestMessage, &p2pProto.ConfigRequest{}); err == nil { // Write request
+		err = readWriter.Flush() // Flush
+	}
+
+	if err != nil { // Check for errors
 		cancel() // Cancel
 
 		return &config.DagConfig{}, err // Return found error
 	}
 
-	reader := bufio.NewReader(stream) // Initialize reader from stream
+	response := &p2pProto.ConfigResponse{} // Init response buffer
 
-	dagConfigBytes, err := readAsync(reader) // Read async
-	if err != nil {                          // Check for errors
+	if err = readProto(readWriter.Reader, ConfigMessage, response); err != nil { // Read config
 		cancel() // Cancel
 
 		return &config.DagConfig{}, err // Return found error
 	}
 
-	deserializedConfig := config.DagConfigFromBytes(dagConfigBytes) // Deserialize
+	deserializedConfig := config.DagConfigFromBytes(response.Config) // Deserialize
 
 	if deserializedConfig == nil { // Check nil
 		cancel() // Cancel
@@ -262,72 +288,105 @@ func BootstrapDht(ctx context.Context, host host.Host) (*dht.IpfsDHT, error) {
 	return dht, nil // No error occurred, return nil
 }
 
-// BroadcastDht attempts to send a given message to all nodes in a dht at a given endpoint.
-func BroadcastDht(ctx context.Context, host *routed.RoutedHost, message []byte, streamProtocol, dagIdentifier string) error {
+// GossipDht attempts to send a given message to a random sampling set of at most fanout peers at a given endpoint, skipping banned
+// peers and a given set of excluded peers (e.g. the peer the message was received from).
+// Peers that don't accept the message within StreamTimeout are penalized.
+func GossipDht(ctx context.Context, host *routed.RoutedHost, scores *PeerScores, message *Message, streamProtocol string, fanout int, excluded ...peer.ID) error {
 	peers := host.Peerstore().Peers() // Get peers
 
-	for _, peer := range peers { // Iterate through peers
-		if peer == (*host).ID() { // Check not same node
+	sent := 0 // Init sent counter
+
+	for _, i := range mathRand.New(mathRand.NewSource(time.Now().UnixNano())).Perm(len(peers)) { // Iterate through peers in random order
+		if sent >= fanout { // Check has sent to enough peers
+			break // Break
+		}
+
+		if peers[i] == (*host).ID() || containsPeer(excluded, peers[i]) || (scores != nil && scores.IsBanned(peers[i])) { // Check not same node, not excluded, and not banned
 			continue // Continue
 		}
 
-		stream, err := (*host).NewStream(ctx, peer, protocol.ID(streamProtocol)) // Connect
-		if err != nil {                                                          // Check for errors
+		stream, err := (*host).NewStream(ctx, peers[i], GetStreamHeaderProtocolIDs(streamProtocol)...) // Connect
+		if err != nil {                                                                                // Check for errors
 			continue // Continue
 		}
 
+		stream.SetWriteDeadline(time.Now().Add(StreamTimeout)) // Set write deadline
+
 		writer := bufio.NewWriter(stream) // Initialize writer
 
-		_, err = writer.Write(append(message, '\f')) // Write message
+		if err = WriteMessage(writer, message); err == nil { // Write message
+			err = writer.Flush() // Flush
+		}
+
+		stream.Close() // Close stream
 
 		if err != nil { // Check for errors
+			if timeoutErr, ok := err.(interface{ Timeout() bool }); ok && timeoutErr.Timeout() && scores != nil { // Check timed out
+				scores.Penalize(peers[i], TimeoutPenalty) // Penalize peer
+			}
+
 			continue // Continue
 		}
 
-		writer.Flush() // Flush
+		sent++ // Increment sent
 	}
 
 	return nil // No error occurred, return nil
 }
 
-// BroadcastDhtResult send a given message to all nodes in a dht, and returns the result from each node.
-func BroadcastDhtResult(ctx context.Context, host *routed.RoutedHost, message []byte, streamProtocol, dagIdentifier string, nPeers int) ([][]byte, error) {
+// BroadcastDhtResult send a given message to a sampling set of at most nPeers nodes in a dht (skipping banned peers), and returns
+// the payload of the response (of a given message type) from each node.
+// Peers that fail to respond within StreamTimeout, or that respond with a malformed message, are penalized.
+func BroadcastDhtResult(ctx context.Context, host *routed.RoutedHost, scores *PeerScores, message *Message, responseType MessageType, streamProtocol, dagIdentifier string, nPeers int) ([]*PeerResponse, error) {
 	peers := host.Peerstore().Peers() // Get peers
 
-	results := [][]byte{} // Init results buffer
+	results := []*PeerResponse{} // Init results buffer
+
+	requested := 0 // Init requested counter
 
-	for x, peer := range peers { // Iterate through peers
-		if x >= nPeers { // Check has sent to enough peers
+	for _, peerID := range peers { // Iterate through peers
+		if requested >= nPeers { // Check has sent to enough peers
 			break // Break
 		}
 
-		if peer == (*host).ID() { // Check not same node
+		if peerID == (*host).ID() || (scores != nil && scores.IsBanned(peerID)) { // Check not same node, and not banned
 			continue // Continue
 		}
 
-		stream, err := (*host).NewStream(ctx, peer, protocol.ID(streamProtocol)) // Connect
-		if err != nil {                                                          // Check for errors
+		stream, err := (*host).NewStream(ctx, peerID, GetStreamHeaderProtocolIDs(streamProtocol)...) // Connect
+		if err != nil {                                                                              // Check for errors
 			continue // Continue
 		}
 
+		requested++ // Increment requested
+
+		stream.SetDeadline(time.Now().Add(StreamTimeout)) // Set deadline
+
 		readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Initialize reader/writer
 
-		_, err = readWriter.Write(append(message, byte('\f'))) // Write message
+		if err = WriteMessage(readWriter, message); err == nil { // Write message
+			err = readWriter.Flush() // Flush
+		}
 
 		if err != nil { // Check for errors
+			stream.Close() // Close stream
+
 			continue // Continue
 		}
 
-		readWriter.Flush() // Flush
+		responseBytes, err := readPayload(readWriter.Reader, responseType) // Read response
+
+		stream.Close() // Close stream
+
+		if err != nil { // Check for errors
+			if scores != nil { // Check scoring peers
+				scores.penalizeReadError(peerID, err) // Penalize peer
+			}
 
-		responseBytes, err := readAsync(readWriter.Reader) // Read async
-		if err != nil {                                    // Check for errors
 			continue // Continue
 		}
 
-		results = append(results, responseBytes) // Append response
-
-		readWriter.Flush() // Flush
+		results = append(results, &PeerResponse{Peer: peerID, Payload: responseBytes}) // Append response
 	}
 
 	return results, nil // No error occurred, return response
@@ -338,20 +397,30 @@ func GetStreamHeaderProtocolPath(network string, streamProtocol StreamHeaderProt
 	return fmt.Sprintf("/%s/%s", network, StreamHeaderProtocolNames[streamProtocol]) // Return URI
 }
 
+// GetStreamHeaderProtocolIDs gets the libp2p protocol IDs of each supported version of a given stream header protocol path, newest first.
+func GetStreamHeaderProtocolIDs(streamHeaderProtocolPath string) []protocol.ID {
+	protocolIDs := []protocol.ID{} // Init buffer
+
+	for _, version := range ProtocolVersions { // Iterate through versions
+		protocolIDs = append(protocolIDs, protocol.ID(fmt.Sprintf("%s/%s", streamHeaderProtocolPath, version))) // Append protocol ID
+	}
+
+	return protocolIDs // Return protocol IDs
+}
+
 /* END EXPORTED METHODS */
 
 /* BEGIN INTERNAL METHODS */
 
-// readAsync asynchronously reads from a given reader.
-func readAsync(reader *bufio.Reader) ([]byte, error) {
-	readBytes, err := reader.ReadBytes('\f') // Read bytes up to and including delimiter
-	if err != nil {                          // Check for errors
-		return nil, err // Return found error
+// containsPeer checks whether a given set of peers contains a given peer.
+func containsPeer(peers []peer.ID, target peer.ID) bool {
+	for _, peerID := range peers { // Iterate through peers
+		if peerID == target { // Check is target
+			return true // Found
+		}
 	}
 
-	readBytes = bytes.Trim(readBytes, "\f") // Trim delimiter
-
-	return readBytes, nil // Return read bytes
+	return false // Not found
 }
 
 /* END INTERNAL METHODS */
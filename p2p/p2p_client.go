@@ -5,13 +5,17 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"sync/atomic"
 	"time"
 
-	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/proto"
 	"github.com/juju/loggo"
+	peer "github.com/libp2p/go-libp2p-peer"
 
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/crypto"
+	p2pProto "github.com/polaris-project/go-polaris/internal/proto/p2p"
+	"github.com/polaris-project/go-polaris/mempool"
 
 	"github.com/polaris-project/go-polaris/types"
 	"github.com/polaris-project/go-polaris/validator"
@@ -31,6 +35,10 @@ var (
 	ErrNoAvailablePeers = errors.New("no available peers")
 )
 
+// maxParentRequestRounds is the maximum number of rounds of requests made by RequestMissingParents (i.e. the maximum depth of
+// missing ancestors fetched for a single orphan transaction).
+const maxParentRequestRounds = 16
+
 // logger is the p2p package logger.
 var logger = getLogger()
 
@@ -39,15 +47,39 @@ type Client struct {
 	Network string `json:"network"` // Active network
 
 	Validator *validator.Validator // Validator
+
+	Mempool *mempool.Mempool // Pending transactions
+
+	GossipFanout int `json:"gossip_fanout"` // Maximum number of peers each transaction is published or relayed to
+
+	GossipTTL uint32 `json:"gossip_ttl"` // Number of hops published transactions are relayed
+
+	PeerScores *PeerScores `json:"-"` // Peer scores and bans
+
+	seen *seenCache // Recently seen published transaction hashes
+
+	requestingParents int32 // Whether or not missing parents are currently being requested (1 if requesting)
 }
 
 /* BEGIN EXPORTED METHODS */
 
-// NewClient initializes a new client
+// NewClient initializes a new client, with the peer bans persisted to the p2p data dir (if any).
 func NewClient(network string, validator *validator.Validator) *Client {
+	peerScores, err := ReadPeerScoresFromMemory() // Read peer bans
+	if err != nil {                               // Check for errors
+		logger.Errorf("failed to read persisted peer bans: %s", err.Error()) // Log found error
+
+		peerScores = NewPeerScores() // Initialize peer scores
+	}
+
 	return &Client{
-		Network:   network,   // Set network
-		Validator: validator, // Set validator
+		Network:      network,                                                                                                                           // Set network
+		Validator:    validator,                                                                                                                         // Set validator
+		Mempool:      mempool.NewMempool(validator, mempool.DefaultMaxTransactions, mempool.DefaultMaxTransactionsPerSender, mempool.DefaultMaxOrphans), // Set mempool
+		GossipFanout: DefaultGossipFanout,                                                                                                               // Set gossip fanout
+		GossipTTL:    DefaultGossipTTL,                                                                                                                  // Set gossip TTL
+		PeerScores:   peerScores,                                                                                                                        // Set peer scores
+		seen:         newSeenCache(DefaultSeenCacheSize, DefaultSeenCacheExpiry),                                                                        // Init seen cache
 	}
 }
 
@@ -69,6 +101,20 @@ func (client *Client) SyncDag(ctx context.Context) error {
 		return ErrNoWorkingHost // Return found error
 	}
 
+	if (*client.Validator).GetWorkingDag().Genesis.IsNil() { // Check no genesis
+		logger.Infof("couldn't find a valid genesis transaction; syncing") // Log sync genesis
+	}
+
+	genCtx, cancel := context.WithCancel(ctx) // Initialize context
+
+	err := client.SyncGenesis(genCtx) // Sync genesis (or ban peers on another dag, if the genesis is already known)
+
+	cancel() // Cancel
+
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
 	bestLastTransactionHash, err := client.RequestBestTransactionHash(ctx, 64) // Request best tx hash
 	if err != nil {                                                            // Check for errors
 		return err // Return found error
@@ -81,24 +127,6 @@ func (client *Client) SyncDag(ctx context.Context) error {
 
 	logger.Infof("dag sync: determined must sync up to transaction with hash %s", hex.EncodeToString(remoteBestTransaction.Hash.Bytes())) // Log must sync up to
 
-	if (*client.Validator).GetWorkingDag().Genesis.IsNil() { // Check no genesis
-		logger.Infof("couldn't find a valid genesis transaction; syncing") // Log sync genesis
-
-		genCtx, cancel := context.WithCancel(ctx) // Initialize context
-
-		err = client.SyncGenesis(genCtx) // Sync genesis
-
-		if err != nil { // Check for errors
-			cancel()
-
-			return err // Return found error
-		}
-
-		logger.Infof("finished syncing gensis") // Log finish syncing genesis
-
-		cancel() // Cancel
-	}
-
 	logger.Infof("syncing best transaction") // Log sync best transaction
 
 	err = client.SyncBestTransaction(ctx, remoteBestTransaction.Hash) // Sync up to best remote tx
@@ -107,6 +135,14 @@ func (client *Client) SyncDag(ctx context.Context) error {
 		return err // Return found error
 	}
 
+	if promoted := client.Mempool.Promote(); len(promoted) != 0 { // Promote pending transactions now executable
+		logger.Infof("promoted %d pending transactions", len(promoted)) // Log promoted
+	}
+
+	if err = client.RequestMissingParents(ctx); err != nil { // Request parents of orphaned transactions
+		return err // Return found error
+	}
+
 	logger.Infof("sync finished successfully!") // Log finished
 
 	return nil // No error occurred, return nil
@@ -141,6 +177,8 @@ func (client *Client) SyncBestTransaction(ctx context.Context, remoteBestTransac
 
 		cancel() // Cancel
 
+		var fetched []*types.Transaction // Init fetched children buffer
+
 		for _, childHash := range childHashes { // Iterate through child hashes
 			logger.Infof("requesting child %s from network peers", hex.EncodeToString(childHash.Bytes())) // Log child
 
@@ -155,17 +193,15 @@ func (client *Client) SyncBestTransaction(ctx context.Context, remoteBestTransac
 
 			cancel() // Cancel
 
-			if err := (*client.Validator).ValidateTransaction(destinationTransaction); err == nil { // Check valid transaction
-				logger.Infof("adding child: %s", hex.EncodeToString(destinationTransaction.Hash.Bytes())) // Log add children
+			fetched = append(fetched, destinationTransaction) // Append child
+		}
 
-				err = (*client.Validator).GetWorkingDag().AddTransaction(destinationTransaction) // Add transaction to local dag
+		types.DefaultSignatureVerifier.VerifyTransactions(fetched) // Verify child signatures in parallel before validating each child
 
-				if err != nil { // Check for errors
-					return err // Return found error
-				}
-			} else { // Check for errors
-				logger.Errorf("validation error while adding tx %s: %s", hex.EncodeToString(childHash.Bytes()), err.Error()) // Log found error
-			}
+		logger.Infof("adding %d children", len(fetched)) // Log add children
+
+		if err = client.addSyncedTransactions(fetched); err != nil { // Add children to local dag in a single write
+			return err // Return found error
 		}
 
 		localBestTransaction, _ = (*client.Validator).GetWorkingDag().GetBestTransaction() // Get local best transaction
@@ -174,7 +210,9 @@ func (client *Client) SyncBestTransaction(ctx context.Context, remoteBestTransac
 	return nil // No error occurred, return nil
 }
 
-// SyncGenesis syncs the local genesis transaction set for the working dag.
+// SyncGenesis syncs the local genesis transaction set for the working dag, voting on the genesis hash reported by each peer.
+// If the working dag already has a genesis transaction, it is kept, and no transaction is synced. Either way, peers that report
+// another genesis hash are banned (regardless of their score), whilst peers reporting the working genesis hash are rewarded.
 func (client *Client) SyncGenesis(ctx context.Context) error {
 	if WorkingHost == nil { // Check no host
 		return ErrNoWorkingHost // Return found error
@@ -184,8 +222,15 @@ func (client *Client) SyncGenesis(ctx context.Context) error {
 
 	logger.Infof("requesting genesis transaction hash") // Log request genesis
 
-	genesisHashes, err := BroadcastDhtResult(getGenHashCtx, WorkingHost, types.GenesisHashRequest, GetStreamHeaderProtocolPath(client.Network, RequestGenesisHash), client.Network, 128) // Get genesis transaction hashes
-	if err != nil {                                                                                                                                                                      // Check for errors
+	request, err := newProtoMessage(RequestMessage, &p2pProto.GenesisHashRequest{}) // Initialize request
+	if err != nil {                                                                 // Check for errors
+		cancel() // Cancel
+
+		return err // Return found error
+	}
+
+	responses, err := BroadcastDhtResult(getGenHashCtx, WorkingHost, client.PeerScores, request, HashMessage, GetStreamHeaderProtocolPath(client.Network, RequestGenesisHash), client.Network, 128) // Get genesis transaction hashes
+	if err != nil {                                                                                                                                                                                 // Check for errors
 		cancel() // Cancel
 
 		return err // Return found error
@@ -193,30 +238,46 @@ func (client *Client) SyncGenesis(ctx context.Context) error {
 
 	cancel() // Cancel
 
-	occurrences := make(map[common.Hash]int64) // Occurrences of each transaction hash
+	votes := make(map[peer.ID]common.Hash) // Init genesis hash votes
 
-	bestGenesisHash := genesisHashes[0] // Init best genesis hash buffer
+	for _, response := range responses { // Iterate through responses
+		genesisHashResponse := &p2pProto.GenesisHashResponse{} // Init response buffer
 
-	for _, genesisHash := range genesisHashes { // Iterate through genesis hashes
-		if bytes.Equal(genesisHash, make([]byte, len(genesisHash))) { // Check is nil
-			continue // Continue
+		if client.decodeResponse(response, genesisHashResponse) && client.checkHashLength(response, genesisHashResponse.Hash) { // Check valid response
+			votes[response.Peer] = common.NewHash(genesisHashResponse.Hash) // Set vote
 		}
+	}
+
+	bestGenesisHash, ok := client.mostVotedHash(votes) // Get best genesis hash
 
-		occurrences[common.NewHash(genesisHash)]++ // Increment occurrences of genesis hash
+	if localGenesisHash := (*client.Validator).GetWorkingDag().Genesis; !localGenesisHash.IsNil() { // Check genesis already known
+		bestGenesisHash, ok = localGenesisHash, true // Keep local genesis
+	}
+
+	if !ok { // Check no valid votes
+		return ErrNoAvailablePeers // Return error
+	}
 
-		if occurrences[common.NewHash(genesisHash)] > occurrences[common.NewHash(bestGenesisHash)] { // Check better genesis hash
-			bestGenesisHash = genesisHash // Set best genesis hash
+	for peerID, genesisHash := range votes { // Iterate through votes
+		if !genesisHash.IsNil() && genesisHash != bestGenesisHash { // Check peer on another dag
+			client.PeerScores.Ban(peerID) // Ban peer
 		}
 	}
 
-	logger.Infof("found genesis transaction hash %s", hex.EncodeToString(bestGenesisHash)) // Log found genesis hash
+	client.rewardVoters(votes, bestGenesisHash) // Reward peers on the working dag
+
+	if !(*client.Validator).GetWorkingDag().Genesis.IsNil() { // Check genesis already known
+		return nil // Nothing to sync
+	}
+
+	logger.Infof("found genesis transaction hash %s", hex.EncodeToString(bestGenesisHash.Bytes())) // Log found genesis hash
 
 	getGenCtx, cancel := context.WithCancel(ctx) // Get context
 
 	logger.Infof("requesting full genesis transaction") // Log request genesis tx
 
-	genesisTransaction, err := client.RequestTransactionWithHash(getGenCtx, common.NewHash(bestGenesisHash), 16) // Get genesis transaction
-	if err != nil {                                                                                              // Check for errors
+	genesisTransaction, err := client.RequestTransactionWithHash(getGenCtx, bestGenesisHash, 16) // Get genesis transaction
+	if err != nil {                                                                              // Check for errors
 		cancel() // cancel
 
 		return err // Return found error
@@ -233,24 +294,7 @@ func (client *Client) SyncGenesis(ctx context.Context) error {
 			return validator.ErrDuplicateTransaction // Return found error
 		}
 
-		return types.WorkingDagDB.Update(func(tx *bolt.Tx) error {
-			_, err := tx.CreateBucketIfNotExists([]byte("transaction-bucket")) // Create tx bucket if it doesn't already exist
-			if err != nil {                                                    // Check for errors
-				return err // Return found error
-			}
-
-			workingTransactionBucket := tx.Bucket([]byte("transaction-bucket")) // Get transaction bucket
-
-			err = workingTransactionBucket.Put(genesisTransaction.Hash.Bytes(), genesisTransaction.Bytes()) // Put transaction
-
-			if err != nil { // Check for errors
-				return err // Return found error
-			}
-
-			(*client.Validator).GetWorkingDag().Genesis = genesisTransaction.Hash // Set genesis hash
-
-			return (*client.Validator).GetWorkingDag().WriteToMemory() // Write genesis to db header
-		}) // Write genesis transaction
+		return (*client.Validator).GetWorkingDag().AddGenesisTransaction(genesisTransaction) // Write genesis transaction
 	}
 
 	return nil // No error occurred, return nil
@@ -260,124 +304,212 @@ func (client *Client) SyncGenesis(ctx context.Context) error {
 	BEGIN TRANSACTION HELPERS
 */
 
-// PublishTransaction publishes a given transaction.
+// PublishTransaction adds a given transaction to the mempool, then publishes it to a random sampling set of at most GossipFanout
+// peers, each of which relays the transaction (once accepted into its own mempool) to GossipFanout peers of its own, for up to
+// GossipTTL hops.
+// Transactions that cannot yet be added to the working dag (e.g. with a future nonce, or missing parents) are still published,
+// just as received transactions held by the mempool are relayed.
 func (client *Client) PublishTransaction(ctx context.Context, transaction *types.Transaction) error {
 	if WorkingHost == nil { // Check no host
 		return ErrNoWorkingHost // Return found error
 	}
 
-	if err := (*client.Validator).ValidateTransaction(transaction); err != nil && err != validator.ErrDuplicateTransaction { // Validate transaction
+	if err := client.Mempool.AddTransaction(transaction); err != nil && err != mempool.ErrDuplicateTransaction { // Add transaction to mempool
 		return err // Return found error
 	}
 
-	return BroadcastDht(ctx, WorkingHost, transaction.Bytes(), GetStreamHeaderProtocolPath(client.Network, PublishTransaction), client.Network) // Broadcast transaction
+	client.seen.markSeen(transaction.Hash) // Mark transaction seen
+
+	return client.gossipTransaction(ctx, transaction, client.GossipTTL) // Gossip transaction
 }
 
-// RequestTransactionWithHash requests a given transaction with a given hash from the network.
-// Returns best response from peer sampling set nPeers.
-func (client *Client) RequestTransactionWithHash(ctx context.Context, hash common.Hash, nPeers int) (*types.Transaction, error) {
-	transactionBytes, err := BroadcastDhtResult(ctx, WorkingHost, hash.Bytes(), GetStreamHeaderProtocolPath(client.Network, RequestTransaction), client.Network, nPeers) // Request transaction
-	if err != nil {                                                                                                                                                      // Check for errors
-		return &types.Transaction{}, err // Return found error
+// RequestMissingParents requests the missing parents of all orphan transactions in the mempool from the network, adding each
+// received parent to the mempool (where orphans are re-validated once their parents land). Received parents that are orphans
+// themselves have their own missing parents requested in the next round.
+// If missing parents are already being requested, RequestMissingParents returns immediately.
+func (client *Client) RequestMissingParents(ctx context.Context) error {
+	if WorkingHost == nil { // Check no host
+		return ErrNoWorkingHost // Return found error
 	}
 
-	occurrences := make(map[common.Hash]int64) // Occurrences of each transaction hash
-
-	if len(transactionBytes) == 0 { // Check no response
-		return &types.Transaction{}, ErrNilTxResponse // Return error
+	if !atomic.CompareAndSwapInt32(&client.requestingParents, 0, 1) { // Check already requesting
+		return nil // Already requesting
 	}
 
-	bestTransaction := types.TransactionFromBytes(transactionBytes[0]) // Init best transaction buffer
+	defer atomic.StoreInt32(&client.requestingParents, 0) // Finished requesting
 
-	for _, currentTransactionBytes := range transactionBytes { // Iterate through transaction bytes
-		currentTransaction := types.TransactionFromBytes(currentTransactionBytes) // Deserialize
+	requested := make(map[common.Hash]bool) // Init requested parent set
 
-		if currentTransaction.Hash.IsNil() { // Check hash is nil
-			continue // Continue
+	for round := 0; round < maxParentRequestRounds; round++ { // Request missing parents until none remain
+		var missingParents []common.Hash // Init missing parent buffer
+
+		for _, parentHash := range client.Mempool.MissingParents() { // Iterate through missing parents
+			if !requested[parentHash] { // Check not yet requested
+				missingParents = append(missingParents, parentHash) // Append missing parent
+			}
 		}
 
-		occurrences[currentTransaction.Hash]++ // Increment occurrences
+		if len(missingParents) == 0 { // Check nothing to request
+			return nil // No error occurred, return nil
+		}
+
+		for _, parentHash := range missingParents { // Iterate through missing parents
+			requested[parentHash] = true // Set requested
+
+			logger.Infof("requesting missing parent %s from network peers", hex.EncodeToString(parentHash.Bytes())) // Log request parent
+
+			parent, err := client.RequestTransactionWithHash(ctx, parentHash, 16) // Request parent
+			if err != nil {                                                       // Check for errors
+				logger.Errorf("missing parent request for tx %s failed: %s", hex.EncodeToString(parentHash.Bytes()), err.Error()) // Log found error
+
+				continue // Continue
+			}
+
+			if parent.Hash != parentHash { // Check not requested transaction
+				logger.Errorf("peers did not respond with missing parent %s", hex.EncodeToString(parentHash.Bytes())) // Log invalid response
+
+				continue // Continue
+			}
+
+			if err = client.Mempool.AddTransaction(parent); err != nil && err != mempool.ErrDuplicateTransaction { // Add parent to mempool
+				logger.Errorf("rejected missing parent %s: %s", hex.EncodeToString(parentHash.Bytes()), err.Error()) // Log found error
+			}
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// RequestTransactionWithHash requests a given transaction with a given hash from the network.
+// Returns the first valid response from peer sampling set nPeers. Peers responding with another transaction (or a transaction whose
+// hash doesn't match its contents) are penalized, whilst peers without the transaction respond with an empty response, and are not.
+func (client *Client) RequestTransactionWithHash(ctx context.Context, hash common.Hash, nPeers int) (*types.Transaction, error) {
+	request, err := newProtoMessage(RequestMessage, &p2pProto.TransactionRequest{Hash: hash.Bytes()}) // Initialize request
+	if err != nil {                                                                                   // Check for errors
+		return &types.Transaction{}, err // Return found error
+	}
 
-		if occurrences[currentTransaction.Hash] > occurrences[bestTransaction.Hash] { // Check better than last transaction
-			*bestTransaction = *currentTransaction // Set best transaction
+	responses, err := BroadcastDhtResult(ctx, WorkingHost, client.PeerScores, request, TransactionMessage, GetStreamHeaderProtocolPath(client.Network, RequestTransaction), client.Network, nPeers) // Request transaction
+	if err != nil {                                                                                                                                                                                 // Check for errors
+		return &types.Transaction{}, err // Return found error
+	}
+
+	var bestTransaction *types.Transaction // Init best transaction buffer
+
+	for _, response := range responses { // Iterate through responses
+		if transaction := client.decodeTransactionResponse(response, hash); transaction != nil && bestTransaction == nil { // Check first valid response
+			bestTransaction = transaction // Set best transaction
 		}
 	}
 
+	if bestTransaction == nil { // Check no valid response
+		return &types.Transaction{}, ErrNilTxResponse // Return error
+	}
+
 	return bestTransaction, nil // Return best transaction
 }
 
 // RequestTransactionChildren requests the children of a transaction from a sampling set of nPeers size.
+// Returns the child hash set reported by the most peers; peers reporting that set are rewarded.
 func (client *Client) RequestTransactionChildren(ctx context.Context, parentHash common.Hash, nPeers int) ([]common.Hash, error) {
 	if WorkingHost == nil { // Check no host
 		return []common.Hash{}, ErrNoWorkingHost // Return error
 	}
 
-	childHashesAllResponses, err := BroadcastDhtResult(ctx, WorkingHost, parentHash.Bytes(), GetStreamHeaderProtocolPath(client.Network, RequestChildHashes), client.Network, nPeers) // Request child hashes
-	if err != nil {                                                                                                                                                                   // Check for errors
+	request, err := newProtoMessage(RequestMessage, &p2pProto.ChildHashesRequest{Hash: parentHash.Bytes()}) // Initialize request
+	if err != nil {                                                                                         // Check for errors
+		return nil, err // Return found error
+	}
+
+	childHashesAllResponses, err := BroadcastDhtResult(ctx, WorkingHost, client.PeerScores, request, HashListMessage, GetStreamHeaderProtocolPath(client.Network, RequestChildHashes), client.Network, nPeers) // Request child hashes
+	if err != nil {                                                                                                                                                                                            // Check for errors
 		return nil, err // Return found error
 	}
 
 	occurrences := make(map[common.Hash]int64) // Occurrences of each transaction hash
 
+	votes := make(map[peer.ID]common.Hash) // Init hash set votes (peer => hash set hash sum)
+
 	bestChildHashSet := []common.Hash{}      // Init best hash set buffer
 	bestChildHashSetHashSum := common.Hash{} // Init best hash set hash sum buffer
 
-	for _, childHashes := range childHashesAllResponses { // Iterate through children
-		hashes := bytes.Split(childHashes, []byte("end_hash")) // Separate hashes by delimiter
+	for _, response := range childHashesAllResponses { // Iterate through children
+		childHashes := &p2pProto.ChildHashesResponse{} // Init response buffer
+
+		if !client.decodeResponse(response, childHashes) { // Check malformed response
+			continue // Continue
+		}
 
 		var castedHashes []common.Hash // Init casted buffer
 
-		for _, childHash := range hashes { // Iterate through hashes
-			if childHash == nil || len(childHash) == 0 || common.NewHash(childHash).IsNil() { // Check nil hash
+		for _, childHash := range childHashes.Hashes { // Iterate through hashes
+			if !client.checkHashLength(response, childHash) || common.NewHash(childHash).IsNil() { // Check invalid hash
 				continue // Continue
 			}
 
 			castedHashes = append(castedHashes, common.NewHash(childHash)) // Append casted hash
 		}
 
-		if bytes.Equal(bytes.Join(hashes, []byte{}), make([]byte, len(hashes[0])*len(hashes))) { // Check is nil
+		if len(castedHashes) == 0 { // Check is nil
 			continue // Continue
 		}
 
-		occurrences[crypto.Sha3(bytes.Join(hashes, []byte{}))]++ // Increment occurrences
+		var joinedHashes []byte // Init joined hash buffer
 
-		if occurrences[crypto.Sha3(bytes.Join(hashes, []byte{}))] > occurrences[bestChildHashSetHashSum] { // Check new best hash set
-			bestChildHashSet = castedHashes                                     // Set best hash set
-			bestChildHashSetHashSum = crypto.Sha3(bytes.Join(hashes, []byte{})) // Set best hash set hash sum
+		for _, childHash := range castedHashes { // Iterate through valid hashes
+			joinedHashes = append(joinedHashes, childHash.Bytes()...) // Append hash
 		}
+
+		hashSum := crypto.Sha3(joinedHashes) // Get hash sum of valid hash set (so that padding a response doesn't split its vote)
+
+		occurrences[hashSum]++ // Increment occurrences
+
+		votes[response.Peer] = hashSum // Set vote
+
+		if occurrences[hashSum] > occurrences[bestChildHashSetHashSum] { // Check new best hash set
+			bestChildHashSet = castedHashes   // Set best hash set
+			bestChildHashSetHashSum = hashSum // Set best hash set hash sum
+		}
+	}
+
+	if len(bestChildHashSet) != 0 { // Check found children
+		client.rewardVoters(votes, bestChildHashSetHashSum) // Reward peers agreeing with best hash set
 	}
 
 	return bestChildHashSet, nil // No error occurred, return children
 }
 
-// RequestBestTransactionHash returns the average best tx hash between nPeers.
+// RequestBestTransactionHash returns the best tx hash voted for by the most peers of a sampling set of nPeers size (breaking ties in
+// favor of the hash voted for by the highest scoring peers). Peers voting for the returned hash are rewarded.
 func (client *Client) RequestBestTransactionHash(ctx context.Context, nPeers int) (common.Hash, error) {
-	lastTransactionHashes, err := BroadcastDhtResult(ctx, WorkingHost, types.BestTransactionRequest, GetStreamHeaderProtocolPath(client.Network, RequestBestTransaction), client.Network, nPeers) // Get last transaction hashes
-	if err != nil {                                                                                                                                                                               // Check for errors
+	request, err := newProtoMessage(RequestMessage, &p2pProto.BestTransactionRequest{}) // Initialize request
+	if err != nil {                                                                     // Check for errors
 		return common.Hash{}, err // Return found error
 	}
 
-	occurrences := make(map[common.Hash]int64) // Occurrences of each transaction hash
-
-	if len(lastTransactionHashes) == 0 { // Check no peers
-		return common.Hash{}, ErrNoAvailablePeers // Return error
+	responses, err := BroadcastDhtResult(ctx, WorkingHost, client.PeerScores, request, HashMessage, GetStreamHeaderProtocolPath(client.Network, RequestBestTransaction), client.Network, nPeers) // Get last transaction hashes
+	if err != nil {                                                                                                                                                                              // Check for errors
+		return common.Hash{}, err // Return found error
 	}
 
-	bestLastTransactionHash := lastTransactionHashes[0] // Init last transaction buffer
+	votes := make(map[peer.ID]common.Hash) // Init last transaction hash votes
 
-	for _, lastTransactionHash := range lastTransactionHashes { // Iterate through last transaction hashes
-		if bytes.Equal(lastTransactionHash, make([]byte, len(lastTransactionHash))) { // Check nil
-			continue // Continue
-		}
+	for _, response := range responses { // Iterate through responses
+		bestTransactionResponse := &p2pProto.BestTransactionResponse{} // Init response buffer
 
-		occurrences[common.NewHash(lastTransactionHash)]++ // Increment occurrences of transaction hash
-
-		if occurrences[common.NewHash(lastTransactionHash)] > occurrences[common.NewHash(bestLastTransactionHash)] { // Check better last hash
-			bestLastTransactionHash = lastTransactionHash // Set best last transaction hash
+		if client.decodeResponse(response, bestTransactionResponse) && client.checkHashLength(response, bestTransactionResponse.Hash) { // Check valid response
+			votes[response.Peer] = common.NewHash(bestTransactionResponse.Hash) // Set vote
 		}
 	}
 
-	return common.NewHash(bestLastTransactionHash), nil // Return best hash
+	bestLastTransactionHash, ok := client.mostVotedHash(votes) // Get best last transaction hash
+	if !ok {                                                   // Check no valid votes
+		return common.Hash{}, ErrNoAvailablePeers // Return error
+	}
+
+	client.rewardVoters(votes, bestLastTransactionHash) // Reward peers agreeing with best hash
+
+	return bestLastTransactionHash, nil // Return best hash
 }
 
 /*
@@ -388,6 +520,138 @@ func (client *Client) RequestBestTransactionHash(ctx context.Context, nPeers int
 
 /* BEGIN INTERNAL METHODS */
 
+// addSyncedTransactions validates and adds a given set of synced transactions to the working dag in a single write. Each transaction is
+// validated against a dag containing every transaction accepted before it, so that conflicting transactions (e.g. siblings sharing a
+// sender nonce) are never both added. Transactions that can't be added are held in the mempool (e.g. if orphaned).
+func (client *Client) addSyncedTransactions(transactions []*types.Transaction) error {
+	workingConfig := (*client.Validator).GetWorkingConfig() // Get working config
+
+	results, err := (*client.Validator).GetWorkingDag().ValidateAndAddTransactions(transactions, func(batchDag *types.Dag) types.TransactionValidator {
+		return validator.NewHistoricalBeaconDagValidator(workingConfig, batchDag) // Validate via historical beacon dag validator (synced transactions may have expired since)
+	}) // Validate and add transactions
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	for i, err := range results { // Iterate through results
+		if err == nil { // Check added
+			continue // Continue
+		}
+
+		if err = client.Mempool.AddTransaction(transactions[i]); err != nil { // Hold transaction in mempool (e.g. if orphaned)
+			logger.Errorf("validation error while adding tx %s: %s", hex.EncodeToString(transactions[i].Hash.Bytes()), err.Error()) // Log found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// gossipTransaction sends a given transaction, relayable for a given number of hops, to a random sampling set of at most GossipFanout
+// peers (skipping a given set of excluded peers).
+func (client *Client) gossipTransaction(ctx context.Context, transaction *types.Transaction, ttl uint32, excluded ...peer.ID) error {
+	message, err := newProtoMessage(TransactionMessage, &p2pProto.PublishTransactionRequest{Transaction: transaction.Bytes(), Ttl: ttl}) // Initialize message
+	if err != nil {                                                                                                                      // Check for errors
+		return err // Return found error
+	}
+
+	return GossipDht(ctx, WorkingHost, client.PeerScores, message, GetStreamHeaderProtocolPath(client.Network, PublishTransaction), client.GossipFanout, excluded...) // Gossip transaction
+}
+
+// decodeTransactionResponse decodes the transaction with a given hash from a given response to a transaction request.
+// Returns nil if the response is malformed, empty (i.e. the peer doesn't have the transaction) or holds another transaction; peers are only
+// penalized for malformed responses, and for responding with another transaction (or a transaction whose hash doesn't match its contents),
+// and are only rewarded for responding with the requested transaction.
+func (client *Client) decodeTransactionResponse(response *PeerResponse, hash common.Hash) *types.Transaction {
+	transactionResponse := &p2pProto.TransactionResponse{} // Init response buffer
+
+	if !client.decodeResponse(response, transactionResponse) || len(transactionResponse.Transaction) == 0 { // Check invalid (or unknown transaction) response
+		return nil // No transaction
+	}
+
+	transaction := types.TransactionFromBytes(transactionResponse.Transaction) // Deserialize transaction
+
+	if transaction.Hash != hash || !(*client.Validator).ValidateTransactionHash(transaction) { // Check not requested transaction
+		client.PeerScores.Penalize(response.Peer, InvalidTransactionPenalty) // Penalize peer
+
+		return nil // Invalid transaction
+	}
+
+	client.PeerScores.Reward(response.Peer) // Reward peer
+
+	return transaction // Return transaction
+}
+
+// decodeResponse decodes the payload of a given response into a given protobuf message, penalizing the responding peer if the
+// payload can't be decoded. Peers are not rewarded for merely decodable responses; callers reward useful, correct responses.
+func (client *Client) decodeResponse(response *PeerResponse, payload proto.Message) bool {
+	if err := proto.Unmarshal(response.Payload, payload); err != nil { // Decode payload
+		client.PeerScores.Penalize(response.Peer, MalformedMessagePenalty) // Penalize peer
+
+		return false // Malformed response
+	}
+
+	return true // Valid response
+}
+
+// checkHashLength checks that a given hash (sent in a given response) is of length common.HashLength, penalizing the responding
+// peer otherwise.
+func (client *Client) checkHashLength(response *PeerResponse, hash []byte) bool {
+	if len(hash) != common.HashLength { // Check invalid length
+		client.PeerScores.Penalize(response.Peer, MalformedMessagePenalty) // Penalize peer
+
+		return false // Invalid hash
+	}
+
+	return true // Valid hash
+}
+
+// mostVotedHash gets the non-nil hash voted for by the most peers in a given set of votes (peer => hash), breaking ties in favor of
+// the hash whose voters have the highest total score (and then in favor of the lowest hash).
+// Returns false if no peer voted for a non-nil hash.
+func (client *Client) mostVotedHash(votes map[peer.ID]common.Hash) (common.Hash, bool) {
+	occurrences := make(map[common.Hash]int) // Occurrences of each hash
+
+	scores := make(map[common.Hash]int) // Total score of the voters of each hash
+
+	for peerID, hash := range votes { // Iterate through votes
+		if hash.IsNil() { // Check nil
+			continue // Continue
+		}
+
+		occurrences[hash]++                             // Increment occurrences of hash
+		scores[hash] += client.PeerScores.Score(peerID) // Add voter score
+	}
+
+	var bestHash common.Hash // Init best hash buffer
+
+	found := false // Init found
+
+	for hash, count := range occurrences { // Iterate through voted hashes
+		if found { // Check already has best hash
+			if count < occurrences[bestHash] || (count == occurrences[bestHash] && scores[hash] < scores[bestHash]) { // Check worse hash
+				continue // Continue
+			}
+
+			if count == occurrences[bestHash] && scores[hash] == scores[bestHash] && bytes.Compare(hash.Bytes(), bestHash.Bytes()) > 0 { // Check tied, but higher hash
+				continue // Continue
+			}
+		}
+
+		bestHash, found = hash, true // Set best hash
+	}
+
+	return bestHash, found // Return best hash
+}
+
+// rewardVoters rewards each peer voting for a given hash in a given set of votes (peer => hash).
+func (client *Client) rewardVoters(votes map[peer.ID]common.Hash, hash common.Hash) {
+	for peerID, vote := range votes { // Iterate through votes
+		if vote == hash { // Check voted for hash
+			client.PeerScores.Reward(peerID) // Reward peer
+		}
+	}
+}
+
 // getLogger gets the p2p package logger, and sets the levels of said logger.
 func getLogger() loggo.Logger {
 	logger := loggo.GetLogger("p2p") // Get logger
@@ -2,12 +2,16 @@ package p2p
 
 import (
 	"bufio"
+	"context"
 	"encoding/hex"
+	"time"
 
 	inet "github.com/libp2p/go-libp2p-net"
-	protocol "github.com/libp2p/go-libp2p-protocol"
 	"github.com/polaris-project/go-polaris/common"
+	p2pProto "github.com/polaris-project/go-polaris/internal/proto/p2p"
+	"github.com/polaris-project/go-polaris/mempool"
 	"github.com/polaris-project/go-polaris/types"
+	"github.com/polaris-project/go-polaris/validator"
 )
 
 /* BEGIN EXPORTED METHODS */
@@ -58,13 +62,28 @@ func (client *Client) StartServingStreams(network string) error {
 	return nil // No error occurred, return nil
 }
 
-// StartServingStream starts serving a stream on a given header protocol path.
+// StartServingStream starts serving a stream on each supported version of a given header protocol path.
+// Streams opened by banned peers are reset without being handled, and all other streams are given a deadline of StreamTimeout.
 func (client *Client) StartServingStream(streamHeaderProtocolPath string, handler func(inet.Stream)) error {
 	if WorkingHost == nil { // Check no host
 		return ErrNoWorkingHost // Return found error
 	}
 
-	WorkingHost.SetStreamHandler(protocol.ID(streamHeaderProtocolPath), handler) // Set handler
+	guardedHandler := func(stream inet.Stream) {
+		if client.PeerScores.IsBanned(stream.Conn().RemotePeer()) { // Check banned
+			stream.Reset() // Reset stream
+
+			return // Return
+		}
+
+		stream.SetDeadline(time.Now().Add(StreamTimeout)) // Set deadline
+
+		handler(stream) // Handle stream
+	} // Wrap handler
+
+	for _, protocolID := range GetStreamHeaderProtocolIDs(streamHeaderProtocolPath) { // Iterate through versions
+		WorkingHost.SetStreamHandler(protocolID, guardedHandler) // Set handler
+	}
 
 	return nil // No error occurred, return nil
 }
@@ -78,24 +97,55 @@ func (client *Client) StartServingStream(streamHeaderProtocolPath string, handle
 */
 
 // HandleReceiveTransaction handles a new stream sending a transaction.
+// Transactions that have already been seen are dropped; transactions accepted into the mempool are relayed to other peers until
+// their TTL runs out. Peers sending transactions that can never be valid are penalized.
 func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 	logger.Infof("handling new publish transaction stream") // Log handle stream
 
 	reader := bufio.NewReader(stream) // Initialize reader from stream
 
-	transactionBytes, err := readAsync(reader) // Read async
-	if err != nil {                            // Check for errors
+	request := &p2pProto.PublishTransactionRequest{} // Init request buffer
+
+	if err := readProto(reader, TransactionMessage, request); err != nil { // Read transaction
+		client.penalizeRequestError(stream, err) // Penalize peer
+
+		return // Return
+	}
+
+	transaction := types.TransactionFromBytes(request.Transaction) // Deserialize transaction
+
+	if !(*client.Validator).ValidateTransactionHash(transaction) { // Check invalid hash (a forged hash must never mark another transaction as seen)
+		client.PeerScores.Penalize(stream.Conn().RemotePeer(), InvalidTransactionPenalty) // Penalize peer
+
 		return // Return
 	}
 
-	transaction := types.TransactionFromBytes(transactionBytes) // Deserialize transaction
+	if !client.seen.markSeen(transaction.Hash) { // Check already seen
+		return // Return
+	}
+
+	logger.Infof("adding received transaction with hash: %s to mempool", hex.EncodeToString(transaction.Hash.Bytes())) // Log receive tx
 
-	logger.Infof("validating received transaction with hash: %s", hex.EncodeToString(transaction.Hash.Bytes())) // Log receive tx
+	if err := client.Mempool.AddTransaction(transaction); err != nil { // Add transaction to mempool
+		logger.Infof("rejected received transaction: %s", err.Error()) // Log rejected
 
-	if err := (*client.Validator).ValidateTransaction(transaction); err == nil { // Check transaction valid
-		logger.Infof("transaction was valid; adding to dag") // Log add to dag
+		if isInvalidTransactionError(err) { // Check can never be valid
+			client.PeerScores.Penalize(stream.Conn().RemotePeer(), InvalidTransactionPenalty) // Penalize peer
+		}
 
-		(*client.Validator).GetWorkingDag().AddTransaction(transaction) // Add transaction to working dag
+		return // Return
+	}
+
+	if ttl := request.Ttl; ttl > 1 { // Check relayable
+		if ttl > client.GossipTTL { // Check TTL exceeds local TTL
+			ttl = client.GossipTTL // Limit TTL
+		}
+
+		go client.gossipTransaction(context.Background(), transaction, ttl-1, stream.Conn().RemotePeer()) // Relay transaction
+	}
+
+	if len(client.Mempool.MissingParents()) != 0 { // Check transaction (or an earlier transaction) orphaned
+		go client.RequestMissingParents(context.Background()) // Request missing parents
 	}
 }
 
@@ -103,15 +153,21 @@ func (client *Client) HandleReceiveTransaction(stream inet.Stream) {
 func (client *Client) HandleReceiveBestTransactionRequest(stream inet.Stream) {
 	logger.Infof("handling new best transaction request stream") // Log handle stream
 
-	writer := bufio.NewWriter(stream) // Initialize writer from stream
+	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Init reader/writer for stream
+
+	defer readWriter.Flush() // Flush
 
-	defer writer.Flush() // Flush
+	if err := readProto(readWriter.Reader, RequestMessage, &p2pProto.BestTransactionRequest{}); err != nil { // Read request
+		client.penalizeRequestError(stream, err) // Penalize peer
+
+		return // Return
+	}
 
 	bestTransaction, _ := (*client.Validator).GetWorkingDag().GetBestTransaction() // Get best transaction
 
 	logger.Infof("responding with best transaction hash %s", hex.EncodeToString(bestTransaction.Hash.Bytes())) // Log handle stream
 
-	writer.Write(append(bestTransaction.Hash.Bytes(), byte('\f'))) // Write best transaction hash
+	writeProto(readWriter, HashMessage, &p2pProto.BestTransactionResponse{Hash: bestTransaction.Hash.Bytes()}) // Write best transaction hash
 }
 
 // HandleReceiveTransactionRequest handles a new stream requesting transaction metadata with a given hash.
@@ -122,47 +178,59 @@ func (client *Client) HandleReceiveTransactionRequest(stream inet.Stream) {
 
 	defer readWriter.Flush() // Flush
 
-	targetHashBytes, err := readAsync(readWriter.Reader) // Read async
-	if err != nil {                                      // Check for errors
-		return // Return
-	}
+	request := &p2pProto.TransactionRequest{} // Init request buffer
 
-	logger.Infof("handling request for transaction with hash: %s", hex.EncodeToString(targetHashBytes)) // Log handle request
+	if err := readProto(readWriter.Reader, RequestMessage, request); err != nil { // Read request
+		client.penalizeRequestError(stream, err) // Penalize peer
 
-	transaction, _ := (*client.Validator).GetWorkingDag().GetTransactionByHash(common.NewHash(targetHashBytes)) // Get transaction with hash
+		return // Return
+	}
 
-	logger.Infof("responding with serialized transaction bytes: %s (len: %d), hash: %s", hex.EncodeToString(transaction.Bytes())[:36], len(transaction.Bytes()), hex.EncodeToString(transaction.Hash.Bytes())) // Log respond
+	logger.Infof("handling request for transaction with hash: %s", hex.EncodeToString(request.Hash)) // Log handle request
 
-	readWriter.Write(append(transaction.Bytes(), byte('\f'))) // Write transaction bytes
+	writeProto(readWriter, TransactionMessage, client.transactionResponse(common.NewHash(request.Hash))) // Write transaction bytes
 }
 
 // HandleReceiveConfigRequest handles a new stream requesting the working dag config.
 func (client *Client) HandleReceiveConfigRequest(stream inet.Stream) {
 	logger.Infof("handling new config request stream") // Log handle stream
 
-	writer := bufio.NewWriter(stream) // Initialize writer
+	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Init reader/writer for stream
+
+	defer readWriter.Flush() // Flush
 
-	defer writer.Flush() // Flush
+	if err := readProto(readWriter.Reader, RequestMessage, &p2pProto.ConfigRequest{}); err != nil { // Read request
+		client.penalizeRequestError(stream, err) // Penalize peer
+
+		return // Return
+	}
 
 	logger.Infof("responding with serialized config bytes: %s", hex.EncodeToString((*client.Validator).GetWorkingConfig().Bytes())[:36]) // Log response
 
-	writer.Write(append((*client.Validator).GetWorkingConfig().Bytes(), byte('\f'))) // Write config bytes
+	writeProto(readWriter, ConfigMessage, &p2pProto.ConfigResponse{Config: (*client.Validator).GetWorkingConfig().Bytes()}) // Write config bytes
 }
 
 // HandleReceiveGenesisHashRequest handles a new stream requesting for the genesis hash of the working dag.
 func (client *Client) HandleReceiveGenesisHashRequest(stream inet.Stream) {
 	logger.Infof("handling new genesis hash request stream") // Log handle stream
 
-	writer := bufio.NewWriter(stream) // Initialize writer
+	readWriter := bufio.NewReadWriter(bufio.NewReader(stream), bufio.NewWriter(stream)) // Init reader/writer for stream
+
+	defer readWriter.Flush() // Flush
+
+	if err := readProto(readWriter.Reader, RequestMessage, &p2pProto.GenesisHashRequest{}); err != nil { // Read request
+		client.penalizeRequestError(stream, err) // Penalize peer
 
-	defer writer.Flush() // Flush
+		return // Return
+	}
 
 	logger.Infof("responding with genesis hash: %s", hex.EncodeToString((*client.Validator).GetWorkingDag().Genesis.Bytes())) // Log response
 
-	writer.Write(append((*client.Validator).GetWorkingDag().Genesis.Bytes(), byte('\f'))) // Write genesis hash
+	writeProto(readWriter, HashMessage, &p2pProto.GenesisHashResponse{Hash: (*client.Validator).GetWorkingDag().Genesis.Bytes()}) // Write genesis hash
 }
 
 // HandleReceiveTransactionChildHashesRequest handles a new stream requesting for the child hashes of a given transaction.
+// Responds with an empty hash list if the transaction is unknown.
 func (client *Client) HandleReceiveTransactionChildHashesRequest(stream inet.Stream) {
 	logger.Infof("handling new child hash request stream") // Log handle stream
 
@@ -170,32 +238,27 @@ func (client *Client) HandleReceiveTransactionChildHashesRequest(stream inet.Str
 
 	defer readWriter.Flush() // Flush
 
-	parentHashBytes, err := readAsync(readWriter.Reader) // Read async
-	if err != nil {                                      // Check for errors
-		return // Return
-	}
+	request := &p2pProto.ChildHashesRequest{} // Init request buffer
 
-	children, err := (*client.Validator).GetWorkingDag().GetTransactionChildren(common.NewHash(parentHashBytes)) // Get children
+	if err := readProto(readWriter.Reader, RequestMessage, request); err != nil { // Read request
+		client.penalizeRequestError(stream, err) // Penalize peer
 
-	if err == nil { // Check no error
-		var summarizedChildHashes []byte // Init summarized child hashes buffer
+		return // Return
+	}
 
-		for x, child := range children { // Iterate through children
-			if x == len(children)-1 { // Check is last
-				summarizedChildHashes = append(summarizedChildHashes, child.Hash[:]...) // Append hash
-			}
+	response := &p2pProto.ChildHashesResponse{} // Init response
 
-			summarizedChildHashes = append(summarizedChildHashes, append(child.Hash[:], []byte("end_hash")...)...) // Append hash
-		}
+	children, err := (*client.Validator).GetWorkingDag().GetTransactionChildren(common.NewHash(request.Hash)) // Get children
 
-		if hexEncodedChildHashes := hex.EncodeToString(summarizedChildHashes); hexEncodedChildHashes != "" { // Check can log
-			logger.Infof("responding with child hashes: %s", hex.EncodeToString(summarizedChildHashes)[:36]) // Log response
+	if err == nil { // Check no error
+		for _, child := range children { // Iterate through children
+			response.Hashes = append(response.Hashes, child.Hash.Bytes()) // Append hash
 		}
+	}
 
-		readWriter.Write(append(summarizedChildHashes, byte('\f'))) // Write child hashes
+	logger.Infof("responding with %d child hashes", len(response.Hashes)) // Log response
 
-		readWriter.Flush() // Flush
-	}
+	writeProto(readWriter, HashListMessage, response) // Write child hashes
 }
 
 /*
@@ -203,3 +266,38 @@ func (client *Client) HandleReceiveTransactionChildHashesRequest(stream inet.Str
 */
 
 /* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// transactionResponse initializes a response to a request for the transaction with a given hash.
+// If the working dag has no such transaction, the response is empty.
+func (client *Client) transactionResponse(hash common.Hash) *p2pProto.TransactionResponse {
+	transaction, err := (*client.Validator).GetWorkingDag().GetTransactionByHash(hash) // Get transaction with hash
+	if err != nil {                                                                    // Check unknown transaction
+		logger.Infof("responding without transaction (%s)", err.Error()) // Log respond
+
+		return &p2pProto.TransactionResponse{} // Return empty response
+	}
+
+	logger.Infof("responding with serialized transaction bytes: %s (len: %d), hash: %s", hex.EncodeToString(transaction.Bytes())[:36], len(transaction.Bytes()), hex.EncodeToString(transaction.Hash.Bytes())) // Log respond
+
+	return &p2pProto.TransactionResponse{Transaction: transaction.Bytes()} // Return transaction response
+}
+
+// penalizeRequestError penalizes the peer that opened a given stream for a given error encountered while reading its request.
+func (client *Client) penalizeRequestError(stream inet.Stream, err error) {
+	client.PeerScores.penalizeReadError(stream.Conn().RemotePeer(), err) // Penalize peer
+}
+
+// isInvalidTransactionError checks whether or not a given mempool error marks a transaction that can never be valid (i.e. that an
+// honest peer would never have sent).
+func isInvalidTransactionError(err error) bool {
+	switch err {
+	case mempool.ErrIncompleteTransaction, validator.ErrInvalidTransactionAmount, validator.ErrInvalidTransactionHash, validator.ErrInvalidTransactionNetwork, validator.ErrInvalidTransactionSignature:
+		return true // Invalid transaction
+	default:
+		return false // Transaction may be valid
+	}
+}
+
+/* END INTERNAL METHODS */
@@ -2,12 +2,16 @@ package p2p
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
-	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	inet "github.com/libp2p/go-libp2p-net"
+	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
+	polarisCrypto "github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 	"github.com/polaris-project/go-polaris/types"
 	"github.com/polaris-project/go-polaris/validator"
 )
@@ -16,15 +20,22 @@ import (
 
 // TestStartServingStream tests the functionality of the StartServingStream() helper method.
 func TestStartServingStream(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                                 // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
 
 	client := NewClient("test_network", &validator) // Initialize client
@@ -48,8 +59,62 @@ func TestStartServingStream(t *testing.T) {
 	if err != nil { // Check for errors
 		t.Fatal(err) // Panic
 	}
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 /* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// TestTransactionResponse tests the functionality of the transactionResponse() and decodeTransactionResponse() helper methods.
+func TestTransactionResponse(t *testing.T) {
+	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
+
+	client := NewClient("test_network", &validator) // Initialize client
+
+	client.PeerScores = NewPeerScores() // Reset peer scores
+
+	known, unknown := genesisTransactions[0].Hash, polarisCrypto.Sha3([]byte("unknown")) // Get known and unknown transaction hashes
+
+	peerID := newTestPeerID(t) // Initialize peer ID
+
+	for _, test := range []struct {
+		servedHash, requestedHash common.Hash // Served and requested transaction hashes
+		found                     bool        // Whether the requested transaction should be found
+		score                     int         // Expected peer score after the response
+	}{
+		{unknown, unknown, false, 0},                           // Peer without transaction (not rewarded for an empty response)
+		{known, known, true, 1},                                // Peer with transaction
+		{known, unknown, false, 1 - InvalidTransactionPenalty}, // Peer with another transaction
+	} { // Iterate through tests
+		payload, err := proto.Marshal(client.transactionResponse(test.servedHash)) // Respond to request
+		if err != nil {                                                            // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transaction := client.decodeTransactionResponse(&PeerResponse{Peer: peerID, Payload: payload}, test.requestedHash) // Decode response
+
+		if (transaction != nil) != test.found { // Check transaction unexpectedly found (or not found)
+			t.Fatalf("requested transaction %x should be found: %t", test.requestedHash.Bytes(), test.found) // Panic
+		}
+
+		if client.PeerScores.Score(peerID) != test.score { // Check unexpected score
+			t.Fatalf("peer should have a score of %d; got %d", test.score, client.PeerScores.Score(peerID)) // Panic
+		}
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
@@ -2,11 +2,20 @@ package p2p
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
 	"os"
-	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 	"github.com/polaris-project/go-polaris/types"
 	"github.com/polaris-project/go-polaris/validator"
 )
@@ -15,15 +24,22 @@ import (
 
 // TestNewClient tests the functionality of the client initializer.
 func TestNewClient(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                                 // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	_, err = NewHost(context.Background(), 2831) // Initialize host
 
 	if err != nil { // Check for errors
@@ -37,10 +53,137 @@ func TestNewClient(t *testing.T) {
 	if client == nil { // Check client is nil
 		t.Fatal("client should not be nil") // Panic
 	}
+}
 
-	types.WorkingDagDB.Close() // Close dag db
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
 
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+// TestAddSyncedTransactions tests the functionality of the addSyncedTransactions() helper method.
+func TestAddSyncedTransactions(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := crypto.AddressFromPrivateKey(privateKey) // Generate address
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(address.Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	var siblings []*types.Transaction // Init siblings buffer
+
+	for i := 0; i < 2; i++ { // Initialize each sibling
+		sibling := types.NewTransaction(
+			0,                                  // Nonce
+			big.NewInt(60),                     // Amount
+			address,                            // Sender
+			common.NewAddress([]byte{byte(i)}), // Recipient
+			[]common.Hash{genesisTransactions[len(genesisTransactions)-1].Hash}, // Parents
+			1,                      // Gas limit
+			big.NewInt(1),          // Gas price
+			[]byte("test payload"), // Payload
+			1,                      // Network
+			time.Time{},            // Valid until
+		) // Initialize sibling sharing a nonce with (and together overspending with) the other sibling
+
+		if err = types.SignTransaction(sibling, privateKey); err != nil { // Sign sibling
+			t.Fatal(err) // Panic
+		}
+
+		siblings = append(siblings, sibling) // Append sibling
+	}
+
+	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
+
+	client := NewClient("test_network", &validator) // Initialize client
+
+	if err = client.addSyncedTransactions(siblings); err != nil { // Add siblings
+		t.Fatal(err) // Panic
+	}
+
+	added := 0 // Init added counter
+
+	for _, sibling := range siblings { // Iterate through siblings
+		if _, err = dag.GetTransactionByHash(sibling.Hash); err == nil { // Check sibling added
+			added++ // Increment added
+		}
+	}
+
+	if added != 1 { // Check conflicting siblings added
+		t.Fatalf("exactly 1 of 2 conflicting siblings should have been added; added %d", added) // Panic
+	}
+
+	if accountState, err := dag.GetAccountState(address); err != nil || accountState.Balance.Cmp(big.NewInt(39)) != 0 { // Check invalid balance
+		t.Fatalf("sender balance should be 39 after a single sibling; got %v (%v)", accountState.Balance, err) // Panic
+	}
 }
 
-/* END EXPORTED METHODS TESTS */
+// TestAddSyncedExpiredTransaction tests that the addSyncedTransactions() helper method adds synced transactions that have expired since
+// they were created.
+func TestAddSyncedExpiredTransaction(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := crypto.AddressFromPrivateKey(privateKey) // Generate address
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(address.Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	child := types.NewTransaction(
+		0,                                  // Nonce
+		big.NewInt(10),                     // Amount
+		address,                            // Sender
+		common.NewAddress([]byte{byte(1)}), // Recipient
+		[]common.Hash{genesisTransactions[len(genesisTransactions)-1].Hash}, // Parents
+		1,                                   // Gas limit
+		big.NewInt(1),                       // Gas price
+		[]byte("test payload"),              // Payload
+		1,                                   // Network
+		time.Now().Add(50*time.Millisecond), // Valid until
+	) // Initialize child expiring shortly
+
+	if err = types.SignTransaction(child, privateKey); err != nil { // Sign child
+		t.Fatal(err) // Panic
+	}
+
+	time.Sleep(time.Until(child.ValidUntil.Add(time.Millisecond))) // Wait for child to expire
+
+	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
+
+	client := NewClient("test_network", &validator) // Initialize client
+
+	if err = client.addSyncedTransactions([]*types.Transaction{child}); err != nil { // Add child
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = dag.GetTransactionByHash(child.Hash); err != nil { // Check child not added
+		t.Fatal("synced transaction that has since expired should have been added") // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
@@ -0,0 +1,84 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+const (
+	// DefaultGossipFanout is the default maximum number of peers a transaction is published or relayed to by a single node.
+	DefaultGossipFanout = 8
+
+	// DefaultGossipTTL is the default number of hops a published transaction is relayed.
+	DefaultGossipTTL = 8
+
+	// DefaultSeenCacheSize is the default maximum number of recently seen transaction hashes remembered by a client.
+	DefaultSeenCacheSize = 1 << 16
+
+	// DefaultSeenCacheExpiry is the default duration a seen transaction hash is remembered for.
+	DefaultSeenCacheExpiry = 10 * time.Minute
+)
+
+// seenCache is a bounded set of recently seen hashes, each of which is forgotten after a given expiry (or once the set is full,
+// earliest seen first).
+type seenCache struct {
+	maxEntries int // Maximum number of remembered hashes
+
+	expiry time.Duration // Duration a hash is remembered for
+
+	seen map[common.Hash]time.Time // Remembered hashes (hash => time first seen)
+
+	order []common.Hash // Remembered hashes, in order of first seen
+
+	lock sync.Mutex // Seen hashes lock
+}
+
+/* BEGIN INTERNAL METHODS */
+
+// newSeenCache initializes a new, empty seen cache remembering at most maxEntries hashes for a given expiry.
+func newSeenCache(maxEntries int, expiry time.Duration) *seenCache {
+	return &seenCache{
+		maxEntries: maxEntries,                      // Set max entries
+		expiry:     expiry,                          // Set expiry
+		seen:       make(map[common.Hash]time.Time), // Init seen
+	} // Return initialized cache
+}
+
+// markSeen marks a given hash as seen.
+// Returns false if the hash has already been seen (and has not since been forgotten).
+func (cache *seenCache) markSeen(hash common.Hash) bool {
+	cache.lock.Lock() // Lock
+
+	defer cache.lock.Unlock() // Unlock
+
+	now := time.Now() // Get now
+
+	for len(cache.order) != 0 && now.Sub(cache.seen[cache.order[0]]) >= cache.expiry { // Forget expired hashes
+		cache.forgetEarliest() // Forget hash
+	}
+
+	if _, ok := cache.seen[hash]; ok { // Check already seen
+		return false // Already seen
+	}
+
+	for len(cache.order) != 0 && len(cache.order) >= cache.maxEntries { // Make room for hash
+		cache.forgetEarliest() // Forget hash
+	}
+
+	cache.seen[hash] = now                  // Remember hash
+	cache.order = append(cache.order, hash) // Append hash
+
+	return true // Not yet seen
+}
+
+// forgetEarliest forgets the earliest seen hash.
+func (cache *seenCache) forgetEarliest() {
+	delete(cache.seen, cache.order[0]) // Forget hash
+
+	cache.order = cache.order[1:] // Remove hash
+}
+
+/* END INTERNAL METHODS */
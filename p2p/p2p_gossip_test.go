@@ -0,0 +1,46 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// TestMarkSeen tests the functionality of the markSeen() helper method.
+func TestMarkSeen(t *testing.T) {
+	cache := newSeenCache(2, time.Hour) // Initialize cache
+
+	a, b, c := crypto.Sha3([]byte("a")), crypto.Sha3([]byte("b")), crypto.Sha3([]byte("c")) // Get hashes
+
+	if !cache.markSeen(a) || !cache.markSeen(b) { // Mark hashes seen
+		t.Fatal("unseen hashes should be marked seen") // Panic
+	}
+
+	if cache.markSeen(a) { // Mark hash seen again
+		t.Fatal("seen hash should not be marked seen twice") // Panic
+	}
+
+	if !cache.markSeen(c) { // Mark hash seen (forgetting earliest hash)
+		t.Fatal("unseen hash should be marked seen") // Panic
+	}
+
+	if len(cache.seen) != 2 || !cache.markSeen(a) { // Check earliest hash forgotten
+		t.Fatal("full cache should forget earliest seen hash") // Panic
+	}
+
+	cache = newSeenCache(2, time.Millisecond) // Initialize cache
+
+	cache.markSeen(a) // Mark hash seen
+
+	time.Sleep(2 * time.Millisecond) // Wait for hash to expire
+
+	if !cache.markSeen(a) { // Mark hash seen again
+		t.Fatal("expired hash should be marked seen") // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
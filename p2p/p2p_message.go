@@ -0,0 +1,178 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Message type definitions
+const (
+	RequestMessage MessageType = iota // A request (e.g. a TransactionRequest)
+
+	TransactionMessage // A transaction (a PublishTransactionRequest or TransactionResponse)
+
+	ConfigMessage // A dag config (a ConfigResponse)
+
+	HashMessage // A single hash (a BestTransactionResponse or GenesisHashResponse)
+
+	HashListMessage // A list of hashes (a ChildHashesResponse)
+)
+
+const (
+	// MessageVersion is the version of the message framing written by this node.
+	MessageVersion = byte(1)
+
+	// MaxMessageSize is the maximum size of a message payload.
+	MaxMessageSize = 4 << 20
+)
+
+var (
+	// ErrMessageTooLarge is an error definition representing a message payload larger than MaxMessageSize.
+	ErrMessageTooLarge = errors.New("message exceeds the maximum message size")
+
+	// ErrUnsupportedMessageVersion is an error definition representing a message framed with an unknown version.
+	ErrUnsupportedMessageVersion = errors.New("unsupported message version")
+
+	// ErrUnexpectedMessageType is an error definition representing a message of a type other than the type expected by its reader.
+	ErrUnexpectedMessageType = errors.New("unexpected message type")
+)
+
+// MessageType represents the type of a message's payload.
+type MessageType byte
+
+// Message is a framed message exchanged over a stream.
+// On the wire, a message is the uvarint encoded length of its payload, followed by its type, its version and its payload (a protobuf
+// message of the stream's protocol; see p2p.proto).
+type Message struct {
+	Type MessageType `json:"type"` // Message type
+
+	Version byte `json:"version"` // Framing version
+
+	Payload []byte `json:"payload"` // Payload
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMessage initializes a new message of a given type with a given payload.
+func NewMessage(messageType MessageType, payload []byte) *Message {
+	return &Message{
+		Type:    messageType,    // Set type
+		Version: MessageVersion, // Set version
+		Payload: payload,        // Set payload
+	} // Return initialized message
+}
+
+// WriteMessage writes a given message to a given writer.
+// Returns an ErrMessageTooLarge error if the message's payload is larger than MaxMessageSize.
+func WriteMessage(writer io.Writer, message *Message) error {
+	if len(message.Payload) > MaxMessageSize { // Check too large
+		return ErrMessageTooLarge // Return error
+	}
+
+	header := make([]byte, binary.MaxVarintLen64+2) // Init header buffer
+
+	n := binary.PutUvarint(header, uint64(len(message.Payload))) // Write length
+
+	header[n] = byte(message.Type) // Write type
+	header[n+1] = message.Version  // Write version
+
+	if _, err := writer.Write(header[:n+2]); err != nil { // Write header
+		return err // Return found error
+	}
+
+	_, err := writer.Write(message.Payload) // Write payload
+
+	return err // Return error (if any)
+}
+
+// ReadMessage reads a message from a given reader.
+// Returns an ErrMessageTooLarge error if the message's payload is larger than MaxMessageSize (without reading the payload), or an
+// ErrUnsupportedMessageVersion error if the message is framed with an unknown version.
+func ReadMessage(reader *bufio.Reader) (*Message, error) {
+	length, err := binary.ReadUvarint(reader) // Read length
+	if err != nil {                           // Check for errors
+		return nil, err // Return found error
+	}
+
+	if length > MaxMessageSize { // Check too large
+		return nil, ErrMessageTooLarge // Return error
+	}
+
+	header := make([]byte, 2) // Init header buffer
+
+	if _, err = io.ReadFull(reader, header); err != nil { // Read type and version
+		return nil, err // Return found error
+	}
+
+	if header[1] != MessageVersion { // Check unknown version
+		return nil, ErrUnsupportedMessageVersion // Return error
+	}
+
+	message := &Message{
+		Type:    MessageType(header[0]), // Set type
+		Version: header[1],              // Set version
+		Payload: make([]byte, length),   // Init payload buffer
+	} // Init message
+
+	if _, err = io.ReadFull(reader, message.Payload); err != nil { // Read payload
+		return nil, err // Return found error
+	}
+
+	return message, nil // Return read message
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// newProtoMessage initializes a new message of a given type with a given protobuf message as its payload.
+func newProtoMessage(messageType MessageType, payload proto.Message) (*Message, error) {
+	encoded, err := proto.Marshal(payload) // Encode payload
+	if err != nil {                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	return NewMessage(messageType, encoded), nil // Return initialized message
+}
+
+// writeProto writes a given protobuf message as a message of a given type to a given writer.
+func writeProto(writer io.Writer, messageType MessageType, payload proto.Message) error {
+	message, err := newProtoMessage(messageType, payload) // Initialize message
+	if err != nil {                                       // Check for errors
+		return err // Return found error
+	}
+
+	return WriteMessage(writer, message) // Write message
+}
+
+// readProto reads a message of a given type from a given reader, and decodes its payload into a given protobuf message.
+func readProto(reader *bufio.Reader, messageType MessageType, payload proto.Message) error {
+	encoded, err := readPayload(reader, messageType) // Read payload
+	if err != nil {                                  // Check for errors
+		return err // Return found error
+	}
+
+	return proto.Unmarshal(encoded, payload) // Decode payload
+}
+
+// readPayload reads a message of a given type from a given reader, returning its payload.
+// Returns an ErrUnexpectedMessageType error if the message is of another type.
+func readPayload(reader *bufio.Reader, messageType MessageType) ([]byte, error) {
+	message, err := ReadMessage(reader) // Read message
+	if err != nil {                     // Check for errors
+		return nil, err // Return found error
+	}
+
+	if message.Type != messageType { // Check unexpected type
+		return nil, ErrUnexpectedMessageType // Return error
+	}
+
+	return message.Payload, nil // Return payload
+}
+
+/* END INTERNAL METHODS */
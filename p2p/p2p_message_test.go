@@ -0,0 +1,94 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	p2pProto "github.com/polaris-project/go-polaris/internal/proto/p2p"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestReadMessage tests the functionality of the WriteMessage() and ReadMessage() helper methods.
+func TestReadMessage(t *testing.T) {
+	buffer := new(bytes.Buffer) // Init stream buffer
+
+	payloads := [][]byte{{'\f', 0x0c, '\f'}, nil, bytes.Repeat([]byte{0x0c}, 300)} // Init payloads (containing the former delimiter)
+
+	for _, payload := range payloads { // Iterate through payloads
+		if err := WriteMessage(buffer, NewMessage(HashListMessage, payload)); err != nil { // Write message
+			t.Fatal(err) // Panic
+		}
+	}
+
+	reader := bufio.NewReader(buffer) // Init reader
+
+	for _, payload := range payloads { // Iterate through payloads
+		message, err := ReadMessage(reader) // Read message
+		if err != nil {                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if message.Type != HashListMessage || message.Version != MessageVersion || !bytes.Equal(message.Payload, payload) { // Check message mismatch
+			t.Fatalf("read message should match written message; got %v", message) // Panic
+		}
+	}
+
+	if err := WriteMessage(buffer, NewMessage(TransactionMessage, make([]byte, MaxMessageSize+1))); err != ErrMessageTooLarge { // Write oversized message
+		t.Fatalf("expected %v, got %v", ErrMessageTooLarge, err) // Panic
+	}
+
+	header := make([]byte, binary.MaxVarintLen64) // Init header buffer
+
+	buffer.Write(header[:binary.PutUvarint(header, MaxMessageSize+1)]) // Write oversized length
+
+	if _, err := ReadMessage(bufio.NewReader(buffer)); err != ErrMessageTooLarge { // Read oversized message
+		t.Fatalf("expected %v, got %v", ErrMessageTooLarge, err) // Panic
+	}
+
+	buffer.Reset() // Reset buffer
+
+	WriteMessage(buffer, &Message{Type: HashMessage, Version: MessageVersion + 1}) // Write message of unknown version
+
+	if _, err := ReadMessage(bufio.NewReader(buffer)); err != ErrUnsupportedMessageVersion { // Read message of unknown version
+		t.Fatalf("expected %v, got %v", ErrUnsupportedMessageVersion, err) // Panic
+	}
+
+	buffer.Reset() // Reset buffer
+
+	WriteMessage(buffer, NewMessage(HashMessage, make([]byte, 32))) // Write hash message
+
+	if _, err := readPayload(bufio.NewReader(buffer), TransactionMessage); err != ErrUnexpectedMessageType { // Read message of unexpected type
+		t.Fatalf("expected %v, got %v", ErrUnexpectedMessageType, err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// TestReadProto tests the functionality of the writeProto() and readProto() helper methods.
+func TestReadProto(t *testing.T) {
+	buffer := new(bytes.Buffer) // Init stream buffer
+
+	hashes := [][]byte{bytes.Repeat([]byte{0x01}, 32), bytes.Repeat([]byte{0x0c}, 32)} // Init hashes
+
+	if err := writeProto(buffer, HashListMessage, &p2pProto.ChildHashesResponse{Hashes: hashes}); err != nil { // Write response
+		t.Fatal(err) // Panic
+	}
+
+	response := &p2pProto.ChildHashesResponse{} // Init response buffer
+
+	if err := readProto(bufio.NewReader(buffer), HashListMessage, response); err != nil { // Read response
+		t.Fatal(err) // Panic
+	}
+
+	if len(response.Hashes) != len(hashes) || !bytes.Equal(response.Hashes[0], hashes[0]) || !bytes.Equal(response.Hashes[1], hashes[1]) { // Check response mismatch
+		t.Fatalf("read response should match written response; got %v", response) // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
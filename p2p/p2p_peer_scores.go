@@ -0,0 +1,225 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/polaris-project/go-polaris/common"
+)
+
+// Peer penalty definitions
+const (
+	// TimeoutPenalty is the penalty for failing to respond to a request in time (or closing a stream without responding).
+	TimeoutPenalty = 5
+
+	// MalformedMessagePenalty is the penalty for sending a message that can't be read or decoded.
+	MalformedMessagePenalty = 20
+
+	// InvalidTransactionPenalty is the penalty for sending a transaction that can never be valid (e.g. with an invalid signature).
+	InvalidTransactionPenalty = 25
+)
+
+const (
+	// MaxPeerScore is the maximum score of a peer (earned by responding to requests usefully and correctly).
+	MaxPeerScore = 100
+
+	// DefaultBanScore is the default score at (or below) which a peer is banned.
+	DefaultBanScore = -100
+
+	// DefaultBanDuration is the default duration of a ban.
+	DefaultBanDuration = 24 * time.Hour
+)
+
+// PeerScores tracks the score of each peer, and the peers banned for misbehaving.
+// Each peer starts with a score of 0, which is lowered by penalties and raised by rewards (up to MaxPeerScore). Once a peer's score
+// drops to BanScore, the peer is banned for BanDuration, and its score is reset. Bans are persisted to the p2p data dir.
+type PeerScores struct {
+	BanScore int `json:"ban_score"` // Score at which a peer is banned
+
+	BanDuration time.Duration `json:"ban_duration"` // Duration of a ban
+
+	scores map[peer.ID]int // Peer scores (peer => score)
+
+	bans map[peer.ID]time.Time // Banned peers (peer => ban expiry)
+
+	lock sync.Mutex // Scores lock
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewPeerScores initializes a new peer score set with no scored or banned peers.
+func NewPeerScores() *PeerScores {
+	return &PeerScores{
+		BanScore:    DefaultBanScore,             // Set ban score
+		BanDuration: DefaultBanDuration,          // Set ban duration
+		scores:      make(map[peer.ID]int),       // Init scores
+		bans:        make(map[peer.ID]time.Time), // Init bans
+	} // Return initialized peer scores
+}
+
+// ReadPeerScoresFromMemory initializes a new peer score set with the bans persisted to the p2p data dir (if any).
+// Expired bans are dropped.
+func ReadPeerScoresFromMemory() (*PeerScores, error) {
+	scores := NewPeerScores() // Initialize peer scores
+
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/bans.json", common.PeerIdentityDir))) // Read bans
+	if os.IsNotExist(err) {                                                                               // Check no persisted bans
+		return scores, nil // Return initialized peer scores
+	} else if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	var bans map[string]time.Time // Init bans buffer
+
+	if err = json.Unmarshal(data, &bans); err != nil { // Decode bans
+		return nil, err // Return found error
+	}
+
+	for encodedID, expiry := range bans { // Iterate through bans
+		peerID, err := peer.IDB58Decode(encodedID) // Decode peer ID
+		if err != nil {                            // Check for errors
+			continue // Continue
+		}
+
+		if time.Now().Before(expiry) { // Check not expired
+			scores.bans[peerID] = expiry // Set ban
+		}
+	}
+
+	return scores, nil // Return read peer scores
+}
+
+// WriteToMemory persists all active bans to the p2p data dir.
+func (scores *PeerScores) WriteToMemory() error {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	return scores.writeBans() // Write bans
+}
+
+// Penalize lowers the score of a given peer by a given penalty, banning the peer if its score drops to BanScore.
+func (scores *PeerScores) Penalize(peerID peer.ID, penalty int) {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	scores.scores[peerID] -= penalty // Lower score
+
+	logger.Infof("penalized peer %s by %d (score: %d)", peerID.Pretty(), penalty, scores.scores[peerID]) // Log penalty
+
+	if scores.scores[peerID] > scores.BanScore { // Check not yet banned
+		return // Return
+	}
+
+	scores.ban(peerID) // Ban peer
+}
+
+// Ban bans a given peer for BanDuration regardless of its score (e.g. for reporting a genesis transaction other than the working dag's
+// genesis transaction).
+func (scores *PeerScores) Ban(peerID peer.ID) {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	scores.ban(peerID) // Ban peer
+}
+
+// Reward raises the score of a given peer by one (up to MaxPeerScore).
+func (scores *PeerScores) Reward(peerID peer.ID) {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	if scores.scores[peerID] < MaxPeerScore { // Check can raise score
+		scores.scores[peerID]++ // Raise score
+	}
+}
+
+// Score gets the score of a given peer.
+func (scores *PeerScores) Score(peerID peer.ID) int {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	return scores.scores[peerID] // Return score
+}
+
+// IsBanned checks whether or not a given peer is currently banned.
+func (scores *PeerScores) IsBanned(peerID peer.ID) bool {
+	scores.lock.Lock() // Lock
+
+	defer scores.lock.Unlock() // Unlock
+
+	expiry, ok := scores.bans[peerID] // Get ban expiry
+
+	if ok && !time.Now().Before(expiry) { // Check ban expired
+		delete(scores.bans, peerID) // Lift ban
+
+		return false // Not banned
+	}
+
+	return ok // Return is banned
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// penalizeReadError penalizes a given peer for a given error encountered while reading a message sent by the peer.
+// Peers that time out (or close their stream without sending a message) receive a TimeoutPenalty; peers that send an unreadable
+// message receive a MalformedMessagePenalty.
+func (scores *PeerScores) penalizeReadError(peerID peer.ID, err error) {
+	if timeoutErr, ok := err.(interface{ Timeout() bool }); (ok && timeoutErr.Timeout()) || err == io.EOF { // Check timed out
+		scores.Penalize(peerID, TimeoutPenalty) // Penalize peer
+
+		return // Return
+	}
+
+	scores.Penalize(peerID, MalformedMessagePenalty) // Penalize peer
+}
+
+// ban bans a given peer for BanDuration, resetting its score, and persists the ban. The scores lock must be held.
+func (scores *PeerScores) ban(peerID peer.ID) {
+	delete(scores.scores, peerID) // Reset score
+
+	scores.bans[peerID] = time.Now().Add(scores.BanDuration) // Ban peer
+
+	logger.Infof("banned peer %s until %s", peerID.Pretty(), scores.bans[peerID].Format(time.RFC3339)) // Log ban
+
+	if err := scores.writeBans(); err != nil { // Persist bans
+		logger.Errorf("failed to persist peer bans: %s", err.Error()) // Log found error
+	}
+}
+
+// writeBans persists all active bans to the p2p data dir.
+func (scores *PeerScores) writeBans() error {
+	bans := make(map[string]time.Time) // Init bans buffer
+
+	for peerID, expiry := range scores.bans { // Iterate through bans
+		if time.Now().Before(expiry) { // Check not expired
+			bans[peerID.Pretty()] = expiry // Set ban
+		}
+	}
+
+	data, err := json.MarshalIndent(bans, "", "  ") // Encode bans
+	if err != nil {                                 // Check for errors
+		return err // Return found error
+	}
+
+	if err = common.CreateDirIfDoesNotExist(common.PeerIdentityDir); err != nil { // Create p2p dir if it doesn't already exist
+		return err // Return found error
+	}
+
+	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/bans.json", common.PeerIdentityDir)), data, 0o644) // Write bans
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,154 @@
+// Package p2p provides common peer-to-peer communications helper methods and definitions.
+package p2p
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	crypto "github.com/libp2p/go-libp2p-crypto"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/polaris-project/go-polaris/common"
+	polarisCrypto "github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestPenalize tests the functionality of the Penalize() helper method.
+func TestPenalize(t *testing.T) {
+	peerIdentityDir, err := ioutil.TempDir("", "polaris_test") // Create temporary p2p dir
+	if err != nil {                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(peerIdentityDir) // Remove temporary p2p dir
+
+	defer func(dir string) { common.PeerIdentityDir = dir }(common.PeerIdentityDir) // Restore p2p dir
+
+	common.PeerIdentityDir = peerIdentityDir // Set p2p dir
+
+	scores := NewPeerScores() // Initialize peer scores
+
+	peerID := newTestPeerID(t) // Initialize peer ID
+
+	scores.Reward(peerID) // Reward peer
+
+	if scores.Score(peerID) != 1 { // Check score not raised
+		t.Fatalf("rewarded peer should have a score of 1; got %d", scores.Score(peerID)) // Panic
+	}
+
+	scores.Penalize(peerID, MalformedMessagePenalty) // Penalize peer
+
+	if scores.IsBanned(peerID) || scores.Score(peerID) != 1-MalformedMessagePenalty { // Check banned too early
+		t.Fatal("peer above ban score should not be banned") // Panic
+	}
+
+	scores.Penalize(peerID, 1-MalformedMessagePenalty-DefaultBanScore) // Penalize peer down to ban score
+
+	if !scores.IsBanned(peerID) { // Check not banned
+		t.Fatal("peer at ban score should be banned") // Panic
+	}
+
+	readScores, err := ReadPeerScoresFromMemory() // Read persisted bans
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if !readScores.IsBanned(peerID) { // Check ban not persisted
+		t.Fatal("ban should be persisted") // Panic
+	}
+
+	readScores.bans[peerID] = readScores.bans[peerID].Add(-2 * DefaultBanDuration) // Expire ban
+
+	if readScores.IsBanned(peerID) { // Check still banned
+		t.Fatal("expired ban should be lifted") // Panic
+	}
+}
+
+// TestBan tests the functionality of the Ban() helper method.
+func TestBan(t *testing.T) {
+	peerIdentityDir, err := ioutil.TempDir("", "polaris_test") // Create temporary p2p dir
+	if err != nil {                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(peerIdentityDir) // Remove temporary p2p dir
+
+	defer func(dir string) { common.PeerIdentityDir = dir }(common.PeerIdentityDir) // Restore p2p dir
+
+	common.PeerIdentityDir = peerIdentityDir // Set p2p dir
+
+	scores := NewPeerScores() // Initialize peer scores
+
+	peerID := newTestPeerID(t) // Initialize peer ID
+
+	for i := 0; i < MaxPeerScore; i++ { // Reward peer up to max score
+		scores.Reward(peerID) // Reward peer
+	}
+
+	scores.Ban(peerID) // Ban peer
+
+	if !scores.IsBanned(peerID) || scores.Score(peerID) != 0 { // Check not banned
+		t.Fatal("peer with max score should be banned, and have its score reset") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// TestMostVotedHash tests the functionality of the mostVotedHash() helper method.
+func TestMostVotedHash(t *testing.T) {
+	client := &Client{PeerScores: NewPeerScores()} // Initialize client
+
+	honest, dishonest, undecided := newTestPeerID(t), newTestPeerID(t), newTestPeerID(t) // Initialize peer IDs
+
+	honestHash, dishonestHash := polarisCrypto.Sha3([]byte("honest")), polarisCrypto.Sha3([]byte("dishonest")) // Get hashes
+
+	client.PeerScores.Reward(honest) // Reward honest peer
+
+	for i := 0; i < 8; i++ { // Vote repeatedly (map iteration order is random)
+		bestHash, ok := client.mostVotedHash(map[peer.ID]common.Hash{dishonest: dishonestHash, honest: honestHash, undecided: {}}) // Get best hash
+
+		if !ok || bestHash != honestHash { // Check tie not broken by score
+			t.Fatalf("tied vote should favor the highest scoring peer; got %x", bestHash.Bytes()) // Panic
+		}
+	}
+
+	if _, ok := client.mostVotedHash(map[peer.ID]common.Hash{undecided: {}}); ok { // Get best hash of nil votes
+		t.Fatal("nil votes should not elect a hash") // Panic
+	}
+}
+
+// TestRewardVoters tests the functionality of the rewardVoters() helper method.
+func TestRewardVoters(t *testing.T) {
+	client := &Client{PeerScores: NewPeerScores()} // Initialize client
+
+	agreeing, disagreeing := newTestPeerID(t), newTestPeerID(t) // Initialize peer IDs
+
+	bestHash, otherHash := polarisCrypto.Sha3([]byte("best")), polarisCrypto.Sha3([]byte("other")) // Get hashes
+
+	client.rewardVoters(map[peer.ID]common.Hash{agreeing: bestHash, disagreeing: otherHash}, bestHash) // Reward voters
+
+	if client.PeerScores.Score(agreeing) != 1 || client.PeerScores.Score(disagreeing) != 0 { // Check unexpected scores
+		t.Fatalf("only peers voting for the elected hash should be rewarded; got scores %d and %d", client.PeerScores.Score(agreeing), client.PeerScores.Score(disagreeing)) // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
+
+// newTestPeerID initializes a new, random peer ID.
+func newTestPeerID(t *testing.T) peer.ID {
+	_, publicKey, err := crypto.GenerateEd25519Key(rand.Reader) // Generate key pair
+	if err != nil {                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	peerID, err := peer.IDFromPublicKey(publicKey) // Get peer ID
+	if err != nil {                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	return peerID // Return peer ID
+}
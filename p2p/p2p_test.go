@@ -6,12 +6,18 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"testing"
+	"time"
 
 	protocol "github.com/libp2p/go-libp2p-protocol"
+	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
 	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 	"github.com/polaris-project/go-polaris/types"
 	"github.com/polaris-project/go-polaris/validator"
 )
@@ -55,29 +61,6 @@ func TestBootstrapDht(t *testing.T) {
 	}
 }
 
-// TestBroadcastDht tests the functionality of the BroadcastDht() helper method.
-func TestBroadcastDht(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background()) // Get context
-
-	defer cancel() // Cancel
-
-	BootstrapNodes = []string{
-		"/ip4/104.131.131.82/tcp/4001/ipfs/QmaCpDMGvV2BGHeYERUEnRQAwe3N8SzbUtfsmvsqQLuvuJ",
-		"/ip4/104.236.179.241/tcp/4001/ipfs/QmSoLPppuBtQSGwKDZT2M73ULpjvfd3aZ6ha4oFGL1KrGM",
-	} // Set bootstrap nodes
-
-	host, err := NewHost(ctx, 2831) // Initialize libp2p host with context and nat manager
-	if err != nil {                 // Check for errors
-		t.Fatal(err) // Panic
-	}
-
-	err = BroadcastDht(ctx, host, []byte("test"), "/test/1.0.0", "test_network") // Broadcast
-
-	if err != nil { // Check for errors
-		t.Fatal(err) // Panic
-	}
-}
-
 // TestPublish tests the functionality of the Publish() helper method.
 func TestPublish(t *testing.T) {
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
@@ -89,13 +72,15 @@ func TestPublish(t *testing.T) {
 
 	transaction := types.NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(0),        // Amount
+		big.NewInt(0),          // Amount
 		address,                // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		0,                      // Gas limit
 		big.NewInt(0),          // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Initialize a new transaction
 
 	err = types.SignTransaction(transaction, privateKey) // Sign transaction
@@ -114,13 +99,22 @@ func TestPublish(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                                 // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
 
 	client := NewClient("test_network", &validator) // Initialize client
@@ -136,6 +130,71 @@ func TestPublish(t *testing.T) {
 	}
 }
 
+// TestPublishFutureNonce tests that the Publish() helper method publishes transactions held by the mempool with a future nonce.
+func TestPublishFutureNonce(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := crypto.AddressFromPrivateKey(privateKey) // Generate address
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(address.Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction := types.NewTransaction(
+		1,                                  // Nonce
+		big.NewInt(10),                     // Amount
+		address,                            // Sender
+		common.NewAddress([]byte{byte(1)}), // Recipient
+		[]common.Hash{genesisTransactions[len(genesisTransactions)-1].Hash}, // Parents
+		1,                      // Gas limit
+		big.NewInt(1),          // Gas price
+		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
+	) // Initialize transaction with a future nonce
+
+	if err = types.SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	ctx, cancel := context.WithCancel(context.Background()) // Get context
+
+	defer cancel() // Cancel
+
+	if _, err = NewHost(ctx, 3862); err != nil { // Initialize host
+		t.Fatal(err) // Panic
+	}
+
+	validator := validator.Validator(validator.NewBeaconDagValidator(dagConfig, dag)) // Initialize validator
+
+	client := NewClient("test_network", &validator) // Initialize client
+
+	if err = client.PublishTransaction(context.Background(), transaction); err != nil { // Publish transaction
+		t.Fatalf("transaction with a future nonce should be published; got %v", err) // Panic
+	}
+
+	if _, err = client.Mempool.GetTransaction(transaction.Hash); err != nil { // Check not pending
+		t.Fatal("transaction with a future nonce should be held by the mempool") // Panic
+	}
+
+	if client.seen.markSeen(transaction.Hash) { // Check not gossiped
+		t.Fatal("published transaction should be marked seen") // Panic
+	}
+}
+
 // TestGetStreamHeaderProtocolPath tests the functionality of the GetStreamHeaderProtocol() helper method.
 func TestGetStreamHeaderProtocolPath(t *testing.T) {
 	streamHeaderProtocolPath := GetStreamHeaderProtocolPath("test_network", PublishTransaction) // Get stream header protocol URI
@@ -143,4 +202,17 @@ func TestGetStreamHeaderProtocolPath(t *testing.T) {
 	t.Log(protocol.ID(streamHeaderProtocolPath)) // Get libp2p representation
 }
 
+// TestGetStreamHeaderProtocolIDs tests the functionality of the GetStreamHeaderProtocolIDs() helper method.
+func TestGetStreamHeaderProtocolIDs(t *testing.T) {
+	defer func(versions []string) { ProtocolVersions = versions }(ProtocolVersions) // Restore versions
+
+	ProtocolVersions = []string{"2.0.0", "1.0.0"} // Set versions
+
+	protocolIDs := GetStreamHeaderProtocolIDs(GetStreamHeaderProtocolPath("test_network", PublishTransaction)) // Get protocol IDs
+
+	if len(protocolIDs) != 2 || protocolIDs[0] != protocol.ID("/test_network/pub_transaction/2.0.0") || protocolIDs[1] != protocol.ID("/test_network/pub_transaction/1.0.0") { // Check invalid protocol IDs
+		t.Fatalf("protocol IDs should list each version, newest first; got %v", protocolIDs) // Panic
+	}
+}
+
 /* END EXPORTED METHODS TESTS */
@@ -0,0 +1,127 @@
+// Package storage defines the key-value storage interface used to persist the dag, as well
+// as its boltdb (default) and in-memory implementations.
+package storage
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// BoltStorage is a storage implementation backed by a boltdb file.
+type BoltStorage struct {
+	db *bolt.DB // Bolt db
+}
+
+// boltBatch wraps a bolt tx as a storage batch.
+type boltBatch struct {
+	tx *bolt.Tx // Bolt tx
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewBoltStorage opens (or creates) the bolt db at a given path.
+func NewBoltStorage(path string) (*BoltStorage, error) {
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: 5 * time.Second}) // Open DB with timeout
+	if err != nil {                                                            // Check for errors
+		return &BoltStorage{}, err // Return found error
+	}
+
+	return &BoltStorage{
+		db: db, // Set db
+	}, nil // Return initialized storage
+}
+
+// View runs a given function in a read-only bolt tx.
+func (storage *BoltStorage) View(fn func(reader Reader) error) error {
+	if storage.db == nil { // Check not opened
+		return ErrStorageClosed // Return error
+	}
+
+	return storage.db.View(func(tx *bolt.Tx) error {
+		return fn(&boltBatch{tx: tx}) // Run fn
+	})
+}
+
+// Batch runs a given function in a read-write bolt tx.
+func (storage *BoltStorage) Batch(fn func(batch Batch) error) error {
+	if storage.db == nil { // Check not opened
+		return ErrStorageClosed // Return error
+	}
+
+	return storage.db.Update(func(tx *bolt.Tx) error {
+		return fn(&boltBatch{tx: tx}) // Run fn
+	})
+}
+
+// Close closes the underlying bolt db.
+func (storage *BoltStorage) Close() error {
+	if storage.db == nil { // Check not opened
+		return ErrStorageClosed // Return error
+	}
+
+	return storage.db.Close() // Close db
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// Get gets the value at a given key in a given bucket.
+func (batch *boltBatch) Get(bucket []byte, key []byte) []byte {
+	if boltBucket := batch.tx.Bucket(bucket); boltBucket != nil { // Check bucket exists
+		return boltBucket.Get(key) // Return value
+	}
+
+	return nil // No value
+}
+
+// Iterate calls a given function for each key with a given prefix in a given bucket.
+func (batch *boltBatch) Iterate(bucket []byte, prefix []byte, fn func(key []byte, value []byte) error) error {
+	boltBucket := batch.tx.Bucket(bucket) // Get bucket
+
+	if boltBucket == nil { // Check bucket doesn't exist
+		return nil // Nothing to iterate
+	}
+
+	c := boltBucket.Cursor() // Get cursor
+
+	for key, value := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, value = c.Next() { // Iterate through keys with prefix
+		if err := fn(key, value); err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// Put sets the value at a given key in a given bucket.
+func (batch *boltBatch) Put(bucket []byte, key []byte, value []byte) error {
+	boltBucket, err := batch.tx.CreateBucketIfNotExists(bucket) // Create bucket if it doesn't already exist
+	if err != nil {                                             // Check for errors
+		return err // Return found error
+	}
+
+	return boltBucket.Put(key, value) // Put value
+}
+
+// Delete removes a given key from a given bucket.
+func (batch *boltBatch) Delete(bucket []byte, key []byte) error {
+	if boltBucket := batch.tx.Bucket(bucket); boltBucket != nil { // Check bucket exists
+		return boltBucket.Delete(key) // Delete key
+	}
+
+	return nil // Nothing to delete
+}
+
+// DeleteBucket removes a given bucket.
+func (batch *boltBatch) DeleteBucket(bucket []byte) error {
+	if batch.tx.Bucket(bucket) == nil { // Check bucket doesn't exist
+		return nil // Nothing to delete
+	}
+
+	return batch.tx.DeleteBucket(bucket) // Delete bucket
+}
+
+/* END INTERNAL METHODS */
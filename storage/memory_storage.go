@@ -0,0 +1,185 @@
+// Package storage defines the key-value storage interface used to persist the dag, as well
+// as its boltdb (default) and in-memory implementations.
+package storage
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStorage is an ephemeral storage implementation held entirely in memory.
+// Its contents are lost once the process exits.
+type MemoryStorage struct {
+	buckets map[string]map[string][]byte // Bucket name => key => value
+
+	closed bool // Whether or not the storage has been closed
+
+	lock sync.RWMutex // Buckets lock
+}
+
+// memoryBatch is a storage batch applied directly to a memory storage instance, recording an undo log
+// that is replayed if the batch fails.
+type memoryBatch struct {
+	storage *MemoryStorage // Working storage
+
+	undo []func() // Functions reverting each write made in the batch
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewMemoryStorage initializes a new, empty memory storage instance.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		buckets: make(map[string]map[string][]byte), // Init buckets
+	} // Return initialized storage
+}
+
+// View runs a given function in a read-only view of the storage.
+func (storage *MemoryStorage) View(fn func(reader Reader) error) error {
+	storage.lock.RLock() // Lock for reading
+
+	defer storage.lock.RUnlock() // Unlock
+
+	if storage.closed { // Check closed
+		return ErrStorageClosed // Return error
+	}
+
+	return fn(&memoryBatch{storage: storage}) // Run fn
+}
+
+// Batch runs a given function in a batch, reverting its writes if it returns an error.
+func (storage *MemoryStorage) Batch(fn func(batch Batch) error) error {
+	storage.lock.Lock() // Lock for writing
+
+	defer storage.lock.Unlock() // Unlock
+
+	if storage.closed { // Check closed
+		return ErrStorageClosed // Return error
+	}
+
+	batch := &memoryBatch{storage: storage} // Init batch
+
+	err := fn(batch) // Run fn
+
+	if err != nil { // Check for errors
+		for i := len(batch.undo) - 1; i >= 0; i-- { // Iterate through writes, latest first
+			batch.undo[i]() // Revert write
+		}
+	}
+
+	return err // Return error
+}
+
+// Close closes the storage, discarding its contents.
+func (storage *MemoryStorage) Close() error {
+	storage.lock.Lock() // Lock for writing
+
+	defer storage.lock.Unlock() // Unlock
+
+	if storage.closed { // Check already closed
+		return ErrStorageClosed // Return error
+	}
+
+	storage.closed = true // Set closed
+
+	storage.buckets = nil // Discard contents
+
+	return nil // No error occurred, return nil
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// Get gets the value at a given key in a given bucket.
+func (batch *memoryBatch) Get(bucket []byte, key []byte) []byte {
+	return batch.storage.buckets[string(bucket)][string(key)] // Return value
+}
+
+// Iterate calls a given function for each key with a given prefix in a given bucket.
+func (batch *memoryBatch) Iterate(bucket []byte, prefix []byte, fn func(key []byte, value []byte) error) error {
+	var keys []string // Init key buffer
+
+	for key := range batch.storage.buckets[string(bucket)] { // Iterate through keys
+		if strings.HasPrefix(key, string(prefix)) { // Check has prefix
+			keys = append(keys, key) // Append key
+		}
+	}
+
+	sort.Strings(keys) // Sort keys, matching bolt cursor order
+
+	for _, key := range keys { // Iterate through sorted keys
+		value, ok := batch.storage.buckets[string(bucket)][key] // Get value
+
+		if !ok { // Check deleted during iteration
+			continue // Continue
+		}
+
+		if err := fn([]byte(key), value); err != nil { // Check for errors
+			return err // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// Put sets the value at a given key in a given bucket.
+func (batch *memoryBatch) Put(bucket []byte, key []byte, value []byte) error {
+	bucketName, keyName := string(bucket), string(key) // Get map keys
+
+	if batch.storage.buckets[bucketName] == nil { // Check bucket doesn't exist
+		batch.storage.buckets[bucketName] = make(map[string][]byte) // Create bucket
+
+		batch.undo = append(batch.undo, func() { delete(batch.storage.buckets, bucketName) }) // Revert bucket creation
+	}
+
+	batch.recordKey(bucketName, keyName) // Record previous value
+
+	batch.storage.buckets[bucketName][keyName] = append([]byte{}, value...) // Put copy of value
+
+	return nil // No error occurred, return nil
+}
+
+// Delete removes a given key from a given bucket.
+func (batch *memoryBatch) Delete(bucket []byte, key []byte) error {
+	bucketName, keyName := string(bucket), string(key) // Get map keys
+
+	if batch.storage.buckets[bucketName] == nil { // Check bucket doesn't exist
+		return nil // Nothing to delete
+	}
+
+	batch.recordKey(bucketName, keyName) // Record previous value
+
+	delete(batch.storage.buckets[bucketName], keyName) // Delete key
+
+	return nil // No error occurred, return nil
+}
+
+// DeleteBucket removes a given bucket.
+func (batch *memoryBatch) DeleteBucket(bucket []byte) error {
+	bucketName := string(bucket) // Get map key
+
+	if previous, ok := batch.storage.buckets[bucketName]; ok { // Check bucket exists
+		batch.undo = append(batch.undo, func() { batch.storage.buckets[bucketName] = previous }) // Revert bucket deletion
+
+		delete(batch.storage.buckets, bucketName) // Delete bucket
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// recordKey appends a function restoring the current value of a given key to the batch undo log.
+func (batch *memoryBatch) recordKey(bucketName string, keyName string) {
+	previous, existed := batch.storage.buckets[bucketName][keyName] // Get previous value
+
+	batch.undo = append(batch.undo, func() {
+		if existed { // Check key existed
+			batch.storage.buckets[bucketName][keyName] = previous // Restore value
+		} else {
+			delete(batch.storage.buckets[bucketName], keyName) // Remove key
+		}
+	}) // Revert write
+}
+
+/* END INTERNAL METHODS */
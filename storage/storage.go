@@ -0,0 +1,36 @@
+// Package storage defines the key-value storage interface used to persist the dag, as well
+// as its boltdb (default) and in-memory implementations.
+package storage
+
+import "errors"
+
+// ErrStorageClosed is an error definition representing an operation on a closed storage instance.
+var ErrStorageClosed = errors.New("storage has been closed")
+
+// Reader is a read-only view of a storage instance.
+// Byte slices returned by a Reader are only valid for the life of the view or batch they were read in.
+type Reader interface {
+	Get(bucket []byte, key []byte) []byte // Get the value at a given key in a given bucket (nil if no value exists)
+
+	Iterate(bucket []byte, prefix []byte, fn func(key []byte, value []byte) error) error // Call fn for each key with the given prefix in the given bucket, in ascending key order
+}
+
+// Batch is a set of reads and writes applied atomically to a storage instance.
+type Batch interface {
+	Reader // Batches can read their own writes
+
+	Put(bucket []byte, key []byte, value []byte) error // Set the value at a given key in a given bucket, creating the bucket if necessary
+
+	Delete(bucket []byte, key []byte) error // Remove a given key from a given bucket
+
+	DeleteBucket(bucket []byte) error // Remove a given bucket, and all of its keys
+}
+
+// Storage is a key-value store made up of named buckets.
+type Storage interface {
+	View(fn func(reader Reader) error) error // Run fn in a consistent, read-only view of the storage
+
+	Batch(fn func(batch Batch) error) error // Run fn in a batch, committing its writes if (and only if) fn returns nil
+
+	Close() error // Close the storage
+}
@@ -0,0 +1,130 @@
+// Package storage defines the key-value storage interface used to persist the dag, as well
+// as its boltdb (default) and in-memory implementations.
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestBoltStorage tests the functionality of the BoltStorage storage implementation.
+func TestBoltStorage(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dir, err := ioutil.TempDir("", "polaris_test") // Create temporary dir
+	if err != nil {                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dir) // Remove temporary dir
+
+	storage, err := NewBoltStorage(filepath.Join(dir, "test.db")) // Open bolt storage
+	if err != nil {                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	testStorage(t, storage) // Test storage
+}
+
+// TestMemoryStorage tests the functionality of the MemoryStorage storage implementation.
+func TestMemoryStorage(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	testStorage(t, NewMemoryStorage()) // Test storage
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// testStorage tests the behavior shared by all storage implementations against a given storage instance.
+func testStorage(t *testing.T, storage Storage) {
+	bucket := []byte("test-bucket") // Init bucket name
+
+	err := storage.Batch(func(batch Batch) error {
+		for _, key := range []string{"b2", "a1", "b1", "c1"} { // Iterate through keys
+			if err := batch.Put(bucket, []byte(key), []byte("value_"+key)); err != nil { // Put value
+				return err // Return found error
+			}
+		}
+
+		if value := batch.Get(bucket, []byte("a1")); !bytes.Equal(value, []byte("value_a1")) { // Check batch can't read its own writes
+			return errors.New("batch should be able to read its own writes") // Return error
+		}
+
+		return nil // No error occurred, return nil
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	var keys []string // Init key buffer
+
+	err = storage.View(func(reader Reader) error {
+		if reader.Get([]byte("missing-bucket"), []byte("a1")) != nil { // Check missing bucket has value
+			t.Error("missing bucket should not have any values") // Log error
+		}
+
+		return reader.Iterate(bucket, []byte("b"), func(key []byte, value []byte) error {
+			keys = append(keys, string(key)) // Append key
+
+			return nil // No error occurred, return nil
+		}) // Iterate through keys with prefix
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(keys) != 2 || keys[0] != "b1" || keys[1] != "b2" { // Check invalid iteration
+		t.Fatalf("should have iterated over [b1 b2] in order; got %v", keys) // Panic
+	}
+
+	errRollback := errors.New("rollback") // Init rollback error
+
+	err = storage.Batch(func(batch Batch) error {
+		batch.Put(bucket, []byte("a1"), []byte("overwritten")) // Overwrite value
+		batch.Delete(bucket, []byte("b1"))                     // Delete value
+		batch.Put([]byte("new-bucket"), []byte("a1"), nil)     // Create bucket
+		batch.DeleteBucket(bucket)                             // Delete bucket
+
+		return errRollback // Fail batch
+	})
+
+	if err != errRollback { // Check batch error not returned
+		t.Fatalf("batch should have returned its error; got %v", err) // Panic
+	}
+
+	err = storage.View(func(reader Reader) error {
+		if value := reader.Get(bucket, []byte("a1")); !bytes.Equal(value, []byte("value_a1")) { // Check overwrite not reverted
+			t.Errorf("failed batch should not have overwritten value; got %s", value) // Log error
+		}
+
+		if reader.Get(bucket, []byte("b1")) == nil { // Check delete not reverted
+			t.Error("failed batch should not have deleted value") // Log error
+		}
+
+		if reader.Get([]byte("new-bucket"), []byte("a1")) != nil { // Check bucket creation not reverted
+			t.Error("failed batch should not have created bucket") // Log error
+		}
+
+		return nil // No error occurred, return nil
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = storage.Close(); err != nil { // Close storage
+		t.Fatal(err) // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
@@ -3,10 +3,6 @@
 package types
 
 import (
-	"bytes"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -16,18 +12,15 @@ import (
 
 	"github.com/juju/loggo"
 
-	"github.com/boltdb/bolt"
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
 	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 )
 
 var transactionBucket = []byte("transaction-bucket")
 
 var (
-	// WorkingDagDB represents the current opened dag database.
-	WorkingDagDB *bolt.DB
-
 	// ErrDagAlreadyExists represents an error describing
 	// the attempted overwriting of an existing DAG.
 	ErrDagAlreadyExists = errors.New("dag already exists")
@@ -66,21 +59,29 @@ type Dag struct {
 	Genesis common.Hash `json:"genesis"` // Dag genesis
 
 	LastTransaction common.Hash `json:"last_tx"` // Last transaction hash
+
+	db storage.Storage // Dag db
+
+	dbDir string // Directory containing the dag header (empty if the header is not persisted)
 }
 
 /* BEGIN EXPORTED METHODS */
 
-// NewDag creates a new dag with the given config, and writes the dag db to memory.
-// The newly opened dag db is stored in the WorkingDagDB variable.
+// NewDag creates a new dag with the given config, and writes the dag config and dag db to memory.
+// The dag db is opened in the common db directory, and is owned by the returned dag.
 func NewDag(config *config.DagConfig) (*Dag, error) {
-	logger.Infof("initializing dag instance") // Log init dag
-
 	err := config.WriteToMemory() // Write dag config to persistent memory
 	if err != nil {               // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
-	err = common.CreateDirIfDoesNotExist(common.DbDir) // Make database directory
+	return NewDagInDir(config, common.DbDir) // Create dag in db dir
+}
+
+// NewDagInDir creates a new dag with the given config, and writes the dag db (via bolt) and its header to the given directory.
+// Unlike NewDag, the dag config is not written to persistent memory.
+func NewDagInDir(config *config.DagConfig, dbDir string) (*Dag, error) {
+	err := common.CreateDirIfDoesNotExist(dbDir) // Make database directory
 
 	if err != nil { // Check for errors
 		return &Dag{}, err // Return found error
@@ -88,22 +89,39 @@ func NewDag(config *config.DagConfig) (*Dag, error) {
 
 	logger.Infof("opening dag db") // Log open db
 
-	dagDB, err := bolt.Open(filepath.FromSlash(fmt.Sprintf("%s/%s.db", common.DbDir, config.Identifier)), 0o644, &bolt.Options{Timeout: 5 * time.Second}) // Open DB with timeout
-	if err != nil {                                                                                                                                       // Check for errors
+	db, err := storage.NewBoltStorage(filepath.FromSlash(fmt.Sprintf("%s/%s.db", dbDir, config.Identifier))) // Open dag db
+	if err != nil {                                                                                          // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
-	WorkingDagDB = dagDB // Set dag DB
+	dag, err := NewDagWithStorage(config, db, dbDir) // Initialize dag
+	if err != nil {                                  // Check for errors
+		db.Close() // Close dag db
+
+		return &Dag{}, err // Return found error
+	}
+
+	return dag, nil // Return initialized dag
+}
+
+// NewDagWithStorage creates a new dag with the given config on top of a given storage backend, which is owned by the returned dag.
+// The dag header is written to the given directory; if dbDir is empty, the header is only kept in memory (i.e. for ephemeral dags).
+func NewDagWithStorage(config *config.DagConfig, db storage.Storage, dbDir string) (*Dag, error) {
+	logger.Infof("initializing dag instance") // Log init dag
 
-	err = createTransactionBucketIfNotExist() // Create transaction bucket if it doesn't already exist
+	err := migrateIndexes(db) // Build indexes if necessary
 
 	if err != nil { // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
-	logger.Infof("attempting to open dag db header") // Log open dag db header
+	var dagHeader *Dag // Init header buffer
 
-	dagHeader, err := readDagDbHeaderFromMemory(config.Identifier) // Read dag db
+	if dbDir != "" { // Check header persisted
+		logger.Infof("attempting to open dag db header") // Log open dag db header
+
+		dagHeader, err = readDagDbHeaderFromMemory(dbDir, config.Identifier) // Read dag db
+	}
 
 	if err != nil || dagHeader == nil { // Check no existing dag
 		logger.Infof("could not load local dag db header; initializing one instead") // Log initialize
@@ -112,6 +130,8 @@ func NewDag(config *config.DagConfig) (*Dag, error) {
 			DagConfig: config, // Set config
 		} // Initialize dag db header
 
+		dagHeader.dbDir = dbDir // Set db dir
+
 		logger.Infof("initialized dag db header, writing to memory") // Log write
 
 		err = dagHeader.WriteToMemory() // Write dag db header to persistent memory
@@ -121,20 +141,22 @@ func NewDag(config *config.DagConfig) (*Dag, error) {
 		}
 	}
 
+	dagHeader.db = db // Set dag db
+
 	logger.Infof("finished setting up dag") // Log setup dag
 
 	return dagHeader, nil // Return initialized dag
 }
 
-// Close closes the working dag.
+// Close closes the dag's db.
 func (dag *Dag) Close() error {
 	logger.Infof("closing dag db") // Log close
 
-	if WorkingDagDB == nil { // Check no working dag db
+	if dag == nil || dag.db == nil { // Check no dag db
 		return ErrDagDbNotOpened // Return error
 	}
 
-	return WorkingDagDB.Close() // Close
+	return dag.db.Close() // Close
 }
 
 // MakeGenesis makes the dag's genesis transaction set.
@@ -146,26 +168,31 @@ func (dag *Dag) MakeGenesis() ([]*Transaction, error) {
 		return nil, ErrDuplicateTransaction // Return found error
 	}
 
-	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate gensis address
-	if err != nil {                                                    // Check for errors
+	privateKey, err := crypto.GenerateKey(dag.DagConfig.AcceptedSignatureSchemes()[0]) // Generate gensis key with an accepted signature scheme
+	if err != nil {                                                                    // Check for errors
+		return nil, err // Return found error
+	}
+
+	genesisAddress, err := crypto.AddressFromSigner(privateKey) // Derive genesis address
+	if err != nil {                                             // Check for errors
 		return nil, err // Return found error
 	}
 
 	logger.Infof("created genesis private key") // Log init private key
 
-	totalGenesisValue := 0.0 // Init total value buffer
+	totalGenesisValue := big.NewInt(0) // Init total value buffer
 
 	for _, value := range dag.DagConfig.Alloc { // Iterate through alloc
-		totalGenesisValue += value // Increment value
+		totalGenesisValue.Add(totalGenesisValue, value) // Increment value
 	}
 
 	genesisTransactions := []*Transaction{} // Initialize genesis transactions
 
 	logger.Infof("creating genesis transaction") // Log init genesis
 
-	genesisTransaction := NewTransaction(0, big.NewFloat(totalGenesisValue), nil, crypto.AddressFromPrivateKey(privateKey), nil, 0, big.NewInt(0), []byte("genesis")) // Initialize genesis transaction
+	genesisTransaction := NewTransaction(0, totalGenesisValue, nil, genesisAddress, nil, 0, big.NewInt(0), []byte("genesis"), dag.DagConfig.Network, time.Time{}) // Initialize genesis transaction
 
-	err = dag.forceAddTransaction(genesisTransaction) // Add genesis transaction
+	err = dag.AddGenesisTransaction(genesisTransaction) // Add genesis transaction
 
 	if err != nil { // Check for errors
 		return nil, err // Return found error
@@ -173,14 +200,6 @@ func (dag *Dag) MakeGenesis() ([]*Transaction, error) {
 
 	logger.Infof("added genesis transaction to dag") // Log add genesis
 
-	(*dag).Genesis = genesisTransaction.Hash // Set genesis
-
-	err = (*dag).WriteToMemory() // Write dag header to persistent memory
-
-	if err != nil { // Check for errors
-		return nil, err // Return found error
-	}
-
 	genesisTransactions = append(genesisTransactions, genesisTransaction) // Append genesis
 
 	lastParent := genesisTransaction // Set last parent
@@ -197,7 +216,7 @@ func (dag *Dag) MakeGenesis() ([]*Transaction, error) {
 
 		decodedAddress := common.NewAddress(decodedKey) // Decode address
 
-		transaction := NewTransaction(x, big.NewFloat(value), crypto.AddressFromPrivateKey(privateKey), decodedAddress, []common.Hash{lastParent.Hash}, 0, big.NewInt(0), []byte("genesis_child")) // Initialize new genesis child transaction
+		transaction := NewTransaction(x, new(big.Int).Set(value), genesisAddress, decodedAddress, []common.Hash{lastParent.Hash}, 0, big.NewInt(0), []byte("genesis_child"), dag.DagConfig.Network, time.Time{}) // Initialize new genesis child transaction
 
 		err = SignTransaction(transaction, privateKey) // Sign transaction
 
@@ -223,38 +242,65 @@ func (dag *Dag) MakeGenesis() ([]*Transaction, error) {
 	return genesisTransactions, nil // No error occurred, return nil
 }
 
-// OpenDag attempts to open all dag-related resources.
+// AddGenesisTransaction adds a given (unsigned) genesis transaction to the working dag, and sets the dag genesis to its hash.
+// Returns an ErrDuplicateTransaction error if the transaction already exists in the dag db.
+func (dag *Dag) AddGenesisTransaction(transaction *Transaction) error {
+	err := dag.forceAddTransaction(transaction) // Add genesis transaction
+	if err != nil {                             // Check for errors
+		return err // Return found error
+	}
+
+	(*dag).Genesis = transaction.Hash // Set genesis
+
+	return (*dag).WriteToMemory() // Write dag header to persistent memory
+}
+
+// OpenDag attempts to open all dag-related resources in the common db directory.
 func OpenDag(identifier string) (*Dag, error) {
+	return OpenDagInDir(identifier, common.DbDir) // Open dag in db dir
+}
+
+// OpenDagInDir attempts to open all resources of the dag with the given identifier in the given directory.
+func OpenDagInDir(identifier string, dbDir string) (*Dag, error) {
 	logger.Infof("opening dag db header with identifier: %s", identifier) // Log open dag
 
-	dagDbHeader, err := readDagDbHeaderFromMemory(identifier) // Read dag db header
-	if err != nil {                                           // Check for errors
+	dagDbHeader, err := readDagDbHeaderFromMemory(dbDir, identifier) // Read dag db header
+	if err != nil {                                                  // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
 	logger.Infof("finished opening dag db header with identifier: %s", identifier) // Log opened dag
 
-	WorkingDagDB, err = bolt.Open(filepath.FromSlash(fmt.Sprintf("%s/%s.db", common.DbDir, identifier)), 0o644, &bolt.Options{Timeout: 5 * time.Second}) // Open DB with timeout
+	db, err := storage.NewBoltStorage(filepath.FromSlash(fmt.Sprintf("%s/%s.db", dbDir, identifier))) // Open dag db
+	if err != nil {                                                                                   // Check for errors
+		return &Dag{}, err // Return found error
+	}
+
+	err = migrateIndexes(db) // Build indexes if necessary
 
 	if err != nil { // Check for errors
+		db.Close() // Close dag db
+
 		return &Dag{}, err // Return found error
 	}
 
+	dagDbHeader.db = db // Set dag db
+
 	logger.Infof("opened dag db with identifier: %s", identifier) // Log opened dag db
 
 	return dagDbHeader, nil // Return dag db header
 }
 
 // AddTransaction appends a given transaction to the working dag.
-// Returns an ErrDagDbNotOpened error if the working dag db is nil (has been not opened).
+// Returns an ErrDagDbNotOpened error if the dag db is nil (has been not opened).
 // Return an ErrNilTransaction error if the given transaction pointer is nil.
-// Returns an ErrDuplicateTransaction error if the transaction already exists in the working dag db.
+// Returns an ErrDuplicateTransaction error if the transaction already exists in the dag db.
 // Returns an ErrNilSignature error if the transaction has not been signed.
 // Return an ErrInvalidSignature error if the transaction's signature is invalid.
 func (dag *Dag) AddTransaction(transaction *Transaction) error {
 	logger.Infof("adding transaction with hash: %s", hex.EncodeToString(transaction.Hash.Bytes())) // Log add transaction
 
-	if WorkingDagDB == nil { // Check dag db not opened
+	if dag.db == nil { // Check dag db not opened
 		return ErrDagDbNotOpened // Return found error
 	}
 
@@ -262,18 +308,13 @@ func (dag *Dag) AddTransaction(transaction *Transaction) error {
 		return ErrNilTransaction // Return found error
 	}
 
-	if transaction.Signature == nil { // Check no signature
+	if !transaction.IsSigned() { // Check no signature
 		return ErrNilSignature // Return found error
 	}
 
-	err := createTransactionBucketIfNotExist() // Create transaction bucket if it doesn't already exist
-	if err != nil {                            // Check for errors
-		return err // Return found error
-	}
-
 	logger.Infof("checking transaction with hash: %s already exists in dag", hex.EncodeToString(transaction.Hash.Bytes())) // Log check tx already exists
 
-	_, err = dag.GetTransactionByHash(transaction.Hash) // Get transaction by hash
+	_, err := dag.GetTransactionByHash(transaction.Hash) // Get transaction by hash
 
 	if err == nil { // Check tx already exists
 		return ErrDuplicateTransaction // Return found error
@@ -281,18 +322,16 @@ func (dag *Dag) AddTransaction(transaction *Transaction) error {
 
 	logger.Infof("verifying transaction signature with hash: %s", hex.EncodeToString(transaction.Hash.Bytes())) // Log verify tx signature
 
-	if !transaction.Signature.Verify(transaction.Sender) { // Check transaction signature invalid
+	if !transaction.VerifySignature() { // Check transaction signature invalid
 		return ErrInvalidSignature // Return found error
 	}
 
 	logger.Infof("transaction signature with hash: %s verified", hex.EncodeToString(transaction.Hash.Bytes())) // Log verified signature
 
-	return WorkingDagDB.Update(func(tx *bolt.Tx) error {
-		workingTransactionBucket := tx.Bucket(transactionBucket) // Get transaction bucket
+	logger.Infof("adding transaction with hash: %s to dag db", hex.EncodeToString(transaction.Hash.Bytes())) // Log add tx to dag db
 
-		logger.Infof("adding transaction with hash: %s to dag db", hex.EncodeToString(transaction.Hash.Bytes())) // Log add tx to dag db
-
-		return workingTransactionBucket.Put(transaction.Hash.Bytes(), transaction.Bytes()) // Put transaction
+	return dag.db.Batch(func(batch storage.Batch) error {
+		return writeTransaction(batch, transaction) // Write transaction
 	}) // Write transaction
 }
 
@@ -300,31 +339,26 @@ func (dag *Dag) AddTransaction(transaction *Transaction) error {
 	BEGIN DB READING HELPER METHODS
 */
 
-// GetTransactionByHash attempts to query the working dag db by the given transaction hash.
+// GetTransactionByHash attempts to query the dag db by the given transaction hash.
 // If no transaction exists at this hash, an
 func (dag *Dag) GetTransactionByHash(transactionHash common.Hash) (*Transaction, error) {
 	logger.Infof("attempting to query transaction by hash: %s", hex.EncodeToString(transactionHash.Bytes())) // Log get tx
 
-	var txBytes []byte // Init buffer
-
-	if WorkingDagDB == nil { // Check no working db
+	if dag.db == nil { // Check no working db
 		return &Transaction{}, ErrDagDbNotOpened // Return found error
 	}
 
-	err := createTransactionBucketIfNotExist() // Create tx bucket if doesn't already exist to prevent nil pointer dereferences
-	if err != nil {                            // Check for errors
-		return &Transaction{}, err // Return found error
-	}
-
-	err = WorkingDagDB.View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(transactionBucket) // Get tx bucket
+	var transaction *Transaction // Init tx buffer
 
-		txBytes = bucket.Get(transactionHash.Bytes()) // Get tx at hash
+	err := dag.db.View(func(reader storage.Reader) error {
+		txBytes := reader.Get(transactionBucket, transactionHash.Bytes()) // Get tx at hash
 
 		if txBytes == nil { // Check no transaction at hash
 			return ErrNilTransactionAtHash // Return error
 		}
 
+		transaction = TransactionFromBytes(txBytes) // Deserialize tx
+
 		return nil // No error occurred, return nil
 	})
 
@@ -332,111 +366,66 @@ func (dag *Dag) GetTransactionByHash(transactionHash common.Hash) (*Transaction,
 		return &Transaction{}, err // Return found error
 	}
 
-	return TransactionFromBytes(txBytes), nil // Return deserialized tx
+	return transaction, nil // Return deserialized tx
 }
 
-// GetTransactionChildren iterates through the dag's transactions, and finds transactions with the given hash as a parent.
+// GetTransactionChildren queries the children index of the dag db for transactions with the given hash as a parent.
 func (dag *Dag) GetTransactionChildren(transactionHash common.Hash) ([]*Transaction, error) {
 	logger.Infof("attempting to query transaction children for tx with hash: %s", hex.EncodeToString(transactionHash.Bytes())) // Log query tx children
 
-	if WorkingDagDB == nil { // Check no dag db
+	if dag.db == nil { // Check no dag db
 		return []*Transaction{}, ErrDagDbNotOpened // Return found error
 	}
 
 	transactions := []*Transaction{} // Initialize tx buffer
 
-	err := createTransactionBucketIfNotExist() // Create transaction bucket if not exist
-	if err != nil {                            // Check for errors
-		return []*Transaction{}, err // Return found error
-	}
-
-	return transactions, WorkingDagDB.View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(transactionBucket) // Get transaction bucket
-
-		c := bucket.Cursor() // Get cursor
-
-		for currentTransactionHash, transactionBytes := c.First(); currentTransactionHash != nil; currentTransactionHash, transactionBytes = c.Next() { // Iterate through tx set
-			transaction := TransactionFromBytes(transactionBytes) // Deserialize transaction
-
-			for _, parentHash := range transaction.ParentTransactions { // Iterate through tx parents
-				if bytes.Equal(parentHash.Bytes(), transactionHash.Bytes()) { // Check matching parent
-					logger.Infof("found child with hash: %s and parent hash: %s", hex.EncodeToString(transaction.Hash.Bytes()), hex.EncodeToString(transactionHash.Bytes())) // Log found child
-
-					transactions = append(transactions, transaction) // Append to transactions
-				}
-			}
-		}
+	err := dag.db.View(func(reader storage.Reader) error {
+		transactions = transactionsAtHashes(reader, queryIndex(reader, childrenIndexBucket, transactionHash.Bytes())) // Get children
 
 		return nil // No error occurred, return nil
-	}) // Return filtered transactions
+	})
+
+	return transactions, err // Return children
 }
 
-// GetTransactionsByAddress attempts to filter the dag by a given sending or receiving address.
+// GetTransactionsByAddress queries the sender and recipient indexes of the dag db for a given address.
 func (dag *Dag) GetTransactionsByAddress(address *common.Address) ([]*Transaction, error) {
 	logger.Infof("attempting to query transactions by sender or recipient: %s", hex.EncodeToString(address.Bytes())) // Log query tx
 
-	if WorkingDagDB == nil { // Check no dag db
+	if dag.db == nil { // Check no dag db
 		return []*Transaction{}, ErrDagDbNotOpened // Return found error
 	}
 
 	transactions := []*Transaction{} // Init tx buffer
 
-	err := createTransactionBucketIfNotExist() // Create transaction bucket if not exist
-	if err != nil {                            // Check for errors
-		return []*Transaction{}, err // Return found error
-	}
-
-	return transactions, WorkingDagDB.View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(transactionBucket) // Get transaction bucket
-
-		c := bucket.Cursor() // Get cursor
+	err := dag.db.View(func(reader storage.Reader) error {
+		hashes := append(queryIndex(reader, senderIndexBucket, address.Bytes()), queryIndex(reader, recipientIndexBucket, address.Bytes())...) // Get sent and received tx hashes
 
-		for transactionHash, transactionBytes := c.First(); transactionHash != nil; transactionHash, transactionBytes = c.Next() { // Iterate through tx set
-			transaction := TransactionFromBytes(transactionBytes) // Deserialize transaction
-
-			if bytes.Equal(transaction.Sender.Bytes(), address.Bytes()) || bytes.Equal(transaction.Recipient.Bytes(), address.Bytes()) { // Check relevant
-				logger.Infof("found transaction with recipient/sender: %s", hex.EncodeToString(transaction.Hash.Bytes())) // Log found tx
-
-				transactions = append(transactions, transaction) // Append transaction
-			}
-		}
+		transactions = transactionsAtHashes(reader, hashes) // Get transactions
 
 		return nil // No error occurred, return nil
-	}) // Return filtered transactions
+	})
+
+	return transactions, err // Return filtered transactions
 }
 
-// GetTransactionsBySender attempts to filter the dag by a given sending address.
+// GetTransactionsBySender queries the sender index of the dag db for a given sending address.
 func (dag *Dag) GetTransactionsBySender(sender *common.Address) ([]*Transaction, error) {
 	logger.Infof("attempting to query transactions by sender: %s", hex.EncodeToString(sender.Bytes())) // Log query tx
 
-	if WorkingDagDB == nil { // Check no dag db
+	if dag.db == nil { // Check no dag db
 		return []*Transaction{}, ErrDagDbNotOpened // Return found error
 	}
 
 	transactions := []*Transaction{} // Init tx buffer
 
-	err := createTransactionBucketIfNotExist() // Create transaction bucket if not exist
-	if err != nil {                            // Check for errors
-		return []*Transaction{}, err // Return found error
-	}
-
-	return transactions, WorkingDagDB.View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(transactionBucket) // Get transaction bucket
-
-		c := bucket.Cursor() // Get cursor
-
-		for transactionHash, transactionBytes := c.First(); transactionHash != nil; transactionHash, transactionBytes = c.Next() { // Iterate through tx set
-			transaction := TransactionFromBytes(transactionBytes) // Deserialize transaction
-
-			if bytes.Equal(transaction.Sender.Bytes(), sender.Bytes()) { // Check is sender
-				logger.Infof("found transaction with sender: %s", hex.EncodeToString(transaction.Hash.Bytes())) // Log found tx
-
-				transactions = append(transactions, transaction) // Append transaction
-			}
-		}
+	err := dag.db.View(func(reader storage.Reader) error {
+		transactions = transactionsAtHashes(reader, queryIndex(reader, senderIndexBucket, sender.Bytes())) // Get transactions
 
 		return nil // No error occurred, return nil
-	}) // Return filtered transactions
+	})
+
+	return transactions, err // Return filtered transactions
 }
 
 // GetBestTransaction gets the last transaction in the dag. If more than one last child exists, the child with
@@ -499,32 +488,18 @@ func (dag *Dag) GetBestTransaction() (*Transaction, error) {
 	BEGIN HELPER METHODS
 */
 
-// CalculateAddressBalance calculates the total balance of an address from genesis to latest tx.
-func (dag *Dag) CalculateAddressBalance(address *common.Address) (*big.Float, error) {
+// CalculateAddressBalance reads the balance of an address (in base units) as of the latest tx from the dag db's account state.
+func (dag *Dag) CalculateAddressBalance(address *common.Address) (*big.Int, error) {
 	logger.Infof("calculating balance for address: %s", hex.EncodeToString(address.Bytes())) // Log calculate balance
 
-	transactionsRegardingAddress, err := dag.GetTransactionsByAddress(address) // Filter by pertaining to
-	if err != nil {                                                            // Check for errors
-		return &big.Float{}, err // Return found error
+	accountState, err := dag.GetAccountState(address) // Get account state
+	if err != nil {                                   // Check for errors
+		return &big.Int{}, err // Return found error
 	}
 
-	logger.Infof("found %d transactions related to address %s", len(transactionsRegardingAddress), hex.EncodeToString(address.Bytes())) // Log found related
-
-	balance := big.NewFloat(0) // Init balance buffer
-
-	for _, transaction := range transactionsRegardingAddress { // Iterate through transactions
-		if bytes.Equal(transaction.Sender.Bytes(), address.Bytes()) { // Check was sender
-			balance.Sub(balance, transaction.CalculateTotalValue()) // Subtract transaction value
-		}
+	logger.Infof("calculated balance of address %s: %s", hex.EncodeToString(address.Bytes()), accountState.Balance.String()) // Log calculated balance
 
-		if bytes.Equal(transaction.Recipient.Bytes(), address.Bytes()) { // Check was recipient
-			balance.Add(balance, transaction.Amount) // Add transaction amount
-		}
-	}
-
-	logger.Infof("calculated balance of address %s: %d", hex.EncodeToString(address.Bytes()), balance) // Log calculated balance
-
-	return balance, nil // Return balance
+	return accountState.Balance, nil // Return balance
 }
 
 /*
@@ -536,42 +511,38 @@ func (dag *Dag) CalculateAddressBalance(address *common.Address) (*big.Float, er
 /* BEGIN INTERNAL METHODS */
 
 /*
-	BEGIN DB BUCKET HELPER METHODS
+	BEGIN DB WRITING HELPER METHODS
 */
 
 // forceAddTransaction forces the adding of a given transaction to the dag (only useful for adding a genesis tx).
 func (dag *Dag) forceAddTransaction(transaction *Transaction) error {
-	err := createTransactionBucketIfNotExist() // Create transaction bucket if it doesn't already exist
-	if err != nil {                            // Check for errors
-		return err // Return found error
+	if dag.db == nil { // Check dag db not opened
+		return ErrDagDbNotOpened // Return found error
 	}
 
-	_, err = dag.GetTransactionByHash(transaction.Hash) // Get transaction by hash
+	return dag.db.Batch(func(batch storage.Batch) error {
+		return writeTransaction(batch, transaction) // Write transaction
+	}) // Write transaction
+}
 
-	if err == nil { // Check tx already exists
+// writeTransaction puts a given transaction, its index entries and its account state changes in a given batch.
+// Returns an ErrDuplicateTransaction error if the transaction already exists in the batch.
+func writeTransaction(batch storage.Batch, transaction *Transaction) error {
+	if batch.Get(transactionBucket, transaction.Hash.Bytes()) != nil { // Check tx already exists
 		return ErrDuplicateTransaction // Return found error
 	}
 
-	return WorkingDagDB.Update(func(tx *bolt.Tx) error {
-		workingTransactionBucket := tx.Bucket(transactionBucket) // Get transaction bucket
-
-		return workingTransactionBucket.Put(transaction.Hash.Bytes(), transaction.Bytes()) // Put transaction
-	}) // Write transaction // No error occurred, return nil
-}
-
-// createTransactionBucketIfNotExist attempts to create the "transaction" bucket in the working dag db.
-func createTransactionBucketIfNotExist() error {
-	if WorkingDagDB == nil { // Check no working db
-		return ErrDagDbNotOpened // Return found error
+	err := batch.Put(transactionBucket, transaction.Hash.Bytes(), transaction.Bytes()) // Put transaction
+	if err != nil {                                                                    // Check for errors
+		return err // Return found error
 	}
 
-	err := WorkingDagDB.Update(func(tx *bolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(transactionBucket) // Create tx bucket if it doesn't already exist
-
-		return err // Return error
-	}) // Create tx bucket if it doesn't already exist
+	err = indexTransaction(batch, transaction) // Index transaction
+	if err != nil {                            // Check for errors
+		return err // Return found error
+	}
 
-	return err // Return error
+	return applyTransactionToAccountState(batch, transaction) // Update sender and recipient state
 }
 
 // getDagLogger gets the dag package logger, and sets the levels of said logger.
@@ -584,7 +555,7 @@ func getDagLogger() loggo.Logger {
 }
 
 /*
-	END DB BUCKET HELPER METHODS
+	END DB WRITING HELPER METHODS
 */
 
 /* END INTERNAL METHODS */
@@ -0,0 +1,214 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+var accountStateBucket = []byte("account-state-bucket") // Address => account state
+
+// AccountState represents the current state of an address in the dag, as of the latest transaction.
+type AccountState struct {
+	Balance *big.Int `json:"balance"` // Address balance (in base units)
+
+	Nonce uint64 `json:"nonce"` // Highest nonce of any transaction sent by the address
+
+	SentTransactions uint64 `json:"sent_transactions"` // Number of transactions sent by the address
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewAccountState initializes a new, empty account state.
+func NewAccountState() *AccountState {
+	return &AccountState{
+		Balance: big.NewInt(0), // Set balance
+	} // Return initialized account state
+}
+
+// NextNonce returns the nonce the next transaction sent by the address must have.
+func (accountState *AccountState) NextNonce() uint64 {
+	if accountState.SentTransactions == 0 { // Check no sent transactions
+		return 0 // First nonce
+	}
+
+	return accountState.Nonce + 1 // Return next nonce
+}
+
+// Bytes serializes a given account state to a byte array via json.
+func (accountState *AccountState) Bytes() []byte {
+	marshaledVal, _ := json.Marshal(*accountState) // Marshal JSON
+
+	return marshaledVal // Return marshaled value
+}
+
+// AccountStateFromBytes deserializes an account state from a given byte array.
+func AccountStateFromBytes(b []byte) *AccountState {
+	buffer := NewAccountState() // Initialize account state buffer
+
+	err := json.Unmarshal(b, buffer) // Unmarshal
+	if err != nil {                  // Check for errors
+		return NewAccountState() // Return empty account state
+	}
+
+	return buffer // Return deserialized account state
+}
+
+// GetAccountState reads the current state of a given address from the working dag db.
+// If the address has never sent or received a transaction, an empty account state is returned.
+func (dag *Dag) GetAccountState(address *common.Address) (*AccountState, error) {
+	if dag.db == nil { // Check no working db
+		return NewAccountState(), ErrDagDbNotOpened // Return found error
+	}
+
+	accountState := NewAccountState() // Init state buffer
+
+	err := dag.db.View(func(reader storage.Reader) error {
+		accountState = readAccountState(reader, address) // Read account state
+
+		return nil // No error occurred, return nil
+	})
+
+	return accountState, err // Return account state
+}
+
+// GetNextNonce gets the nonce that the next transaction sent by a given address must have.
+func (dag *Dag) GetNextNonce(address *common.Address) (uint64, error) {
+	accountState, err := dag.GetAccountState(address) // Get account state
+	if err != nil {                                   // Check for errors
+		return 0, err // Return found error
+	}
+
+	return accountState.NextNonce(), nil // Return next nonce
+}
+
+// RebuildAccountState recalculates the state of every address from scratch by replaying all of the dag's transactions,
+// and overwrites the stored account state with the result.
+// Returns the addresses whose stored state did not match the recalculated state.
+func (dag *Dag) RebuildAccountState() ([]common.Address, error) {
+	logger.Infof("rebuilding account state") // Log rebuild
+
+	if dag.db == nil { // Check no working db
+		return nil, ErrDagDbNotOpened // Return found error
+	}
+
+	var mismatched []common.Address // Init mismatched buffer
+
+	err := dag.db.Batch(func(batch storage.Batch) error {
+		var err error // Init error buffer
+
+		mismatched, err = rebuildAccountState(batch) // Rebuild
+
+		return err // Return error
+	})
+
+	logger.Infof("finished rebuilding account state; found %d mismatched addresses", len(mismatched)) // Log finished
+
+	return mismatched, err // Return mismatched addresses
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// readAccountState reads the state of a given address in a given view.
+func readAccountState(reader storage.Reader, address *common.Address) *AccountState {
+	if stateBytes := reader.Get(accountStateBucket, address.Bytes()); stateBytes != nil { // Check has state
+		return AccountStateFromBytes(stateBytes) // Return state
+	}
+
+	return NewAccountState() // Return empty state
+}
+
+// applyTransactionToAccountState updates the sender and recipient state of a given transaction in a given batch.
+func applyTransactionToAccountState(batch storage.Batch, transaction *Transaction) error {
+	if transaction.Sender != nil { // Check has sender
+		senderState := readAccountState(batch, transaction.Sender) // Read sender state
+
+		senderState.Balance.Sub(senderState.Balance, transaction.CalculateTotalValue()) // Subtract transaction value
+
+		if senderState.SentTransactions == 0 || transaction.AccountNonce > senderState.Nonce { // Check new highest nonce
+			senderState.Nonce = transaction.AccountNonce // Set nonce
+		}
+
+		senderState.SentTransactions++ // Increment sent
+
+		if err := batch.Put(accountStateBucket, transaction.Sender.Bytes(), senderState.Bytes()); err != nil { // Write sender state
+			return err // Return found error
+		}
+	}
+
+	if transaction.Recipient != nil && transaction.Amount != nil { // Check has recipient
+		recipientState := readAccountState(batch, transaction.Recipient) // Read recipient state
+
+		recipientState.Balance.Add(recipientState.Balance, transaction.Amount) // Add transaction amount
+
+		return batch.Put(accountStateBucket, transaction.Recipient.Bytes(), recipientState.Bytes()) // Write recipient state
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// rebuildAccountState replays every transaction in a given batch into a fresh account state bucket.
+// Returns the addresses whose previous state differed from the rebuilt state.
+func rebuildAccountState(batch storage.Batch) ([]common.Address, error) {
+	previous := make(map[common.Address][]byte) // Init previous state buffer
+
+	err := batch.Iterate(accountStateBucket, nil, func(address []byte, stateBytes []byte) error {
+		previous[*common.NewAddress(address)] = append([]byte{}, stateBytes...) // Copy previous state
+
+		return nil // No error occurred, return nil
+	}) // Read existing state
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	if err = batch.DeleteBucket(accountStateBucket); err != nil { // Drop existing state
+		return nil, err // Return found error
+	}
+
+	err = batch.Iterate(transactionBucket, nil, func(hash []byte, transactionBytes []byte) error {
+		return applyTransactionToAccountState(batch, TransactionFromBytes(transactionBytes)) // Apply transaction
+	}) // Replay all transactions
+
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	var mismatched []common.Address // Init mismatched buffer
+
+	err = batch.Iterate(accountStateBucket, nil, func(address []byte, stateBytes []byte) error {
+		rebuiltState := AccountStateFromBytes(stateBytes) // Deserialize rebuilt state
+
+		previousBytes, ok := previous[*common.NewAddress(address)] // Get previous state
+
+		delete(previous, *common.NewAddress(address)) // Mark as compared
+
+		if !ok || !accountStatesEqual(AccountStateFromBytes(previousBytes), rebuiltState) { // Check mismatch
+			logger.Warningf("account state mismatch for address %s", hex.EncodeToString(address)) // Log mismatch
+
+			mismatched = append(mismatched, *common.NewAddress(address)) // Append address
+		}
+
+		return nil // No error occurred, return nil
+	})
+
+	for address := range previous { // Iterate through addresses that only existed in the previous state
+		mismatched = append(mismatched, address) // Append address
+	}
+
+	return mismatched, err // Return mismatched addresses
+}
+
+// accountStatesEqual checks whether two given account states are equivalent.
+func accountStatesEqual(a, b *AccountState) bool {
+	return a.Balance.Cmp(b.Balance) == 0 && a.Nonce == b.Nonce && a.SentTransactions == b.SentTransactions // Return equivalent
+}
+
+/* END INTERNAL METHODS */
@@ -0,0 +1,175 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestGetNextNonce tests the functionality of the GetNextNonce() helper method.
+func TestGetNextNonce(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
+
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	nonce, err := dag.GetNextNonce(crypto.AddressFromPrivateKey(privateKey)) // Get next nonce
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if nonce != 0 { // Check invalid nonce
+		t.Fatalf("invalid next nonce for new address; found %d", nonce) // Panic
+	}
+
+	for x := uint64(0); x < 2; x++ { // Send two transactions
+		transaction := NewTransaction(
+			x,                                        // Nonce
+			big.NewInt(0),                            // Amount
+			crypto.AddressFromPrivateKey(privateKey), // Sender
+			nil,                                      // Recipient
+			nil,                                      // Parents
+			1,                                        // Gas limit
+			big.NewInt(1000),                         // Gas price
+			[]byte("test payload"),                   // Payload
+			1,                                        // Network
+			time.Time{},                              // Valid until
+		) // Create new transaction
+
+		err = SignTransaction(transaction, privateKey) // Sign transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		err = dag.AddTransaction(transaction) // Add transaction
+
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+	}
+
+	nonce, err = dag.GetNextNonce(crypto.AddressFromPrivateKey(privateKey)) // Get next nonce
+	if err != nil {                                                         // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if nonce != 2 { // Check invalid nonce
+		t.Fatalf("invalid next nonce; found %d, but wanted 2", nonce) // Panic
+	}
+}
+
+// TestRebuildAccountState tests the functionality of the RebuildAccountState() helper method.
+func TestRebuildAccountState(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
+
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	recipient := common.NewAddress([]byte("test recipient")) // Init recipient
+
+	transaction := NewTransaction(
+		0,                                        // Nonce
+		big.NewInt(10),                           // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		recipient,                                // Recipient
+		nil,                                      // Parents
+		2,                                        // Gas limit
+		big.NewInt(1000),                         // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new transaction
+
+	err = SignTransaction(transaction, privateKey) // Sign transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = dag.AddTransaction(transaction) // Add transaction
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	mismatched, err := dag.RebuildAccountState() // Rebuild account state
+	if err != nil {                              // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(mismatched) != 0 { // Check incrementally maintained state diverged
+		t.Fatalf("incremental account state should match rebuilt state; found %d mismatched addresses", len(mismatched)) // Panic
+	}
+
+	err = dag.db.Batch(func(batch storage.Batch) error {
+		return batch.Put(accountStateBucket, recipient.Bytes(), NewAccountState().Bytes()) // Corrupt recipient state
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	mismatched, err = dag.RebuildAccountState() // Rebuild account state
+	if err != nil {                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(mismatched) != 1 || mismatched[0] != *recipient { // Check corrupted address not reported
+		t.Fatalf("should have found 1 mismatched address; found %d", len(mismatched)) // Panic
+	}
+
+	balance, err := dag.CalculateAddressBalance(recipient) // Calculate recipient balance
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if balance.Cmp(big.NewInt(10)) != 0 { // Check state not corrected
+		t.Fatalf("invalid rebuilt balance; found %s, but wanted 10", balance.String()) // Panic
+	}
+
+	balance, err = dag.CalculateAddressBalance(crypto.AddressFromPrivateKey(privateKey)) // Calculate sender balance
+	if err != nil {                                                                      // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if balance.Cmp(big.NewInt(-2010)) != 0 { // Check invalid sender balance
+		t.Fatalf("invalid rebuilt balance; found %s, but wanted -2010", balance.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -0,0 +1,342 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"golang.org/x/crypto/sha3"
+)
+
+// archiveVersion is the current version of the dag archive format.
+const archiveVersion = byte(1)
+
+// importBatchSize is the number of archived transactions added to the dag in each write during an import.
+const importBatchSize = 4096
+
+// maxArchiveRecordSize is the maximum size of a single record (config or transaction) in a dag archive.
+const maxArchiveRecordSize = 32 * 1024 * 1024
+
+// archiveMagic is the sequence of bytes every dag archive begins with.
+var archiveMagic = []byte("PLRSDAG")
+
+var (
+	// ErrInvalidArchive is an error definition representing a dag archive that could not be decoded.
+	ErrInvalidArchive = errors.New("invalid dag archive")
+
+	// ErrUnsupportedArchiveVersion is an error definition representing a dag archive written with an unknown format version.
+	ErrUnsupportedArchiveVersion = errors.New("unsupported dag archive version")
+
+	// ErrArchiveChecksumMismatch is an error definition representing a dag archive whose contents do not match its checksum.
+	ErrArchiveChecksumMismatch = errors.New("dag archive checksum mismatch")
+
+	// ErrDagChangedDuringExport is an error definition representing a dag whose transactions changed while it was being exported.
+	ErrDagChangedDuringExport = errors.New("dag changed during export")
+)
+
+// TransactionValidator represents any validator able to validate a transaction against a dag (e.g. a validator.Validator).
+type TransactionValidator interface {
+	ValidateTransaction(transaction *Transaction) error // Validate a given transaction
+}
+
+// archiveReader reads length-prefixed records from a dag archive, hashing every byte read.
+type archiveReader struct {
+	reader *bufio.Reader // Underlying reader
+
+	hasher io.Writer // Checksum hasher
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// Export writes the dag's config, genesis hash and all of its transactions (in topological order, as visited by Walk) to a given
+// writer, followed by a sha3 checksum of the archive. Transactions are streamed from the dag db rather than read into memory at once
+// (the dag is walked twice: once to count its transactions, then once to write them).
+// Returns an ErrDagChangedDuringExport error if transactions are added to the dag while it is being exported.
+//
+// Archive layout (all lengths are unsigned varints):
+//
+//	magic ("PLRSDAG") | version (1 byte) | config length | config (json) | genesis hash (32 bytes)
+//	| transaction count | (transaction length | transaction)... | sha3-256 checksum of all preceding bytes (32 bytes)
+func (dag *Dag) Export(writer io.Writer) error {
+	logger.Infof("exporting dag %s", dag.DagConfig.Identifier) // Log export
+
+	count := uint64(0) // Init tx counter
+
+	err := dag.Walk(func(*Transaction) error {
+		count++ // Increment tx counter
+
+		return nil // No error occurred, return nil
+	}) // Count transactions
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	hasher := sha3.New256() // Init checksum hasher
+
+	bufferedWriter := bufio.NewWriter(writer) // Init buffered writer
+
+	archiveWriter := io.MultiWriter(bufferedWriter, hasher) // Write to both archive and checksum
+
+	if _, err = archiveWriter.Write(append(append([]byte{}, archiveMagic...), archiveVersion)); err != nil { // Write magic and version
+		return err // Return found error
+	}
+
+	if err = writeArchiveRecord(archiveWriter, dag.DagConfig.Bytes()); err != nil { // Write config
+		return err // Return found error
+	}
+
+	if _, err = archiveWriter.Write(dag.Genesis.Bytes()); err != nil { // Write genesis hash
+		return err // Return found error
+	}
+
+	if err = writeUvarint(archiveWriter, count); err != nil { // Write transaction count
+		return err // Return found error
+	}
+
+	written := uint64(0) // Init written tx counter
+
+	err = dag.Walk(func(transaction *Transaction) error {
+		if written++; written > count { // Check tx added since counted
+			return ErrDagChangedDuringExport // Return error
+		}
+
+		return writeArchiveRecord(archiveWriter, transaction.Bytes()) // Write transaction
+	}) // Write transactions
+	if err != nil { // Check for errors
+		return err // Return found error
+	}
+
+	if written != count { // Check tx count changed
+		return ErrDagChangedDuringExport // Return error
+	}
+
+	if _, err = bufferedWriter.Write(hasher.Sum(nil)); err != nil { // Write checksum
+		return err // Return found error
+	}
+
+	logger.Infof("exported %d transactions", count) // Log exported
+
+	return bufferedWriter.Flush() // Flush archive
+}
+
+// ImportDag reads a dag archive written by Export from a given reader into a new dag in the given db directory.
+// Every transaction after the genesis is validated via a validator returned by newValidator before being added (transactions are
+// added in batches of importBatchSize, each validated against a dag containing every transaction before it).
+// If the import fails, the partially imported dag is removed.
+// Returns an ErrDagAlreadyExists error if a dag with the archive's identifier already exists in the given directory.
+func ImportDag(reader io.Reader, dbDir string, newValidator func(dag *Dag) TransactionValidator) (*Dag, error) {
+	hasher := sha3.New256() // Init checksum hasher
+
+	archive := &archiveReader{
+		reader: bufio.NewReader(reader), // Set reader
+		hasher: hasher,                  // Set hasher
+	} // Init archive reader
+
+	header := make([]byte, len(archiveMagic)+1) // Init header buffer
+
+	if err := archive.readFull(header); err != nil || !bytes.Equal(header[:len(archiveMagic)], archiveMagic) { // Check invalid magic
+		return &Dag{}, ErrInvalidArchive // Return error
+	}
+
+	if header[len(archiveMagic)] != archiveVersion { // Check unsupported version
+		return &Dag{}, ErrUnsupportedArchiveVersion // Return error
+	}
+
+	configBytes, err := archive.readRecord() // Read config
+	if err != nil {                          // Check for errors
+		return &Dag{}, err // Return found error
+	}
+
+	dagConfig := config.DagConfigFromBytes(configBytes) // Deserialize config
+
+	if dagConfig.Identifier == "" { // Check invalid config
+		return &Dag{}, ErrInvalidArchive // Return error
+	}
+
+	genesisHash := make([]byte, common.HashLength) // Init genesis buffer
+
+	if err = archive.readFull(genesisHash); err != nil { // Read genesis hash
+		return &Dag{}, err // Return found error
+	}
+
+	if _, err = os.Stat(filepath.FromSlash(fmt.Sprintf("%s/%s.db", dbDir, dagConfig.Identifier))); err == nil { // Check dag already exists
+		return &Dag{}, ErrDagAlreadyExists // Return error
+	}
+
+	dag, err := NewDagInDir(dagConfig, dbDir) // Initialize dag
+	if err != nil {                           // Check for errors
+		return &Dag{}, err // Return found error
+	}
+
+	logger.Infof("importing dag %s", dagConfig.Identifier) // Log import
+
+	if err = dag.importTransactions(archive, common.NewHash(genesisHash), newValidator); err == nil { // Import transactions
+		checksum := hasher.Sum(nil) // Calculate checksum
+
+		readChecksum := make([]byte, len(checksum)) // Init checksum buffer
+
+		if _, err = io.ReadFull(archive.reader, readChecksum); err != nil || !bytes.Equal(checksum, readChecksum) { // Check invalid checksum
+			err = ErrArchiveChecksumMismatch // Set error
+		}
+	}
+
+	if err != nil { // Check for errors
+		dag.Close() // Close dag
+
+		os.Remove(filepath.FromSlash(fmt.Sprintf("%s/%s.db", dbDir, dagConfig.Identifier)))             // Remove partial dag db
+		os.Remove(filepath.FromSlash(fmt.Sprintf("%s/db_header_%s.json", dbDir, dagConfig.Identifier))) // Remove partial dag db header
+
+		return &Dag{}, err // Return found error
+	}
+
+	logger.Infof("finished importing dag %s", dagConfig.Identifier) // Log finished
+
+	return dag, nil // Return imported dag
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// importTransactions reads the transactions of an archive into the dag in batches, validating each transaction after the genesis.
+func (dag *Dag) importTransactions(archive *archiveReader, genesisHash common.Hash, newValidator func(dag *Dag) TransactionValidator) error {
+	count, err := binary.ReadUvarint(archive) // Read transaction count
+	if err != nil {                           // Check for errors
+		return ErrInvalidArchive // Return error
+	}
+
+	var batch []*Transaction // Init tx batch buffer
+
+	for i := uint64(0); i < count; i++ { // Read each transaction
+		transactionBytes, err := archive.readRecord() // Read transaction
+		if err != nil {                               // Check for errors
+			return err // Return found error
+		}
+
+		transaction, err := DecodeTransaction(transactionBytes) // Deserialize transaction
+		if err != nil {                                         // Check for errors
+			return ErrInvalidArchive // Return error
+		}
+
+		if transaction.Hash == genesisHash { // Check is genesis
+			if err = dag.importTransactionBatch(batch, newValidator); err != nil { // Import preceding transactions
+				return err // Return found error
+			}
+
+			batch = nil // Reset batch
+
+			if err = dag.AddGenesisTransaction(transaction); err != nil { // Add genesis transaction
+				return err // Return found error
+			}
+
+			continue // Continue
+		}
+
+		batch = append(batch, transaction) // Append transaction
+
+		if len(batch) == importBatchSize { // Check batch full
+			if err = dag.importTransactionBatch(batch, newValidator); err != nil { // Import batch
+				return err // Return found error
+			}
+
+			batch = nil // Reset batch
+		}
+	}
+
+	if err = dag.importTransactionBatch(batch, newValidator); err != nil { // Import remaining transactions
+		return err // Return found error
+	}
+
+	if dag.Genesis != genesisHash { // Check genesis not imported
+		return ErrInvalidArchive // Return error
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// importTransactionBatch validates and adds a given batch of archived transactions to the dag in a single write.
+// Returns an error if any transaction in the batch is invalid.
+func (dag *Dag) importTransactionBatch(transactions []*Transaction, newValidator func(dag *Dag) TransactionValidator) error {
+	if len(transactions) == 0 { // Check nothing to import
+		return nil // Nothing to import
+	}
+
+	results, err := dag.ValidateAndAddTransactions(transactions, newValidator) // Add transactions
+	if err != nil {                                                            // Check for errors
+		return err // Return found error
+	}
+
+	for i, err := range results { // Iterate through results
+		if err != nil { // Check invalid
+			return fmt.Errorf("invalid transaction %x in archive: %s", transactions[i].Hash.Bytes(), err.Error()) // Return found error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// writeArchiveRecord writes a given length-prefixed record to a given writer.
+func writeArchiveRecord(writer io.Writer, record []byte) error {
+	if err := writeUvarint(writer, uint64(len(record))); err != nil { // Write length
+		return err // Return found error
+	}
+
+	_, err := writer.Write(record) // Write record
+
+	return err // Return error
+}
+
+// writeUvarint writes a given unsigned varint to a given writer.
+func writeUvarint(writer io.Writer, x uint64) error {
+	buffer := make([]byte, binary.MaxVarintLen64) // Init buffer
+
+	_, err := writer.Write(buffer[:binary.PutUvarint(buffer, x)]) // Write varint
+
+	return err // Return error
+}
+
+// ReadByte reads (and hashes) a single byte from the archive.
+func (archive *archiveReader) ReadByte() (byte, error) {
+	b, err := archive.reader.ReadByte() // Read byte
+	if err != nil {                     // Check for errors
+		return 0, err // Return found error
+	}
+
+	archive.hasher.Write([]byte{b}) // Hash byte
+
+	return b, nil // Return read byte
+}
+
+// readFull reads (and hashes) exactly len(buffer) bytes from the archive.
+func (archive *archiveReader) readFull(buffer []byte) error {
+	if _, err := io.ReadFull(archive.reader, buffer); err != nil { // Read bytes
+		return ErrInvalidArchive // Return error
+	}
+
+	archive.hasher.Write(buffer) // Hash bytes
+
+	return nil // No error occurred, return nil
+}
+
+// readRecord reads (and hashes) a length-prefixed record from the archive.
+func (archive *archiveReader) readRecord() ([]byte, error) {
+	length, err := binary.ReadUvarint(archive)       // Read length
+	if err != nil || length > maxArchiveRecordSize { // Check invalid length
+		return nil, ErrInvalidArchive // Return error
+	}
+
+	record := make([]byte, length) // Init record buffer
+
+	return record, archive.readFull(record) // Read record
+}
+
+/* END INTERNAL METHODS */
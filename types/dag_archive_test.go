@@ -0,0 +1,134 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+// signatureValidator is a minimal transaction validator checking only transaction signatures.
+type signatureValidator struct{}
+
+// ValidateTransaction validates a given transaction's signature.
+func (validator signatureValidator) ValidateTransaction(transaction *Transaction) error {
+	if !transaction.VerifySignature() { // Check invalid signature
+		return ErrInvalidSignature // Return error
+	}
+
+	return nil // Transaction is valid
+}
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestExportImportDag tests the functionality of the Export() and ImportDag() helper methods.
+func TestExportImportDag(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(crypto.AddressFromPrivateKey(privateKey).Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	parent := genesisTransactions[len(genesisTransactions)-1] // Get last genesis child
+
+	transaction := NewTransaction(
+		0,                                        // Nonce
+		big.NewInt(10),                           // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		common.NewAddress([]byte("recipient")),   // Recipient
+		[]common.Hash{parent.Hash},               // Parents
+		1,                                        // Gas limit
+		big.NewInt(1),                            // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new transaction
+
+	if err = SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err = dag.AddTransaction(transaction); err != nil { // Add transaction
+		t.Fatal(err) // Panic
+	}
+
+	var archive bytes.Buffer // Init archive buffer
+
+	if err = dag.Export(&archive); err != nil { // Export dag
+		t.Fatal(err) // Panic
+	}
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
+	corrupted := append([]byte{}, archive.Bytes()...) // Copy archive
+
+	corrupted[len(corrupted)-40] ^= 0xff // Flip byte of last transaction
+
+	if _, err = ImportDag(bytes.NewReader(corrupted), dbDir, func(*Dag) TransactionValidator { return signatureValidator{} }); err == nil { // Import corrupted archive
+		t.Fatal("importing a corrupted archive should fail") // Panic
+	}
+
+	if _, err = os.Stat(filepath.Join(dbDir, "test_network.db")); !os.IsNotExist(err) { // Check partial import not removed
+		t.Fatal("failed import should remove the partially imported dag") // Panic
+	}
+
+	importedDag, err := ImportDag(&archive, dbDir, func(*Dag) TransactionValidator { return signatureValidator{} }) // Import archive
+	if err != nil {                                                                                                 // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer importedDag.Close() // Close imported dag
+
+	if importedDag.Genesis != dag.Genesis { // Check invalid genesis
+		t.Fatal("imported dag should have the same genesis") // Panic
+	}
+
+	if _, err = importedDag.GetTransactionByHash(transaction.Hash); err != nil { // Check transaction not imported
+		t.Fatal(err) // Panic
+	}
+
+	balance, err := importedDag.CalculateAddressBalance(crypto.AddressFromPrivateKey(privateKey)) // Calculate balance
+	if err != nil {                                                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if balance.Cmp(big.NewInt(89)) != 0 { // Check invalid balance
+		t.Fatalf("invalid imported balance; found %s, but wanted 89", balance.String()) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
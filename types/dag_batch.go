@@ -0,0 +1,273 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"errors"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+var (
+	// ErrTransactionOutOfOrder is an error definition representing a transaction in a batch that precedes one of its own parents.
+	ErrTransactionOutOfOrder = errors.New("transaction precedes its parent in batch")
+
+	// ErrRejectedParent is an error definition representing a transaction in a batch whose parent was rejected from the same batch.
+	ErrRejectedParent = errors.New("transaction parent was rejected from batch")
+
+	// ErrMissingParent is an error definition representing a transaction in a batch with a parent in neither the batch nor the dag.
+	ErrMissingParent = errors.New("transaction parent does not exist in batch or dag")
+
+	// ErrInvalidBatchNonce is an error definition representing a transaction in a batch whose nonce does not follow the sender's
+	// last nonce (including the nonces of transactions accepted earlier in the batch).
+	ErrInvalidBatchNonce = errors.New("transaction nonce does not follow sender nonce")
+
+	// ErrInvalidBatchAmount is an error definition representing a transaction in a batch with a nil or negative amount or gas price.
+	ErrInvalidBatchAmount = errors.New("transaction amount or gas price is nil or negative")
+
+	// ErrInsufficientBatchBalance is an error definition representing a transaction in a batch whose sender cannot afford it
+	// (after the transactions accepted earlier in the batch).
+	ErrInsufficientBatchBalance = errors.New("insufficient sender balance")
+)
+
+// batchValidator is the default batch transaction validator, checking that a transaction's parents exist, that its amounts are valid, and that it neither
+// reuses nor skips a sender nonce, nor overspends its sender's balance, against a dag reading from the batch.
+type batchValidator struct {
+	dag *Dag // Dag reading from the batch
+}
+
+// batchStorage is a storage instance running every view and batch in an existing, uncommitted batch, allowing
+// dag reads to observe the batch's writes before they are committed.
+type batchStorage struct {
+	batch storage.Batch // Underlying batch
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// AddTransactions appends a given set of transactions to the working dag in a single write.
+// Transactions must be ordered such that every transaction follows its parents. Besides the checks made by AddTransaction,
+// each transaction's parents must exist, and its nonce and value must follow from its sender's state after every transaction
+// accepted before it in the batch; of several transactions sharing a sender nonce, only the first valid one is added.
+// Returns the error (if any) preventing each transaction from being added, as well as any error preventing the batch from being written,
+// in which case no transaction is added.
+func (dag *Dag) AddTransactions(transactions []*Transaction) ([]error, error) {
+	return dag.ValidateAndAddTransactions(transactions, newBatchValidator) // Add transactions
+}
+
+// ValidateAndAddTransactions appends a given set of transactions to the working dag in a single write, validating each transaction with a
+// validator initialized via the given factory (or, if the factory is nil, checking each transaction as in AddTransactions).
+// Each transaction is validated against a dag containing every transaction accepted before it in the batch.
+// Transactions must be ordered such that every transaction follows its parents.
+// Returns the error (if any) preventing each transaction from being added, as well as any error preventing the batch from being written,
+// in which case no transaction is added.
+func (dag *Dag) ValidateAndAddTransactions(transactions []*Transaction, newValidator func(dag *Dag) TransactionValidator) ([]error, error) {
+	logger.Infof("adding batch of %d transactions", len(transactions)) // Log add transactions
+
+	if dag.db == nil { // Check dag db not opened
+		return nil, ErrDagDbNotOpened // Return found error
+	}
+
+	if newValidator == nil { // Check no validator factory
+		newValidator = newBatchValidator // Set default validator factory
+	}
+
+	results, err := dag.checkTransactionBatch(transactions) // Check batch
+	if err != nil {                                         // Check for errors
+		return nil, err // Return found error
+	}
+
+	verifySignatures(transactions, results) // Verify signatures
+
+	added := 0 // Init added tx counter
+
+	err = dag.db.Batch(func(batch storage.Batch) error {
+		batchDag := &Dag{
+			DagConfig:       dag.DagConfig,              // Set config
+			Genesis:         dag.Genesis,                // Set genesis
+			LastTransaction: dag.LastTransaction,        // Set last transaction
+			db:              batchStorage{batch: batch}, // Set batch db
+		} // Initialize dag reading from batch
+
+		validator := newValidator(batchDag) // Initialize validator
+
+		rejected := make(map[common.Hash]bool) // Init rejected tx set
+
+		for i, transaction := range transactions { // Iterate through transactions
+			if results[i] == nil { // Check not yet rejected
+				results[i] = checkParentsAccepted(transaction, rejected) // Check parents accepted
+			}
+
+			if results[i] == nil { // Check not yet rejected
+				results[i] = validator.ValidateTransaction(transaction) // Validate transaction
+			}
+
+			if results[i] == nil { // Check valid
+				if err := writeTransaction(batch, transaction); err == ErrDuplicateTransaction { // Check duplicate
+					results[i] = err // Set result
+				} else if err != nil { // Check for errors
+					return err // Return found error
+				}
+			}
+
+			if results[i] != nil { // Check rejected
+				if transaction != nil && results[i] != ErrDuplicateTransaction { // Check tx not already in batch or dag
+					rejected[transaction.Hash] = true // Add to rejected set
+				}
+
+				continue // Continue
+			}
+
+			added++ // Increment added tx counter
+		}
+
+		return nil // No error occurred, return nil
+	}) // Write transactions
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	logger.Infof("added %d of %d transactions in batch", added, len(transactions)) // Log added transactions
+
+	return results, nil // Return results
+}
+
+// ValidateTransaction checks that a given transaction's parents exist, that its amounts are not negative, and that its nonce and value
+// follow from its sender's state.
+func (validator batchValidator) ValidateTransaction(transaction *Transaction) error {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if _, err := validator.dag.GetTransactionByHash(parentHash); err != nil { // Check parent missing
+			return ErrMissingParent // Return error
+		}
+	}
+
+	if !transaction.HasValidAmounts() { // Check nil or negative amounts
+		return ErrInvalidBatchAmount // Return error
+	}
+
+	if transaction.Sender == nil { // Check no sender
+		return nil // Nothing else to check
+	}
+
+	accountState, err := validator.dag.GetAccountState(transaction.Sender) // Get sender state
+	if err != nil {                                                        // Check for errors
+		return err // Return found error
+	}
+
+	if transaction.AccountNonce != accountState.NextNonce() { // Check nonce reused or skipped
+		return ErrInvalidBatchNonce // Return error
+	}
+
+	if accountState.Balance.Cmp(transaction.CalculateTotalValue()) < 0 { // Check overspends
+		return ErrInsufficientBatchBalance // Return error
+	}
+
+	return nil // Transaction is valid
+}
+
+// View runs a given function in the underlying batch.
+func (batchStorage batchStorage) View(fn func(reader storage.Reader) error) error {
+	return fn(batchStorage.batch) // Run in batch
+}
+
+// Batch runs a given function in the underlying batch. Writes made by fn are not rolled back if fn fails.
+func (batchStorage batchStorage) Batch(fn func(batch storage.Batch) error) error {
+	return fn(batchStorage.batch) // Run in batch
+}
+
+// Close does nothing, as the underlying batch is owned by its storage instance.
+func (batchStorage batchStorage) Close() error {
+	return nil // Nothing to close
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// checkTransactionBatch checks that each transaction in a given batch is not nil, is signed, does not already exist in the batch
+// or dag, and does not precede any of its parents in the batch.
+func (dag *Dag) checkTransactionBatch(transactions []*Transaction) ([]error, error) {
+	results := make([]error, len(transactions)) // Init results
+
+	positions := make(map[common.Hash]int) // Init batch position of each tx
+
+	for i, transaction := range transactions { // Iterate through transactions
+		if transaction == nil { // Check nil pointer
+			results[i] = ErrNilTransaction // Set result
+		} else if !transaction.IsSigned() { // Check no signature
+			results[i] = ErrNilSignature // Set result
+		} else if _, ok := positions[transaction.Hash]; ok { // Check duplicate in batch
+			results[i] = ErrDuplicateTransaction // Set result
+		} else {
+			positions[transaction.Hash] = i // Set position
+		}
+	}
+
+	err := dag.db.View(func(reader storage.Reader) error {
+		for i, transaction := range transactions { // Iterate through transactions
+			if results[i] == nil && reader.Get(transactionBucket, transaction.Hash.Bytes()) != nil { // Check tx already exists
+				results[i] = ErrDuplicateTransaction // Set result
+			}
+		}
+
+		return nil // No error occurred, return nil
+	}) // Check for existing transactions
+	if err != nil { // Check for errors
+		return nil, err // Return found error
+	}
+
+	for i, transaction := range transactions { // Iterate through transactions
+		if results[i] != nil { // Check already rejected
+			continue // Continue
+		}
+
+		for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+			if position, ok := positions[parentHash]; ok && position > i { // Check parent follows tx
+				results[i] = ErrTransactionOutOfOrder // Set result
+
+				break // Break
+			}
+		}
+	}
+
+	return results, nil // Return results
+}
+
+// newBatchValidator initializes a new default batch transaction validator reading from a given dag.
+func newBatchValidator(dag *Dag) TransactionValidator {
+	return batchValidator{dag: dag} // Return validator
+}
+
+// checkParentsAccepted checks that none of a given transaction's parents are in a given set of rejected transactions.
+func checkParentsAccepted(transaction *Transaction, rejected map[common.Hash]bool) error {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if rejected[parentHash] { // Check parent rejected
+			return ErrRejectedParent // Return error
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// verifySignatures verifies the signature of every transaction in a given batch not yet rejected via the DefaultSignatureVerifier,
+// setting the result of each transaction with an invalid signature to ErrInvalidSignature.
+func verifySignatures(transactions []*Transaction, results []error) {
+	var pending []int // Init pending tx indexes
+
+	var pendingTransactions []*Transaction // Init pending tx buffer
+
+	for i, transaction := range transactions { // Iterate through transactions
+		if results[i] == nil { // Check not yet rejected
+			pending = append(pending, i)                                   // Append index
+			pendingTransactions = append(pendingTransactions, transaction) // Append transaction
+		}
+	}
+
+	for i, valid := range DefaultSignatureVerifier.VerifyTransactions(pendingTransactions) { // Verify signatures
+		if !valid { // Check transaction signature invalid
+			results[pending[i]] = ErrInvalidSignature // Set result
+		}
+	}
+}
+
+/* END INTERNAL METHODS */
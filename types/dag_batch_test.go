@@ -0,0 +1,164 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+// parentValidator is a minimal transaction validator checking only that transaction parents exist in a dag.
+type parentValidator struct {
+	dag *Dag // Dag to check parents against
+}
+
+// ValidateTransaction validates that a given transaction's parents exist in the validator's dag.
+func (validator parentValidator) ValidateTransaction(transaction *Transaction) error {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if _, err := validator.dag.GetTransactionByHash(parentHash); err != nil { // Check parent missing
+			return err // Return found error
+		}
+	}
+
+	return nil // Transaction is valid
+}
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestAddTransactions tests the functionality of the AddTransactions() and ValidateAndAddTransactions() helper methods.
+func TestAddTransactions(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(crypto.AddressFromPrivateKey(privateKey).Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	parent := genesisTransactions[len(genesisTransactions)-1].Hash // Get last genesis child hash
+
+	transaction := newTestBatchTransaction(t, privateKey, 0, parent)         // Initialize transaction
+	child := newTestBatchTransaction(t, privateKey, 1, transaction.Hash)     // Initialize child of transaction
+	conflicting := newTestBatchTransaction(t, privateKey, 1, parent)         // Initialize sibling sharing a nonce with child
+	invalid := newTestBatchTransaction(t, privateKey, 2, child.Hash)         // Initialize transaction with invalid signature
+	rejectedChild := newTestBatchTransaction(t, privateKey, 3, invalid.Hash) // Initialize child of invalid transaction
+	late := newTestBatchTransaction(t, privateKey, 2, child.Hash)            // Initialize transaction added after its child
+	early := newTestBatchTransaction(t, privateKey, 3, late.Hash)            // Initialize child added before its parent
+
+	invalid.Signature = transaction.Signature // Invalidate signature
+
+	batch := []*Transaction{transaction, child, conflicting, child, nil, invalid, rejectedChild, early, late} // Initialize batch
+
+	expected := []error{nil, nil, ErrInvalidBatchNonce, ErrDuplicateTransaction, ErrNilTransaction, ErrInvalidSignature, ErrRejectedParent, ErrTransactionOutOfOrder, nil} // Initialize expected results
+
+	results, err := dag.AddTransactions(batch) // Add transactions
+	if err != nil {                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	for i, result := range results { // Iterate through results
+		if result != expected[i] { // Check unexpected result
+			t.Fatalf("invalid result for transaction %d; found %v, but wanted %v", i, result, expected[i]) // Panic
+		}
+
+		if expected[i] != nil { // Check not added
+			continue // Continue
+		}
+
+		if _, err = dag.GetTransactionByHash(batch[i].Hash); err != nil { // Check transaction not added
+			t.Fatal(err) // Panic
+		}
+	}
+
+	if _, err = dag.GetTransactionByHash(early.Hash); err == nil { // Check out of order transaction added
+		t.Fatal("transaction preceding its parent in batch should not have been added") // Panic
+	}
+
+	validated := newTestBatchTransaction(t, privateKey, 3, late.Hash)           // Initialize transaction
+	validatedChild := newTestBatchTransaction(t, privateKey, 4, validated.Hash) // Initialize child of transaction
+
+	results, err = dag.ValidateAndAddTransactions([]*Transaction{validated, validatedChild, transaction}, func(batchDag *Dag) TransactionValidator { return parentValidator{dag: batchDag} }) // Validate and add transactions
+	if err != nil {                                                                                                                                                                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if results[0] != nil || results[1] != nil { // Check not added
+		t.Fatal("validator should observe transactions accepted earlier in the batch") // Panic
+	}
+
+	if results[2] != ErrDuplicateTransaction { // Check existing transaction not rejected
+		t.Fatalf("should have returned ErrDuplicateTransaction; got %v", results[2]) // Panic
+	}
+
+	var invalidAmounts []*Transaction // Init invalid amount transactions buffer
+
+	for _, amounts := range [][2]*big.Int{{big.NewInt(-50), big.NewInt(1)}, {big.NewInt(1), nil}} { // Iterate through negative amount and nil gas price
+		transaction := NewTransaction(3, amounts[0], crypto.AddressFromPrivateKey(privateKey), nil, []common.Hash{late.Hash}, 1, amounts[1], []byte("test payload"), 1, time.Time{}) // Initialize transaction
+
+		if err = SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+			t.Fatal(err) // Panic
+		}
+
+		invalidAmounts = append(invalidAmounts, transaction) // Append transaction
+	}
+
+	results, err = dag.AddTransactions(invalidAmounts) // Add transactions
+	if err != nil {                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if results[0] != ErrInvalidBatchAmount || results[1] != ErrInvalidBatchAmount { // Check invalid amounts not rejected
+		t.Fatalf("transactions with nil or negative amounts should be rejected; got %v", results) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// newTestBatchTransaction initializes a new transaction with a given nonce and parent, signed by a given private key.
+func newTestBatchTransaction(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, parent common.Hash) *Transaction {
+	transaction := NewTransaction(
+		nonce,                                    // Nonce
+		big.NewInt(1),                            // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		nil,                                      // Recipient
+		[]common.Hash{parent},                    // Parents
+		1,                                        // Gas limit
+		big.NewInt(1),                            // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new transaction
+
+	if err := SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	return transaction // Return signed transaction
+}
+
+/* END INTERNAL METHODS TESTS */
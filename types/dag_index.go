@@ -0,0 +1,167 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+// indexVersion is the current version of the dag db secondary indexes.
+// Incrementing indexVersion forces all existing dag dbs to rebuild their indexes (and account state) when opened.
+const indexVersion = uint64(1)
+
+var (
+	childrenIndexBucket  = []byte("transaction-children-bucket")  // Parent hash + child hash => nil
+	senderIndexBucket    = []byte("transaction-sender-bucket")    // Sender address + tx hash => nil
+	recipientIndexBucket = []byte("transaction-recipient-bucket") // Recipient address + tx hash => nil
+	tipsIndexBucket      = []byte("transaction-tips-bucket")      // Hash of tx without children => nil
+	metaBucket           = []byte("dag-meta-bucket")              // Dag db metadata
+
+	indexVersionKey = []byte("index-version") // Index version meta key
+)
+
+var (
+	// ErrLegacyDagDb is an error definition representing a dag db written before the canonical transaction encoding.
+	// Transactions in such a db are stored under legacy (json) hashes, and can't be migrated; the dag must be synced or imported into a new db.
+	ErrLegacyDagDb = errors.New("dag db predates the canonical transaction encoding; sync or import the dag into a new db")
+)
+
+/* BEGIN INTERNAL METHODS */
+
+/*
+	BEGIN INDEX HELPER METHODS
+*/
+
+// indexTransaction writes the child, sender, recipient and tip index entries for a given transaction in the given batch.
+func indexTransaction(batch storage.Batch, transaction *Transaction) error {
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		err := batch.Put(childrenIndexBucket, indexKey(parentHash.Bytes(), transaction.Hash), []byte{}) // Put child index entry
+		if err != nil {                                                                                 // Check for errors
+			return err // Return found error
+		}
+
+		if err = batch.Delete(tipsIndexBucket, parentHash.Bytes()); err != nil { // Parent is no longer a tip
+			return err // Return found error
+		}
+	}
+
+	if len(queryIndex(batch, childrenIndexBucket, transaction.Hash.Bytes())) == 0 { // Check no children indexed before transaction
+		if err := batch.Put(tipsIndexBucket, transaction.Hash.Bytes(), []byte{}); err != nil { // Put tip index entry
+			return err // Return found error
+		}
+	}
+
+	if transaction.Sender != nil { // Check has sender
+		err := batch.Put(senderIndexBucket, indexKey(transaction.Sender.Bytes(), transaction.Hash), []byte{}) // Put sender index entry
+		if err != nil {                                                                                       // Check for errors
+			return err // Return found error
+		}
+	}
+
+	if transaction.Recipient != nil { // Check has recipient
+		return batch.Put(recipientIndexBucket, indexKey(transaction.Recipient.Bytes(), transaction.Hash), []byte{}) // Put recipient index entry
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// queryIndex returns the hashes of all transactions indexed under the given prefix in a given index bucket.
+func queryIndex(reader storage.Reader, bucket []byte, prefix []byte) []common.Hash {
+	var hashes []common.Hash // Init hash buffer
+
+	reader.Iterate(bucket, prefix, func(key []byte, _ []byte) error {
+		hashes = append(hashes, common.NewHash(key[len(prefix):])) // Append hash
+
+		return nil // No error occurred, return nil
+	}) // Iterate through keys with prefix
+
+	return hashes // Return hashes
+}
+
+// transactionsAtHashes reads the transactions stored at each of the given hashes, sorted by hash.
+// Duplicate hashes are only read once.
+func transactionsAtHashes(reader storage.Reader, hashes []common.Hash) []*Transaction {
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i].Bytes(), hashes[j].Bytes()) < 0
+	}) // Sort hashes
+
+	transactions := []*Transaction{} // Init tx buffer
+
+	for i, hash := range hashes { // Iterate through hashes
+		if i > 0 && hash == hashes[i-1] { // Check duplicate
+			continue // Continue
+		}
+
+		if transactionBytes := reader.Get(transactionBucket, hash.Bytes()); transactionBytes != nil { // Check transaction exists
+			transactions = append(transactions, TransactionFromBytes(transactionBytes)) // Append transaction
+		}
+	}
+
+	return transactions // Return transactions
+}
+
+// migrateIndexes builds the secondary indexes and account state of a given dag db if they were built with an older index version (or not at all).
+func migrateIndexes(db storage.Storage) error {
+	return db.Batch(func(batch storage.Batch) error {
+		if version := batch.Get(metaBucket, indexVersionKey); version != nil && binary.BigEndian.Uint64(version) >= indexVersion { // Check indexes up to date
+			return nil // Nothing to migrate
+		}
+
+		logger.Infof("building dag db indexes (version %d)", indexVersion) // Log migrate
+
+		for _, bucket := range [][]byte{childrenIndexBucket, senderIndexBucket, recipientIndexBucket, tipsIndexBucket} { // Iterate through index buckets
+			if err := batch.DeleteBucket(bucket); err != nil { // Drop stale index
+				return err // Return found error
+			}
+		}
+
+		indexed := 0 // Init indexed counter
+
+		err := batch.Iterate(transactionBucket, nil, func(hash []byte, transactionBytes []byte) error {
+			if len(transactionBytes) == 0 || transactionBytes[0] != transactionEncodingVersion { // Check legacy transaction
+				return ErrLegacyDagDb // Return error
+			}
+
+			transaction := TransactionFromBytes(transactionBytes) // Deserialize transaction
+
+			transaction.Hash = common.NewHash(hash) // Index under stored key
+
+			indexed++ // Increment indexed
+
+			return indexTransaction(batch, transaction) // Index transaction
+		}) // Index all existing transactions
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		logger.Infof("finished indexing %d transactions", indexed) // Log finished
+
+		if _, err = rebuildAccountState(batch); err != nil { // Build account state
+			return err // Return found error
+		}
+
+		version := make([]byte, 8) // Init version buffer
+
+		binary.BigEndian.PutUint64(version, indexVersion) // Encode version
+
+		return batch.Put(metaBucket, indexVersionKey, version) // Set index version
+	})
+}
+
+// indexKey concatenates a given index prefix and transaction hash into an index key.
+func indexKey(prefix []byte, hash common.Hash) []byte {
+	return append(append([]byte{}, prefix...), hash.Bytes()...) // Return key
+}
+
+/*
+	END INDEX HELPER METHODS
+*/
+
+/* END INTERNAL METHODS */
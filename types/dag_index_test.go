@@ -0,0 +1,156 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// TestMigrateIndexes tests the functionality of the migrateIndexes() helper method.
+func TestMigrateIndexes(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
+
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction := NewTransaction(
+		0,                                        // Nonce
+		big.NewInt(0),                            // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		crypto.AddressFromPrivateKey(privateKey), // Recipient
+		nil,                                      // Parents
+		1,                                        // Gas limit
+		big.NewInt(1000),                         // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new transaction
+
+	child := NewTransaction(
+		1,                                        // Nonce
+		big.NewInt(0),                            // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		nil,                                      // Recipient
+		[]common.Hash{transaction.Hash},          // Parents
+		1,                                        // Gas limit
+		big.NewInt(1000),                         // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new child transaction
+
+	err = dag.db.Batch(func(batch storage.Batch) error {
+		for _, bucket := range [][]byte{childrenIndexBucket, senderIndexBucket, recipientIndexBucket, tipsIndexBucket, metaBucket} { // Iterate through index buckets
+			if err := batch.DeleteBucket(bucket); err != nil { // Drop index, simulating a dag db written before indexing
+				return err // Return found error
+			}
+		}
+
+		if err := batch.Put(transactionBucket, transaction.Hash.Bytes(), transaction.Bytes()); err != nil { // Put transaction without indexing
+			return err // Return found error
+		}
+
+		return batch.Put(transactionBucket, child.Hash.Bytes(), child.Bytes()) // Put child without indexing
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	err = migrateIndexes(dag.db) // Build indexes
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	children, err := dag.GetTransactionChildren(transaction.Hash) // Get children
+	if err != nil {                                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(children) != 1 || children[0].Hash != child.Hash { // Check invalid children
+		t.Fatalf("should have found 1 indexed child transaction; found %d", len(children)) // Panic
+	}
+
+	tips, err := dag.GetTips() // Get tips
+	if err != nil {            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(tips) != 1 || tips[0].Hash != child.Hash { // Check invalid tips
+		t.Fatalf("should have found 1 indexed tip; found %d", len(tips)) // Panic
+	}
+
+	transactions, err := dag.GetTransactionsBySender(crypto.AddressFromPrivateKey(privateKey)) // Get transactions from sender
+	if err != nil {                                                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(transactions) != 2 { // Check invalid tx set
+		t.Fatalf("should have found 2 indexed transactions; found %d", len(transactions)) // Panic
+	}
+
+	transactions, err = dag.GetTransactionsByAddress(crypto.AddressFromPrivateKey(privateKey)) // Get transactions related to address
+	if err != nil {                                                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(transactions) != 2 { // Check sent-to-self transaction counted once
+		t.Fatalf("should have found 2 related transactions; found %d", len(transactions)) // Panic
+	}
+
+	err = dag.db.Batch(func(batch storage.Batch) error {
+		if err := batch.DeleteBucket(metaBucket); err != nil { // Drop index version, simulating a dag db written before indexing
+			return err // Return found error
+		}
+
+		legacyTransaction, err := json.Marshal(struct {
+			*Transaction
+
+			Amount *big.Float `json:"amount"` // Legacy amount (in whole units)
+		}{child, big.NewFloat(1.5)}) // Marshal child as json, simulating a dag db written before the canonical encoding
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		return batch.Put(transactionBucket, crypto.Sha3(legacyTransaction).Bytes(), legacyTransaction) // Put legacy transaction
+	})
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if err = migrateIndexes(dag.db); err != ErrLegacyDagDb { // Build indexes
+		t.Fatalf("should have returned ErrLegacyDagDb; got %v", err) // Panic
+	}
+
+	if tips, err = dag.GetTips(); err != nil || len(tips) != 1 { // Get tips
+		t.Fatal("refused migration should not drop existing indexes") // Panic
+	}
+}
+
+/* END INTERNAL METHODS TESTS */
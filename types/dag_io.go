@@ -21,23 +21,28 @@ func (dag *Dag) Bytes() []byte {
 }
 
 // WriteToMemory writes the dag header to persistent memory.
+// Ephemeral dags (those without a db directory) are not written.
 func (dag *Dag) WriteToMemory() error {
-	err := common.CreateDirIfDoesNotExist(common.DbDir) // Create db dir if necessary
-	if err != nil {                                     // Check for errors
+	if dag.dbDir == "" { // Check header not persisted
+		return nil // Nothing to write
+	}
+
+	err := common.CreateDirIfDoesNotExist(dag.dbDir) // Create db dir if necessary
+	if err != nil {                                  // Check for errors
 		return err // Return found error
 	}
 
-	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/db_header_%s.json", common.DbDir, dag.DagConfig.Identifier)), dag.Bytes(), 0o644) // Write dag header to persistent memory
+	return ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/db_header_%s.json", dag.dbDir, dag.DagConfig.Identifier)), dag.Bytes(), 0o644) // Write dag header to persistent memory
 }
 
 /* END EXPORTED METHODS */
 
 /* BEGIN INTERNAL METHODS */
 
-// readDagDbHeaderFromMemory attempts to read the dag db header with the given identifier from persistent memory.
-func readDagDbHeaderFromMemory(identifier string) (*Dag, error) {
-	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/db_header_%s.json", common.DbDir, identifier))) // Read header
-	if err != nil {                                                                                                 // Check for errors
+// readDagDbHeaderFromMemory attempts to read the dag db header with the given identifier from the given db directory.
+func readDagDbHeaderFromMemory(dbDir string, identifier string) (*Dag, error) {
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/db_header_%s.json", dbDir, identifier))) // Read header
+	if err != nil {                                                                                          // Check for errors
 		return &Dag{}, err // Return found error
 	}
 
@@ -49,6 +54,8 @@ func readDagDbHeaderFromMemory(identifier string) (*Dag, error) {
 		return &Dag{}, err // Return found error
 	}
 
+	buffer.dbDir = dbDir // Set db dir
+
 	return buffer, nil // No error occurred, retrun nil
 }
 
@@ -4,6 +4,8 @@ package types
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/polaris-project/go-polaris/config"
@@ -13,16 +15,25 @@ import (
 
 // TestBytesDag tests the functionality of the dag Bytes() helper method.
 func TestBytesDag(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
-	t.Log(dag.Bytes()) // Log dag bytes
+	defer dag.Close() // Close dag
 
-	WorkingDagDB.Close() // Close dag db
+	t.Log(dag.Bytes()) // Log dag bytes
 }
 
 /* END EXPORTED METHODS TESTS */
@@ -31,47 +42,65 @@ func TestBytesDag(t *testing.T) {
 
 // TestReadDagDbHeaderFromMemory tests the functionality of the readDagDbHeaderFromMemory() helper method.
 func TestReadDagDbHeaderFromMemory(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	err = dag.WriteToMemory() // Write dag db header to persistent memory
 
 	if err != nil { // Check for errors
 		t.Fatal(err) // Panic
 	}
 
-	readDag, err := readDagDbHeaderFromMemory(dag.DagConfig.Identifier) // Read dag db header
-	if err != nil {                                                     // Check for errors
+	readDag, err := readDagDbHeaderFromMemory(dbDir, dag.DagConfig.Identifier) // Read dag db header
+	if err != nil {                                                            // Check for errors
 		t.Fatal(err) // Panic
 	}
 
 	if !bytes.Equal(readDag.Bytes(), dag.Bytes()) { // Check dags not equivalent
 		t.Fatal("dags should be equivalent") // Panic
 	}
-
-	WorkingDagDB.Close() // Close dag db
 }
 
 // TestWriteToMemoryDagDbHeader tests the functionality of the writeToMemory() dag db header helper.
 func TestWriteToMemoryDagDbHeader(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                           // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	err = dag.WriteToMemory() // Write dag db header to persistent memory
 
 	if err != nil { // Check for errors
 		t.Fatal(err) // Panic
 	}
-
-	WorkingDagDB.Close() // Close working dag db
 }
 
 /* END INTERNAL METHODS TESTS */
@@ -8,45 +8,127 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/hex"
+	"io/ioutil"
 	"math/big"
 	"os"
-	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
 	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 )
 
 /* BEGIN EXPORTED METHODS TESTS */
 
 // TestNewDag tests the functionality of the NewDag() method.
 func TestNewDag(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	_, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {             // Check for errors
+	dag, err := NewDagInDir(dagConfig, dbDir) // Initialize dag with dag config
+	if err != nil {                           // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+}
+
+// TestOpenDagInDir tests the functionality of the OpenDagInDir() method, with several dags opened at once.
+func TestOpenDagInDir(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
+	dag, err := NewDagInDir(config.NewDagConfig(nil, "test_network", 1), dbDir) // Initialize dag
+	if err != nil {                                                             // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	otherDag, err := NewDagInDir(config.NewDagConfig(nil, "other_test_network", 2), dbDir) // Initialize second dag in same process
+	if err != nil {                                                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer otherDag.Close() // Close second dag
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction := NewTransaction(
+		0,                                        // Nonce
+		big.NewInt(0),                            // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		nil,                                      // Recipient
+		nil,                                      // Parents
+		1,                                        // Gas limit
+		big.NewInt(1000),                         // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
+	) // Create new transaction
+
+	err = dag.AddGenesisTransaction(transaction) // Add transaction to first dag
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = otherDag.GetTransactionByHash(transaction.Hash); err != ErrNilTransactionAtHash { // Check transaction leaked into second dag
+		t.Fatal("transaction should only exist in the dag it was added to") // Panic
+	}
+
+	err = dag.Close() // Close first dag
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	reopenedDag, err := OpenDagInDir("test_network", dbDir) // Reopen first dag
+	if err != nil {                                         // Check for errors
 		t.Fatal(err) // Panic
 	}
 
-	WorkingDagDB.Close() // Close dag db
+	defer reopenedDag.Close() // Close reopened dag
 
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	if reopenedDag.Genesis != transaction.Hash { // Check header not persisted
+		t.Fatal("reopened dag should have persisted genesis") // Panic
+	}
+
+	if _, err = reopenedDag.GetTransactionByHash(transaction.Hash); err != nil { // Check transaction not persisted
+		t.Fatal(err) // Panic
+	}
 }
 
 // TestGetTransactionByHash tests the functionality of the GetTransactionByHash() helper method.
 func TestGetTransactionByHash(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -54,13 +136,15 @@ func TestGetTransactionByHash(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -80,23 +164,21 @@ func TestGetTransactionByHash(t *testing.T) {
 	if err != nil { // Check for errors
 		t.Fatal(err) // Panic
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestGetTransactionByAddress tests the functionality of the GetTransactionByAddress() helper method.
 func TestGetTransactionByAddress(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -104,13 +186,15 @@ func TestGetTransactionByAddress(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -133,23 +217,21 @@ func TestGetTransactionByAddress(t *testing.T) {
 	if len(transactions) != 1 { // Check invalid tx set
 		t.Fatalf("should have found 1 related transaction; found %d", len(transactions)) // Log invalid filter
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestGetTransactionsBySender tests the functionality of the GetTransactionBySender() helper method.
 func TestGetTransactionsBySender(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -157,13 +239,15 @@ func TestGetTransactionsBySender(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -186,23 +270,21 @@ func TestGetTransactionsBySender(t *testing.T) {
 	if len(transactions) != 1 { // Check invalid tx set
 		t.Fatalf("should have found 1 related transaction; found %d", len(transactions)) // Log invalid filter
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestGetTransactionChildren tests the functionality of the GetTransactionChildren() helper method.
 func TestGetTransactionChildren(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -210,13 +292,15 @@ func TestGetTransactionChildren(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -233,13 +317,15 @@ func TestGetTransactionChildren(t *testing.T) {
 
 	child := NewTransaction(
 		1,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		[]common.Hash{transaction.Hash},          // Set parent hash
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new child transaction
 
 	err = SignTransaction(child, privateKey) // Sign transaction
@@ -262,23 +348,21 @@ func TestGetTransactionChildren(t *testing.T) {
 	if len(children) != 1 { // Check invalid tx set
 		t.Fatalf("should have found 1 child transaction; found %d", len(children)) // Log invalid filter
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestGetBestTransaction tests the functionality of the GetBestTransaction() helper method.
 func TestGetBestTransaction(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -286,13 +370,15 @@ func TestGetBestTransaction(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -311,13 +397,15 @@ func TestGetBestTransaction(t *testing.T) {
 
 	child := NewTransaction(
 		1,                                        // Nonce
-		big.NewFloat(0),                          // Amount
+		big.NewInt(0),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		crypto.AddressFromPrivateKey(privateKey), // Recipient
 		[]common.Hash{transaction.Hash},          // Set parent hash
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new child transaction
 
 	err = SignTransaction(child, privateKey) // Sign transaction
@@ -340,23 +428,21 @@ func TestGetBestTransaction(t *testing.T) {
 	if !bytes.Equal(bestTransaction.Hash.Bytes(), child.Hash.Bytes()) { // Check invalid best tx
 		t.Fatalf("invalid best transaction; found %s, but wanted %s", hex.EncodeToString(bestTransaction.Hash.Bytes()), child.Hash.Bytes()) // Log invalid best transaction
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestCalculateAddressBalance tests the functionality of the CalculateAddressBalance() helper method.
 func TestCalculateAddressBalance(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {               // Check for errors
+	dag, err := NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                          // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -364,13 +450,15 @@ func TestCalculateAddressBalance(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                                        // Nonce
-		big.NewFloat(1),                          // Amount
+		big.NewInt(1),                            // Amount
 		crypto.AddressFromPrivateKey(privateKey), // Sender
 		nil,                                      // Recipient
 		nil,                                      // Parents
 		1,                                        // Gas limit
 		big.NewInt(1000),                         // Gas price
 		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Create new transaction
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
@@ -390,13 +478,9 @@ func TestCalculateAddressBalance(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	if balance.Cmp(big.NewFloat(-1001.0)) != 0 { // Check invalid balance
+	if balance.Cmp(big.NewInt(-1001)) != 0 { // Check invalid balance
 		t.Fatal("invalid balance calculation") // Panic
 	}
-
-	WorkingDagDB.Close() // Close working dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 /* END EXPORTED METHODS TESTS */
@@ -0,0 +1,253 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"bytes"
+	"container/heap"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+/* BEGIN EXPORTED METHODS */
+
+// Walk calls a given function for each transaction descending from the dag genesis (including the genesis itself),
+// in topological order: every transaction is visited after all of its parents.
+// Transactions whose parents are all visited at once are visited by timestamp, then by hash, so the order is deterministic.
+// The walk is stopped, and the error returned, as soon as fn returns an error.
+// The dag db is not held open while fn runs, so fn may safely read from or write to the dag.
+func (dag *Dag) Walk(fn func(transaction *Transaction) error) error {
+	if dag.db == nil { // Check no dag db
+		return ErrDagDbNotOpened // Return found error
+	}
+
+	if dag.Genesis.IsNil() { // Check no genesis
+		return ErrNilGenesis // Return found error
+	}
+
+	genesis, err := dag.GetTransactionByHash(dag.Genesis) // Get genesis
+	if err != nil {                                       // Check for errors
+		return err // Return found error
+	}
+
+	pending := make(map[common.Hash]int) // Number of unvisited parents per discovered transaction
+
+	ready := &transactionHeap{genesis} // Init ready heap
+
+	for ready.Len() > 0 { // Do until no ready transactions
+		transaction := heap.Pop(ready).(*Transaction) // Pop earliest ready transaction
+
+		if err = fn(transaction); err != nil { // Visit transaction
+			return err // Return found error
+		}
+
+		var children []*Transaction // Init children buffer
+
+		err = dag.db.View(func(reader storage.Reader) error {
+			children = transactionsAtHashes(reader, queryIndex(reader, childrenIndexBucket, transaction.Hash.Bytes())) // Get children
+
+			for _, child := range children { // Iterate through children
+				if _, discovered := pending[child.Hash]; !discovered { // Check first time child seen
+					pending[child.Hash] = countStoredParents(reader, child) // Set pending
+				}
+			}
+
+			return nil // No error occurred, return nil
+		})
+
+		if err != nil { // Check for errors
+			return err // Return found error
+		}
+
+		for _, child := range children { // Iterate through children
+			if pending[child.Hash]--; pending[child.Hash] == 0 { // Check all parents visited
+				delete(pending, child.Hash) // Forget child
+
+				heap.Push(ready, child) // Push ready child
+			}
+		}
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// GetAncestors gets the ancestors of the transaction with the given hash, up to a given depth (a depth of 1 returns its parents).
+// If depth is negative, all ancestors are returned.
+// Ancestors are sorted by depth, then by hash.
+// Returns an ErrNilTransactionAtHash error if no transaction exists at the given hash.
+func (dag *Dag) GetAncestors(transactionHash common.Hash, depth int) ([]*Transaction, error) {
+	return dag.collectRelatives(transactionHash, depth, func(_ storage.Reader, transaction *Transaction) []common.Hash {
+		return transaction.ParentTransactions // Return parents
+	}) // Collect ancestors
+}
+
+// GetDescendants gets the descendants of the transaction with the given hash, up to a given depth (a depth of 1 returns its children).
+// If depth is negative, all descendants are returned.
+// Descendants are sorted by depth, then by hash.
+// Returns an ErrNilTransactionAtHash error if no transaction exists at the given hash.
+func (dag *Dag) GetDescendants(transactionHash common.Hash, depth int) ([]*Transaction, error) {
+	return dag.collectRelatives(transactionHash, depth, func(reader storage.Reader, transaction *Transaction) []common.Hash {
+		return queryIndex(reader, childrenIndexBucket, transaction.Hash.Bytes()) // Return children
+	}) // Collect descendants
+}
+
+// IsAncestor checks whether the transaction at a given ancestor hash is an ancestor of the transaction at a given descendant hash.
+// A transaction is not its own ancestor.
+// Returns an ErrNilTransactionAtHash error if either transaction does not exist.
+func (dag *Dag) IsAncestor(ancestorHash common.Hash, descendantHash common.Hash) (bool, error) {
+	if dag.db == nil { // Check no dag db
+		return false, ErrDagDbNotOpened // Return found error
+	}
+
+	isAncestor := false // Init result buffer
+
+	err := dag.db.View(func(reader storage.Reader) error {
+		if reader.Get(transactionBucket, ancestorHash.Bytes()) == nil || reader.Get(transactionBucket, descendantHash.Bytes()) == nil { // Check transactions exist
+			return ErrNilTransactionAtHash // Return error
+		}
+
+		visited := map[common.Hash]bool{ancestorHash: true} // Init visited set
+
+		queue := []common.Hash{ancestorHash} // Init search queue
+
+		for len(queue) > 0 { // Do until searched all descendants
+			hash := queue[0] // Get next hash
+
+			queue = queue[1:] // Dequeue
+
+			for _, childHash := range queryIndex(reader, childrenIndexBucket, hash.Bytes()) { // Iterate through children (keys only, no transactions are decoded)
+				if childHash == descendantHash { // Check found descendant
+					isAncestor = true // Set is ancestor
+
+					return nil // Stop searching
+				}
+
+				if !visited[childHash] { // Check not yet visited
+					visited[childHash] = true // Mark visited
+
+					queue = append(queue, childHash) // Enqueue child
+				}
+			}
+		}
+
+		return nil // No error occurred, return nil
+	})
+
+	return isAncestor, err // Return result
+}
+
+// GetTips gets all transactions in the dag without any children, sorted by hash.
+func (dag *Dag) GetTips() ([]*Transaction, error) {
+	if dag.db == nil { // Check no dag db
+		return []*Transaction{}, ErrDagDbNotOpened // Return found error
+	}
+
+	transactions := []*Transaction{} // Init tx buffer
+
+	err := dag.db.View(func(reader storage.Reader) error {
+		transactions = transactionsAtHashes(reader, queryIndex(reader, tipsIndexBucket, nil)) // Get tips
+
+		return nil // No error occurred, return nil
+	})
+
+	return transactions, err // Return tips
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// collectRelatives collects the transactions reachable from the transaction with the given hash through a given relatives function,
+// up to a given depth (all reachable transactions if negative), level by level.
+func (dag *Dag) collectRelatives(transactionHash common.Hash, depth int, relatives func(reader storage.Reader, transaction *Transaction) []common.Hash) ([]*Transaction, error) {
+	if dag.db == nil { // Check no dag db
+		return []*Transaction{}, ErrDagDbNotOpened // Return found error
+	}
+
+	collected := []*Transaction{} // Init tx buffer
+
+	err := dag.db.View(func(reader storage.Reader) error {
+		transactionBytes := reader.Get(transactionBucket, transactionHash.Bytes()) // Get root transaction
+
+		if transactionBytes == nil { // Check no transaction at hash
+			return ErrNilTransactionAtHash // Return error
+		}
+
+		visited := map[common.Hash]bool{transactionHash: true} // Init visited set
+
+		level := []*Transaction{TransactionFromBytes(transactionBytes)} // Init current level
+
+		for currentDepth := 0; len(level) > 0 && (depth < 0 || currentDepth < depth); currentDepth++ { // Do until no more relatives, or reached depth
+			var hashes []common.Hash // Init next level hash buffer
+
+			for _, transaction := range level { // Iterate through current level
+				for _, hash := range relatives(reader, transaction) { // Iterate through relatives
+					if !visited[hash] { // Check not yet visited
+						visited[hash] = true // Mark visited
+
+						hashes = append(hashes, hash) // Append hash
+					}
+				}
+			}
+
+			level = transactionsAtHashes(reader, hashes) // Get next level
+
+			collected = append(collected, level...) // Append level
+		}
+
+		return nil // No error occurred, return nil
+	})
+
+	if err != nil { // Check for errors
+		return []*Transaction{}, err // Return found error
+	}
+
+	return collected, nil // Return collected transactions
+}
+
+// countStoredParents counts the distinct parents of a given transaction that exist in the dag db.
+func countStoredParents(reader storage.Reader, transaction *Transaction) int {
+	counted := make(map[common.Hash]bool) // Init counted set
+
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		if !counted[parentHash] && reader.Get(transactionBucket, parentHash.Bytes()) != nil { // Check stored, not yet counted parent
+			counted[parentHash] = true // Mark counted
+		}
+	}
+
+	return len(counted) // Return count
+}
+
+// transactionHeap is a min-heap of transactions ordered by timestamp, then hash.
+type transactionHeap []*Transaction
+
+// Len returns the number of transactions in the heap.
+func (h transactionHeap) Len() int { return len(h) }
+
+// Less checks whether the transaction at index i should be sorted before the transaction at index j.
+func (h transactionHeap) Less(i, j int) bool {
+	if !h[i].Timestamp.Equal(h[j].Timestamp) { // Check different timestamps
+		return h[i].Timestamp.Before(h[j].Timestamp) // Earliest first
+	}
+
+	return bytes.Compare(h[i].Hash.Bytes(), h[j].Hash.Bytes()) < 0 // Lowest hash first
+}
+
+// Swap swaps the transactions at indexes i and j.
+func (h transactionHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+// Push appends a given transaction to the heap.
+func (h *transactionHeap) Push(x interface{}) { *h = append(*h, x.(*Transaction)) }
+
+// Pop removes the last transaction from the heap.
+func (h *transactionHeap) Pop() interface{} {
+	old := *h                      // Get heap
+	transaction := old[len(old)-1] // Get last
+
+	*h = old[:len(old)-1] // Shrink heap
+
+	return transaction // Return last
+}
+
+/* END INTERNAL METHODS */
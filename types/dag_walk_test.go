@@ -0,0 +1,172 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/config"
+	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestWalk tests the functionality of the Walk(), GetAncestors(), GetDescendants(), IsAncestor() and GetTips() methods.
+func TestWalk(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dag, transactions := newTestDiamondDag(t) // Initialize diamond dag
+
+	defer dag.Close() // Close dag
+
+	genesis, a, b, c, d := transactions[0], transactions[1], transactions[2], transactions[3], transactions[4] // Get transactions
+
+	visited := make(map[common.Hash]bool) // Init visited set
+
+	err := dag.Walk(func(transaction *Transaction) error {
+		for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+			if !visited[parentHash] { // Check parent not yet visited
+				t.Errorf("transaction %x visited before its parent %x", transaction.Hash.Bytes(), parentHash.Bytes()) // Log error
+			}
+		}
+
+		if visited[transaction.Hash] { // Check already visited
+			t.Errorf("transaction %x visited twice", transaction.Hash.Bytes()) // Log error
+		}
+
+		visited[transaction.Hash] = true // Mark visited
+
+		return nil // No error occurred, return nil
+	}) // Walk dag
+
+	if err != nil { // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if len(visited) != len(transactions) { // Check not all visited
+		t.Fatalf("should have visited %d transactions; visited %d", len(transactions), len(visited)) // Panic
+	}
+
+	checkHashes := func(found []*Transaction, err error, wanted ...*Transaction) {
+		if err != nil { // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if len(found) != len(wanted) { // Check invalid count
+			t.Fatalf("should have found %d transactions; found %d", len(wanted), len(found)) // Panic
+		}
+
+		for _, transaction := range wanted { // Iterate through wanted transactions
+			contains := false // Init contains buffer
+
+			for _, foundTransaction := range found { // Iterate through found transactions
+				contains = contains || foundTransaction.Hash == transaction.Hash // Check found
+			}
+
+			if !contains { // Check missing transaction
+				t.Fatalf("should have found transaction %x", transaction.Hash.Bytes()) // Panic
+			}
+		}
+	}
+
+	ancestors, err := dag.GetAncestors(c.Hash, 1) // Get parents
+	checkHashes(ancestors, err, a, b)             // Check parents
+
+	ancestors, err = dag.GetAncestors(c.Hash, -1) // Get all ancestors
+	checkHashes(ancestors, err, a, b, genesis)    // Check ancestors
+
+	if ancestors[2].Hash != genesis.Hash { // Check not sorted by depth
+		t.Fatal("ancestors should be sorted by depth") // Panic
+	}
+
+	descendants, err := dag.GetDescendants(genesis.Hash, 1) // Get children
+	checkHashes(descendants, err, a, b)                     // Check children
+
+	descendants, err = dag.GetDescendants(a.Hash, -1) // Get all descendants
+	checkHashes(descendants, err, c, d)               // Check descendants
+
+	if _, err = dag.GetDescendants(common.NewHash([]byte("missing")), -1); err != ErrNilTransactionAtHash { // Check missing transaction
+		t.Fatalf("should have returned ErrNilTransactionAtHash; got %v", err) // Panic
+	}
+
+	for _, testCase := range []struct {
+		ancestor, descendant *Transaction // Transactions
+
+		isAncestor bool // Expected result
+	}{
+		{genesis, c, true},
+		{b, c, true},
+		{b, d, false},
+		{c, genesis, false},
+		{c, c, false},
+	} { // Iterate through test cases
+		isAncestor, err := dag.IsAncestor(testCase.ancestor.Hash, testCase.descendant.Hash) // Check is ancestor
+		if err != nil {                                                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		if isAncestor != testCase.isAncestor { // Check invalid result
+			t.Errorf("IsAncestor(%x, %x) should be %t", testCase.ancestor.Hash.Bytes(), testCase.descendant.Hash.Bytes(), testCase.isAncestor) // Log error
+		}
+	}
+
+	tips, err := dag.GetTips()   // Get tips
+	checkHashes(tips, err, c, d) // Check tips
+}
+
+/* END EXPORTED METHODS TESTS */
+
+/* BEGIN INTERNAL METHODS TESTS */
+
+// newTestDiamondDag initializes an in-memory dag with a genesis G, children A and B of G, a child C of both A and B, and a child D of A.
+// The returned transactions are ordered G, A, B, C, D.
+func newTestDiamondDag(t *testing.T) (*Dag, []*Transaction) {
+	dag, err := NewDagWithStorage(config.NewDagConfig(nil, "test_network", 1), storage.NewMemoryStorage(), "") // Initialize in-memory dag
+	if err != nil {                                                                                            // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	genesis := NewTransaction(0, big.NewInt(0), nil, crypto.AddressFromPrivateKey(privateKey), nil, 0, big.NewInt(0), []byte("genesis"), 1, time.Time{}) // Initialize genesis transaction
+
+	if err = dag.AddGenesisTransaction(genesis); err != nil { // Add genesis
+		t.Fatal(err) // Panic
+	}
+
+	transactions := []*Transaction{genesis} // Init tx buffer
+
+	for i, parents := range [][]int{{0}, {0}, {1, 2}, {1}} { // Iterate through parent indexes of A, B, C and D
+		var parentHashes []common.Hash // Init parent hash buffer
+
+		for _, parent := range parents { // Iterate through parent indexes
+			parentHashes = append(parentHashes, transactions[parent].Hash) // Append parent hash
+		}
+
+		transaction := NewTransaction(uint64(i), big.NewInt(0), crypto.AddressFromPrivateKey(privateKey), nil, parentHashes, 0, big.NewInt(0), []byte("test payload"), 1, time.Time{}) // Initialize transaction
+
+		if err = SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+			t.Fatal(err) // Panic
+		}
+
+		if err = dag.AddTransaction(transaction); err != nil { // Add transaction
+			t.Fatal(err) // Panic
+		}
+
+		transactions = append(transactions, transaction) // Append transaction
+	}
+
+	return dag, transactions // Return dag
+}
+
+/* END INTERNAL METHODS TESTS */
@@ -28,9 +28,11 @@ var (
 // Transaction is a data type representing a transfer of monetary value between addresses.
 // A transactions does not necessarily imply the transfer of value between human peers, but also contracts.
 type Transaction struct {
+	Network uint64 `json:"network" gencodec:"required"` // ID of the network the transaction is valid on (see config.DagConfig.Network)
+
 	AccountNonce uint64 `json:"nonce" gencodec:"required"` // Index in account transaction list
 
-	Amount *big.Float `json:"amount" gencodec:"required"` // Transaction value
+	Amount *big.Int `json:"amount" gencodec:"required"` // Transaction value (in base units)
 
 	Sender    *common.Address `json:"sender" gencodec:"required"`    // Transaction sender
 	Recipient *common.Address `json:"recipient" gencodec:"required"` // Transaction recipient
@@ -42,18 +44,24 @@ type Transaction struct {
 
 	Payload []byte `json:"payload" gencodec:"required"` // Data sent with transaction (i.e. contract bytecode, message, etc...)
 
-	Signature *Signature `json:"signature" gencodec:"required"` // ECDSA transaction signature
+	Signature *Signature `json:"signature" gencodec:"required"` // Transaction signature (nil if sent from a multi-signature address)
+
+	Multisig *MultisigSignature `json:"multisig,omitempty"` // Multi-signature transaction signatures (nil unless sent from a multi-signature address)
 
 	Timestamp time.Time `json:"timestamp" gencodec:"required"` // Transaction timestamp
 
+	ValidUntil time.Time `json:"valid_until"` // Time after which the transaction can no longer be added to the dag (never expires if zero)
+
 	Hash common.Hash `json:"hash" gencodec:"required"` // Transaction hash
 }
 
 /* BEGIN EXPORTED METHODS */
 
-// NewTransaction creates a new transaction with the given account nonce, value, sender, recipient, gas price, gas limit, and payload.
-func NewTransaction(accountNonce uint64, amount *big.Float, sender, recipient *common.Address, parentTransactions []common.Hash, gasLimit uint64, gasPrice *big.Int, payload []byte) *Transaction {
+// NewTransaction creates a new transaction with the given account nonce, value, sender, recipient, gas price, gas limit, payload,
+// network ID and expiry. A zero validUntil time creates a transaction that never expires.
+func NewTransaction(accountNonce uint64, amount *big.Int, sender, recipient *common.Address, parentTransactions []common.Hash, gasLimit uint64, gasPrice *big.Int, payload []byte, network uint64, validUntil time.Time) *Transaction {
 	transaction := &Transaction{
+		Network:            network,            // Set network
 		AccountNonce:       accountNonce,       // Set account nonce
 		Amount:             amount,             // Set amount
 		Sender:             sender,             // Set sender
@@ -64,16 +72,63 @@ func NewTransaction(accountNonce uint64, amount *big.Float, sender, recipient *c
 		Payload:            payload,            // Set payload
 		Signature:          nil,                // Set signature
 		Timestamp:          time.Now().UTC(),   // Set timestamp
+		ValidUntil:         validUntil.UTC(),   // Set expiry
 	}
 
-	(*transaction).Hash = crypto.Sha3(transaction.Bytes()) // Set transaction hash
+	(*transaction).Hash = transaction.CalculateHash() // Set transaction hash
 
 	return transaction // Return initialized transaction
 }
 
-// CalculateTotalValue calculates the total value of a transaction, including both its amount and total gas.
-func (transaction *Transaction) CalculateTotalValue() *big.Float {
-	return new(big.Float).Add(transaction.Amount, new(big.Float).SetInt(transaction.GasPrice.Mul(transaction.GasPrice, big.NewInt(int64(transaction.GasLimit))))) // Return total value
+// SigningPreimage returns the message a transaction's sender signs: the canonical encoding of every field of the transaction,
+// with a nil signature, nil multi-signature signatures and a nil hash. A multi-signature sender's policy is committed to by
+// the sender address itself, so each of the policy's signers signs the same preimage.
+func (transaction *Transaction) SigningPreimage() []byte {
+	unsignedTransaction := *transaction // Copy transaction
+
+	unsignedTransaction.Signature = nil            // Exclude signature
+	unsignedTransaction.Multisig = nil             // Exclude multi-signature signatures
+	unsignedTransaction.Hash = common.NewHash(nil) // Exclude hash
+
+	return unsignedTransaction.Bytes() // Return preimage
+}
+
+// SigningHash returns the hash of the transaction's signing preimage, which is signed by (and verified against) the sender's key.
+func (transaction *Transaction) SigningHash() common.Hash {
+	return crypto.Sha3(transaction.SigningPreimage()) // Return signing hash
+}
+
+// CalculateHash calculates the transaction's hash (its ID): the hash of the canonical encoding of every field of the
+// transaction (including its signature, if set), with a nil hash.
+func (transaction *Transaction) CalculateHash() common.Hash {
+	transactionCopy := *transaction // Copy transaction
+
+	transactionCopy.Hash = common.NewHash(nil) // Exclude hash
+
+	return crypto.Sha3(transactionCopy.Bytes()) // Return hash
+}
+
+// VerifySignature checks that the transaction has been signed by its sender (or by enough of its sender's multi-signature keys), recomputing the signing hash from the
+// transaction's current contents (the signature's V value is never trusted).
+// Signatures are verified via the DefaultSignatureVerifier, and are therefore only verified once.
+func (transaction *Transaction) VerifySignature() bool {
+	return DefaultSignatureVerifier.VerifyTransaction(transaction) // Return signature validity
+}
+
+// IsExpired checks whether the transaction has expired at a given time (transactions without a valid until time never expire).
+func (transaction *Transaction) IsExpired(at time.Time) bool {
+	return !transaction.ValidUntil.IsZero() && at.After(transaction.ValidUntil) // Return expired
+}
+
+// HasValidAmounts checks that the transaction has a non-negative amount and gas price (transactions with a negative amount or gas
+// price would credit their sender, and debit their recipient).
+func (transaction *Transaction) HasValidAmounts() bool {
+	return transaction.Amount != nil && transaction.GasPrice != nil && transaction.Amount.Sign() >= 0 && transaction.GasPrice.Sign() >= 0 // Return amounts valid
+}
+
+// CalculateTotalValue calculates the total value of a transaction (in base units), including both its amount and total gas.
+func (transaction *Transaction) CalculateTotalValue() *big.Int {
+	return new(big.Int).Add(transaction.Amount, new(big.Int).Mul(transaction.GasPrice, new(big.Int).SetUint64(transaction.GasLimit))) // Return total value
 }
 
 /* BEGIN EXPORTED METHODS */
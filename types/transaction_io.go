@@ -3,34 +3,148 @@
 package types
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"path/filepath"
+	"time"
 
 	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
 )
 
+// transactionEncodingVersion is the current version of the canonical transaction encoding.
+const transactionEncodingVersion = byte(1)
+
+const (
+	absentField  = byte(0) // Marks a nil pointer field
+	presentField = byte(1) // Marks a set pointer field
+	compactField = byte(2) // Marks a compact signature
+
+	positiveNumber = byte(1) // Marks a non-negative number
+	negativeNumber = byte(2) // Marks a negative number
+)
+
+var (
+	// ErrInvalidTransactionEncoding is an error definition representing a transaction that could not be decoded, or that was not canonically encoded.
+	ErrInvalidTransactionEncoding = errors.New("invalid transaction encoding")
+
+	// ErrUnsupportedTransactionEncodingVersion is an error definition representing a transaction encoded with an unknown encoding version.
+	ErrUnsupportedTransactionEncodingVersion = errors.New("unsupported transaction encoding version")
+)
+
+// transactionDecoder reads the fields of a canonically encoded transaction.
+// Once a read fails, all subsequent reads return zero values, and err is set.
+type transactionDecoder struct {
+	reader *bytes.Reader // Encoded transaction reader
+
+	err error // First decoding error
+}
+
 /* BEGIN EXPORTED METHODS */
 
-// TransactionFromBytes deserializes a transaction from a given byte array.
-func TransactionFromBytes(b []byte) *Transaction {
-	buffer := &Transaction{} // Initialize tx buffer
+// DecodeTransaction decodes a transaction from its canonical binary encoding (see Bytes()).
+// Returns an ErrInvalidTransactionEncoding error if b is malformed, has trailing data or is not the canonical encoding of the transaction it decodes to.
+func DecodeTransaction(b []byte) (*Transaction, error) {
+	if len(b) == 0 { // Check nothing to decode
+		return &Transaction{}, ErrInvalidTransactionEncoding // Return error
+	}
+
+	if b[0] != transactionEncodingVersion { // Check unknown version
+		return &Transaction{}, ErrUnsupportedTransactionEncodingVersion // Return error
+	}
+
+	decoder := &transactionDecoder{reader: bytes.NewReader(b[1:])} // Init decoder
+
+	transaction := &Transaction{
+		Network:            decoder.readUint64(),                                 // Read network
+		AccountNonce:       decoder.readUint64(),                                 // Read account nonce
+		Amount:             decoder.readInt(),                                    // Read amount
+		Sender:             decoder.readAddress(),                                // Read sender
+		Recipient:          decoder.readAddress(),                                // Read recipient
+		ParentTransactions: decoder.readHashes(),                                 // Read parents
+		GasPrice:           decoder.readInt(),                                    // Read gas price
+		GasLimit:           decoder.readUint64(),                                 // Read gas limit
+		Payload:            decoder.readBytes(),                                  // Read payload
+		Signature:          decoder.readSignature(),                              // Read signature
+		Multisig:           decoder.readMultisig(),                               // Read multi-signature signatures
+		Timestamp:          decoder.readTime(),                                   // Read timestamp
+		ValidUntil:         decoder.readExpiry(),                                 // Read valid until
+		Hash:               common.NewHash(decoder.readFixed(common.HashLength)), // Read hash
+	} // Decode fields in canonical order
+
+	if decoder.err != nil || decoder.reader.Len() != 0 { // Check malformed or trailing data
+		return &Transaction{}, ErrInvalidTransactionEncoding // Return error
+	}
+
+	if !bytes.Equal(transaction.Bytes(), b) { // Check not canonical
+		return &Transaction{}, ErrInvalidTransactionEncoding // Return error
+	}
 
-	err := json.Unmarshal(b, buffer) // Unmarshal
-	if err != nil {                  // Check for errors
+	return transaction, nil // Return decoded transaction
+}
+
+// TransactionFromBytes decodes a transaction from its canonical binary encoding.
+// If the given bytes cannot be decoded, an empty transaction is returned.
+func TransactionFromBytes(b []byte) *Transaction {
+	transaction, err := DecodeTransaction(b) // Decode
+	if err != nil {                          // Check for errors
 		return &Transaction{}
 	}
 
-	return buffer // Return deserialized transaction
+	return transaction // Return decoded transaction
 }
 
-// Bytes serializes a given transaction to a byte array via json.
+// Bytes serializes a given transaction to its canonical binary encoding, used for hashing, signing, storage and the p2p network.
+//
+// All integers are big-endian, and all lengths and counts are uint32s. Fields are written in the following order:
+//
+//	version (1 byte) | network (uint64) | account nonce (uint64) | amount (int) | sender (address) | recipient (address)
+//	| parent count | parent hashes (32 bytes each) | gas price (int) | gas limit (uint64) | payload length | payload
+//	| signature | multi-signature signatures | timestamp (int64 unix seconds, uint32 nanoseconds) | valid until (optional timestamp) | hash (32 bytes)
+//
+// Pointer fields (addresses, numbers and the signature) begin with a 0 byte if nil, followed by nothing else.
+// The valid until time is likewise a 0 byte if zero, or a 1 byte followed by a timestamp.
+// An address is otherwise a 1 byte followed by its 20 bytes. A signature is otherwise a 1 byte, followed by its scheme (1 byte),
+// length-prefixed public key, length-prefixed V, and its R and S ints, or (if compact) a 2 byte, followed by its scheme (1 byte),
+// recovery ID (1 byte), and its R and S ints. Multi-signature signatures are otherwise a 1 byte, followed
+// by the policy's threshold (uint32), key count, each key's scheme (1 byte) and length-prefixed public key, the signature count
+// and each signature.
+//
+// An int is otherwise a sign byte (1 for non-negative, 2 for negative), followed by its length-prefixed magnitude
+// (without leading zeros). Amounts are ints of base units (see common.Decimals).
 func (transaction *Transaction) Bytes() []byte {
-	marshaledVal, _ := json.MarshalIndent(*transaction, "", "  ") // Marshal JSON
+	buffer := &bytes.Buffer{} // Init buffer
+
+	buffer.WriteByte(transactionEncodingVersion) // Write version
+
+	writeUint64(buffer, transaction.Network)      // Write network
+	writeUint64(buffer, transaction.AccountNonce) // Write account nonce
+	writeInt(buffer, transaction.Amount)          // Write amount
+	writeAddress(buffer, transaction.Sender)      // Write sender
+	writeAddress(buffer, transaction.Recipient)   // Write recipient
+
+	writeUint32(buffer, uint32(len(transaction.ParentTransactions))) // Write parent count
+
+	for _, parentHash := range transaction.ParentTransactions { // Iterate through parents
+		buffer.Write(parentHash.Bytes()) // Write parent hash
+	}
+
+	writeInt(buffer, transaction.GasPrice)        // Write gas price
+	writeUint64(buffer, transaction.GasLimit)     // Write gas limit
+	writeBytes(buffer, transaction.Payload)       // Write payload
+	writeSignature(buffer, transaction.Signature) // Write signature
+	writeMultisig(buffer, transaction.Multisig)   // Write multi-signature signatures
+	writeTime(buffer, transaction.Timestamp)      // Write timestamp
+	writeExpiry(buffer, transaction.ValidUntil)   // Write valid until
+	buffer.Write(transaction.Hash.Bytes())        // Write hash
 
-	return marshaledVal // Return marshaled value
+	return buffer.Bytes() // Return encoded transaction
 }
 
 // String serializes a given transaction to a string via json.
@@ -47,7 +161,7 @@ func (transaction *Transaction) WriteToMemory() error {
 		return err // Return found error
 	}
 
-	err = ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/transaction_%s.json", common.MempoolDir, hex.EncodeToString(transaction.Hash.Bytes()))), transaction.Bytes(), 0o644) // Write transaction to persistent memory
+	err = ioutil.WriteFile(filepath.FromSlash(fmt.Sprintf("%s/transaction_%s.tx", common.MempoolDir, hex.EncodeToString(transaction.Hash.Bytes()))), transaction.Bytes(), 0o644) // Write transaction to persistent memory
 
 	if err != nil { // Check for errors
 		return err // Return error
@@ -58,20 +172,338 @@ func (transaction *Transaction) WriteToMemory() error {
 
 // ReadTransactionFromMemory reads a given transaction (specified by hash) from persistent memory.
 func ReadTransactionFromMemory(hash common.Hash) (*Transaction, error) {
-	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/transaction_%s.json", common.MempoolDir, hex.EncodeToString(hash.Bytes())))) // Read transaction
-	if err != nil {                                                                                                                              // Check for errors
+	data, err := ioutil.ReadFile(filepath.FromSlash(fmt.Sprintf("%s/transaction_%s.tx", common.MempoolDir, hex.EncodeToString(hash.Bytes())))) // Read transaction
+	if err != nil {                                                                                                                            // Check for errors
 		return &Transaction{}, err // Return found error
 	}
 
-	buffer := &Transaction{} // Initialize buffer
+	return DecodeTransaction(data) // Decode transaction
+}
 
-	err = json.Unmarshal(data, buffer) // Deserialize JSON into buffer.
+/* END EXPORTED METHODS */
 
-	if err != nil { // Check for errors
-		return &Transaction{}, err // Return found error
+/* BEGIN INTERNAL METHODS */
+
+/*
+	BEGIN ENCODING HELPER METHODS
+*/
+
+// writeUint32 writes a given uint32 to a given buffer.
+func writeUint32(buffer *bytes.Buffer, x uint32) {
+	var encoded [4]byte // Init encoding buffer
+
+	binary.BigEndian.PutUint32(encoded[:], x) // Encode
+
+	buffer.Write(encoded[:]) // Write
+}
+
+// writeUint64 writes a given uint64 to a given buffer.
+func writeUint64(buffer *bytes.Buffer, x uint64) {
+	var encoded [8]byte // Init encoding buffer
+
+	binary.BigEndian.PutUint64(encoded[:], x) // Encode
+
+	buffer.Write(encoded[:]) // Write
+}
+
+// writeBytes writes a given byte slice to a given buffer, prefixed with its length.
+func writeBytes(buffer *bytes.Buffer, b []byte) {
+	writeUint32(buffer, uint32(len(b))) // Write length
+	buffer.Write(b)                     // Write bytes
+}
+
+// writeAddress writes a given (optional) address to a given buffer.
+func writeAddress(buffer *bytes.Buffer, address *common.Address) {
+	if address == nil { // Check nil
+		buffer.WriteByte(absentField) // Write absent
+
+		return // Done
 	}
 
-	return buffer, nil // No error occurred, return read transaction
+	buffer.WriteByte(presentField) // Write present
+	buffer.Write(address.Bytes())  // Write address
 }
 
-/* END EXPORTED METHODS */
+// writeInt writes a given (optional) big int to a given buffer.
+func writeInt(buffer *bytes.Buffer, x *big.Int) {
+	if x == nil { // Check nil
+		buffer.WriteByte(absentField) // Write absent
+
+		return // Done
+	}
+
+	if x.Sign() < 0 { // Check negative
+		buffer.WriteByte(negativeNumber) // Write sign
+	} else {
+		buffer.WriteByte(positiveNumber) // Write sign
+	}
+
+	writeBytes(buffer, x.Bytes()) // Write magnitude
+}
+
+// writeSignature writes a given (optional) signature to a given buffer.
+func writeSignature(buffer *bytes.Buffer, signature *Signature) {
+	if signature == nil { // Check nil
+		buffer.WriteByte(absentField) // Write absent
+
+		return // Done
+	}
+
+	if signature.IsCompact() { // Check compact
+		buffer.WriteByte(compactField)           // Write compact
+		buffer.WriteByte(byte(signature.Scheme)) // Write scheme
+		buffer.WriteByte(signature.RecoveryID)   // Write recovery ID
+		writeInt(buffer, signature.R)            // Write R
+		writeInt(buffer, signature.S)            // Write S
+
+		return // Done
+	}
+
+	buffer.WriteByte(presentField) // Write present
+
+	buffer.WriteByte(byte(signature.Scheme))         // Write scheme
+	writeBytes(buffer, signature.MarshaledPublicKey) // Write public key
+	writeBytes(buffer, signature.V)                  // Write V
+	writeInt(buffer, signature.R)                    // Write R
+	writeInt(buffer, signature.S)                    // Write S
+}
+
+// writeMultisig writes given (optional) multi-signature signatures to a given buffer.
+func writeMultisig(buffer *bytes.Buffer, multisig *MultisigSignature) {
+	if multisig == nil || multisig.Policy == nil { // Check nil
+		buffer.WriteByte(absentField) // Write absent
+
+		return // Done
+	}
+
+	buffer.WriteByte(presentField) // Write present
+
+	writeUint32(buffer, multisig.Policy.Threshold)         // Write threshold
+	writeUint32(buffer, uint32(len(multisig.Policy.Keys))) // Write key count
+
+	for _, key := range multisig.Policy.Keys { // Iterate through keys
+		buffer.WriteByte(byte(key.Scheme))         // Write scheme
+		writeBytes(buffer, key.MarshaledPublicKey) // Write public key
+	}
+
+	writeUint32(buffer, uint32(len(multisig.Signatures))) // Write signature count
+
+	for _, signature := range multisig.Signatures { // Iterate through signatures
+		writeSignature(buffer, signature) // Write signature
+	}
+}
+
+// writeTime writes a given time to a given buffer, as unix seconds and nanoseconds.
+func writeTime(buffer *bytes.Buffer, t time.Time) {
+	writeUint64(buffer, uint64(t.Unix()))       // Write seconds
+	writeUint32(buffer, uint32(t.Nanosecond())) // Write nanoseconds
+}
+
+// writeExpiry writes a given (optional) valid until time to a given buffer.
+func writeExpiry(buffer *bytes.Buffer, t time.Time) {
+	if t.IsZero() { // Check no expiry
+		buffer.WriteByte(absentField) // Write absent
+
+		return // Done
+	}
+
+	buffer.WriteByte(presentField) // Write present
+	writeTime(buffer, t)           // Write time
+}
+
+// readFixed reads a given number of bytes.
+func (decoder *transactionDecoder) readFixed(n int) []byte {
+	if decoder.err != nil { // Check already failed
+		return nil // Nothing to read
+	}
+
+	if n > decoder.reader.Len() { // Check not enough bytes
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+
+		return nil // Nothing to read
+	}
+
+	b := make([]byte, n) // Init buffer
+
+	decoder.reader.Read(b) // Read
+
+	return b // Return read bytes
+}
+
+// readByte reads a single byte.
+func (decoder *transactionDecoder) readByte() byte {
+	if b := decoder.readFixed(1); b != nil { // Check read
+		return b[0] // Return byte
+	}
+
+	return 0 // Nothing read
+}
+
+// readUint32 reads a uint32.
+func (decoder *transactionDecoder) readUint32() uint32 {
+	if b := decoder.readFixed(4); b != nil { // Check read
+		return binary.BigEndian.Uint32(b) // Return decoded
+	}
+
+	return 0 // Nothing read
+}
+
+// readUint64 reads a uint64.
+func (decoder *transactionDecoder) readUint64() uint64 {
+	if b := decoder.readFixed(8); b != nil { // Check read
+		return binary.BigEndian.Uint64(b) // Return decoded
+	}
+
+	return 0 // Nothing read
+}
+
+// readBytes reads a length-prefixed byte slice.
+func (decoder *transactionDecoder) readBytes() []byte {
+	return decoder.readFixed(int(decoder.readUint32())) // Read bytes
+}
+
+// readPresent reads the presence marker of a pointer field.
+func (decoder *transactionDecoder) readPresent() bool {
+	switch decoder.readByte() {
+	case absentField:
+		return false // Absent
+	case presentField:
+		return true // Present
+	default:
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+
+		return false // Invalid marker
+	}
+}
+
+// readAddress reads an optional address.
+func (decoder *transactionDecoder) readAddress() *common.Address {
+	if !decoder.readPresent() { // Check absent
+		return nil // Nil address
+	}
+
+	return common.NewAddress(decoder.readFixed(common.AddressLength)) // Return address
+}
+
+// readHashes reads a count-prefixed list of hashes.
+func (decoder *transactionDecoder) readHashes() []common.Hash {
+	count := int(decoder.readUint32()) // Read count
+
+	if count > decoder.reader.Len()/common.HashLength { // Check not enough bytes
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+	}
+
+	if count == 0 || decoder.err != nil { // Check no hashes
+		return nil // No hashes
+	}
+
+	hashes := make([]common.Hash, count) // Init hash buffer
+
+	for i := range hashes { // Read each hash
+		hashes[i] = common.NewHash(decoder.readFixed(common.HashLength)) // Read hash
+	}
+
+	return hashes // Return hashes
+}
+
+// readInt reads an optional big int.
+func (decoder *transactionDecoder) readInt() *big.Int {
+	sign := decoder.readByte() // Read sign
+
+	if sign == absentField { // Check nil
+		return nil // Nil int
+	}
+
+	if sign != positiveNumber && sign != negativeNumber { // Check invalid sign
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+
+		return nil // Invalid int
+	}
+
+	x := new(big.Int).SetBytes(decoder.readBytes()) // Read magnitude
+
+	if sign == negativeNumber { // Check negative
+		x.Neg(x) // Negate
+	}
+
+	return x // Return int
+}
+
+// readExpiry reads an optional valid until time.
+func (decoder *transactionDecoder) readExpiry() time.Time {
+	if !decoder.readPresent() { // Check no expiry
+		return time.Time{} // No expiry
+	}
+
+	return decoder.readTime() // Read time
+}
+
+// readSignature reads an optional signature.
+func (decoder *transactionDecoder) readSignature() *Signature {
+	switch marker := decoder.readByte(); {
+	case marker == absentField:
+		return nil // Nil signature
+	case marker == compactField:
+		return &Signature{
+			Scheme:     crypto.SignatureScheme(decoder.readByte()), // Read scheme
+			RecoveryID: decoder.readByte(),                         // Read recovery ID
+			R:          decoder.readInt(),                          // Read R
+			S:          decoder.readInt(),                          // Read S
+		} // Return compact signature
+	case marker != presentField:
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+
+		return nil // Invalid marker
+	}
+
+	return &Signature{
+		Scheme:             crypto.SignatureScheme(decoder.readByte()), // Read scheme
+		MarshaledPublicKey: decoder.readBytes(),                        // Read public key
+		V:                  decoder.readBytes(),                        // Read V
+		R:                  decoder.readInt(),                          // Read R
+		S:                  decoder.readInt(),                          // Read S
+	} // Return signature
+}
+
+// readMultisig reads optional multi-signature signatures.
+func (decoder *transactionDecoder) readMultisig() *MultisigSignature {
+	if !decoder.readPresent() { // Check absent
+		return nil // Nil multi-signature signatures
+	}
+
+	policy := &crypto.MultisigPolicy{Threshold: decoder.readUint32()} // Read threshold
+
+	for i := decoder.readUint32(); i > 0 && decoder.err == nil; i-- { // Read each key
+		policy.Keys = append(policy.Keys, crypto.MultisigKey{
+			Scheme:             crypto.SignatureScheme(decoder.readByte()), // Read scheme
+			MarshaledPublicKey: decoder.readBytes(),                        // Read public key
+		}) // Append key
+	}
+
+	multisig := &MultisigSignature{Policy: policy} // Init multi-signature signatures
+
+	for i := decoder.readUint32(); i > 0 && decoder.err == nil; i-- { // Read each signature
+		multisig.Signatures = append(multisig.Signatures, decoder.readSignature()) // Append signature
+	}
+
+	return multisig // Return multi-signature signatures
+}
+
+// readTime reads a timestamp.
+func (decoder *transactionDecoder) readTime() time.Time {
+	seconds := int64(decoder.readUint64()) // Read seconds
+
+	nanoseconds := decoder.readUint32() // Read nanoseconds
+
+	if nanoseconds >= uint32(time.Second) { // Check invalid nanoseconds
+		decoder.err = ErrInvalidTransactionEncoding // Set error
+	}
+
+	return time.Unix(seconds, int64(nanoseconds)).UTC() // Return time
+}
+
+/*
+	END ENCODING HELPER METHODS
+*/
+
+/* END INTERNAL METHODS */
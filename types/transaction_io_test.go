@@ -4,8 +4,16 @@ package types
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
 	"math/big"
 	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
 )
 
 /* BEGIN EXPORTED METHODS TESTS */
@@ -14,13 +22,15 @@ import (
 func TestTransactionFromBytes(t *testing.T) {
 	transaction := NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(10),       // Amount
+		big.NewInt(10),         // Amount
 		nil,                    // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		1,                      // Gas limit
 		big.NewInt(1000),       // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Initialize a new transaction using the NewTransaction method
 
 	if !bytes.Equal(transaction.Bytes(), TransactionFromBytes(transaction.Bytes()).Bytes()) { // Check transactions not equal
@@ -28,33 +38,112 @@ func TestTransactionFromBytes(t *testing.T) {
 	}
 }
 
-// TestBytesTransaction tests the functionality of the Bytes() transaction helper method.
-func TestBytesTransaction(t *testing.T) {
+// TestDecodeTransaction tests the functionality of the DecodeTransaction() transaction helper method.
+func TestDecodeTransaction(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	parents := []common.Hash{crypto.Sha3([]byte("a")), crypto.Sha3([]byte("b"))} // Init parent hashes
+
 	transaction := NewTransaction(
-		0,                      // Nonce
-		big.NewFloat(10),       // Amount
-		nil,                    // Sender
-		nil,                    // Recipient
-		nil,                    // Parents
-		1,                      // Gas limit
-		big.NewInt(1000),       // Gas price
-		[]byte("test payload"), // Payload
+		7,                                        // Nonce
+		big.NewInt(100000000),                    // Amount
+		crypto.AddressFromPrivateKey(privateKey), // Sender
+		common.NewAddress([]byte("recipient")),   // Recipient
+		parents,                                  // Parents
+		1,                                        // Gas limit
+		big.NewInt(1000),                         // Gas price
+		[]byte("test payload"),                   // Payload
+		1,                                        // Network
+		time.Time{},                              // Valid until
 	) // Initialize a new transaction using the NewTransaction method
 
-	t.Log(transaction.Bytes()) // Log transaction bytes & test the Bytes() method
+	if err = SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	decoded, err := DecodeTransaction(transaction.Bytes()) // Decode transaction
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if decoded.Hash != transaction.Hash || decoded.Amount.Cmp(transaction.Amount) != 0 || !decoded.Timestamp.Equal(transaction.Timestamp) { // Check fields not equal
+		t.Fatal("decoded transaction should be equivalent to source") // Panic
+	}
+
+	if !decoded.VerifySignature() { // Check signature not preserved
+		t.Fatal("decoded transaction signature should be valid") // Panic
+	}
+
+	encoded := transaction.Bytes() // Encode transaction
+
+	for _, invalid := range [][]byte{
+		nil,                                     // Empty
+		encoded[:len(encoded)-1],                // Truncated
+		append(append([]byte{}, encoded...), 0), // Trailing data
+	} { // Iterate through invalid encodings
+		if _, err = DecodeTransaction(invalid); err != ErrInvalidTransactionEncoding { // Decode invalid transaction
+			t.Fatalf("should have returned ErrInvalidTransactionEncoding; got %v", err) // Panic
+		}
+	}
+
+	if _, err = DecodeTransaction(append([]byte{2}, encoded[1:]...)); err != ErrUnsupportedTransactionEncodingVersion { // Decode unknown version
+		t.Fatalf("should have returned ErrUnsupportedTransactionEncodingVersion; got %v", err) // Panic
+	}
+}
+
+// TestBytesTransaction tests the functionality of the Bytes() transaction helper method against a known encoding.
+func TestBytesTransaction(t *testing.T) {
+	transaction := &Transaction{
+		Network:            1,                                                                                                                   // Set network
+		AccountNonce:       1,                                                                                                                   // Set account nonce
+		Amount:             big.NewInt(2500000000),                                                                                              // Set amount
+		Sender:             common.NewAddress([]byte{1}),                                                                                        // Set sender
+		ParentTransactions: []common.Hash{common.NewHash([]byte{2})},                                                                            // Set parents
+		GasPrice:           big.NewInt(1000),                                                                                                    // Set gas price
+		GasLimit:           21000,                                                                                                               // Set gas limit
+		Payload:            []byte("hi"),                                                                                                        // Set payload
+		Signature:          &Signature{Scheme: crypto.Ed25519, MarshaledPublicKey: []byte{3}, V: []byte{4}, R: big.NewInt(5), S: big.NewInt(6)}, // Set signature
+		Timestamp:          time.Unix(1546300800, 5).UTC(),                                                                                      // Set timestamp
+		ValidUntil:         time.Unix(1546304400, 0).UTC(),                                                                                      // Set valid until
+	} // Initialize transaction with fixed fields
+
+	expected := "01" + // Version
+		"0000000000000001" + // Network
+		"0000000000000001" + // Account nonce
+		"01" + "00000004" + "9502f900" + // Amount (2.5 units)
+		"01" + "0000000000000000000000000000000000000001" + // Sender
+		"00" + // Recipient (nil)
+		"00000001" + "0000000000000000000000000000000000000000000000000000000000000002" + // Parents
+		"01" + "00000002" + "03e8" + // Gas price
+		"0000000000005208" + // Gas limit
+		"00000002" + "6869" + // Payload
+		"01" + "01" + "00000001" + "03" + "00000001" + "04" + "01" + "00000001" + "05" + "01" + "00000001" + "06" + // Signature (ed25519)
+		"00" + // Multi-signature signatures (nil)
+		"000000005c2aad80" + "00000005" + // Timestamp
+		"01" + "000000005c2abb90" + "00000000" + // Valid until
+		"0000000000000000000000000000000000000000000000000000000000000000" // Hash
+
+	if encoded := hex.EncodeToString(transaction.Bytes()); encoded != expected { // Check invalid encoding
+		t.Fatalf("invalid transaction encoding; found %s, but wanted %s", encoded, expected) // Panic
+	}
 }
 
 // TestBytesTransaction tests the functionality of the String() transaction helper method.
 func TestStringTransaction(t *testing.T) {
 	transaction := NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(10),       // Amount
+		big.NewInt(10),         // Amount
 		nil,                    // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		1,                      // Gas limit
 		big.NewInt(1000),       // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Initialize a new transaction using the NewTransaction method
 
 	t.Log(transaction.String()) // Log transaction string & test the String() method
@@ -0,0 +1,232 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	gocrypto "crypto"
+	"errors"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+var (
+	// ErrMultisigPolicyMismatch is an error definition representing a multi-signature policy whose address is not the sender of a transaction.
+	ErrMultisigPolicyMismatch = errors.New("multi-signature policy does not derive the transaction sender")
+
+	// ErrNotMultisigSigner is an error definition representing a key that does not participate in a multi-signature policy.
+	ErrNotMultisigSigner = errors.New("key does not participate in the multi-signature policy")
+
+	// ErrMultisigThresholdMet is an error definition representing a multi-signature transaction that already carries as many
+	// signatures as its policy requires.
+	ErrMultisigThresholdMet = errors.New("multi-signature threshold already met")
+
+	// ErrMismatchedTransaction is an error definition representing two copies of a transaction with different signing hashes.
+	ErrMismatchedTransaction = errors.New("transactions do not have the same signing hash")
+)
+
+// MultisigSignature is a data type representing the signatures of a transaction sent from a multi-signature address: the
+// address' policy, and the signatures of the policy's keys collected so far (ordered by the index of their key in the policy).
+type MultisigSignature struct {
+	Policy *crypto.MultisigPolicy `json:"policy"` // Multi-signature policy of the sender
+
+	Signatures []*Signature `json:"signatures"` // Signatures of the policy's keys
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// SignMultisigTransaction signs the signing hash of a given transaction sent from a multi-signature address with a given private
+// key participating in the address' policy, adding the signature to the transaction's other signatures, and recalculates the
+// transaction hash. Signatures can therefore be collected one signer at a time, and the transaction is valid once it carries
+// exactly as many signatures as the policy's threshold.
+// Returns an ErrAlreadySigned error if the transaction has a single-key signature, or if the key has already signed the transaction.
+func SignMultisigTransaction(transaction *Transaction, policy *crypto.MultisigPolicy, privateKey gocrypto.Signer) error {
+	if transaction.Signature != nil { // Check existing single-key signature
+		return ErrAlreadySigned // Return already signed error
+	}
+
+	if err := transaction.setMultisigPolicy(policy); err != nil { // Set policy
+		return err // Return found error
+	}
+
+	signature, err := SignMessage(transaction.SigningHash(), privateKey) // Sign
+	if err != nil {                                                      // Check for errors
+		return err // Return found error
+	}
+
+	if err = transaction.Multisig.addSignature(signature); err != nil { // Add signature
+		return err // Return found error
+	}
+
+	(*transaction).Hash = transaction.CalculateHash() // Set transaction hash
+
+	return nil // No error occurred, return nil
+}
+
+// MergeMultisigSignatures adds the signatures of another copy of a given multi-signature transaction (e.g. one signed by another
+// signer) to the transaction, until the transaction carries as many signatures as its policy requires, and recalculates the
+// transaction hash. Signatures that the transaction already carries are skipped.
+// Returns an ErrMismatchedTransaction error if the copies have different signing hashes.
+func MergeMultisigSignatures(transaction *Transaction, other *Transaction) error {
+	if transaction.SigningHash() != other.SigningHash() { // Check different transactions
+		return ErrMismatchedTransaction // Return error
+	}
+
+	if transaction.Signature != nil { // Check existing single-key signature
+		return ErrAlreadySigned // Return already signed error
+	}
+
+	if other.Multisig == nil { // Check nothing to merge
+		return nil // Nothing to merge
+	}
+
+	if err := transaction.setMultisigPolicy(other.Multisig.Policy); err != nil { // Set policy
+		return err // Return found error
+	}
+
+	signingHash := transaction.SigningHash() // Get signing hash
+
+	for _, signature := range other.Multisig.Signatures { // Iterate through signatures
+		if len(transaction.Multisig.Signatures) == int(transaction.Multisig.Policy.Threshold) { // Check threshold met
+			break // Stop merging
+		}
+
+		if !signature.verifyMultisigPart(signingHash) { // Check invalid signature
+			return ErrInvalidSignature // Return error
+		}
+
+		if err := transaction.Multisig.addSignature(signature); err != nil && err != ErrAlreadySigned { // Add signature
+			return err // Return found error
+		}
+	}
+
+	(*transaction).Hash = transaction.CalculateHash() // Set transaction hash
+
+	return nil // No error occurred, return nil
+}
+
+// IsSigned checks whether a given transaction carries a single-key or multi-signature signature (valid or not).
+func (transaction *Transaction) IsSigned() bool {
+	return transaction.Signature != nil || transaction.Multisig != nil // Return is signed
+}
+
+// Verify checks that a given multi-signature signature is a valid signature of a given message hash by the multi-signature address
+// of a given address: the address must be derived from the signature's (valid) policy, and the signature must carry exactly
+// threshold valid signatures of distinct keys of the policy, in order of their keys.
+func (multisig *MultisigSignature) Verify(messageHash common.Hash, address *common.Address) bool {
+	if multisig == nil || multisig.Policy == nil || address == nil { // Check no signature
+		return false // No signature to verify
+	}
+
+	if multisig.Policy.Validate() != nil || *multisig.Policy.Address() != *address { // Check invalid policy
+		return false // Invalid
+	}
+
+	if len(multisig.Signatures) != int(multisig.Policy.Threshold) { // Check not exactly threshold signatures
+		return false // Invalid
+	}
+
+	lastIndex := -1 // Init last key index
+
+	for _, signature := range multisig.Signatures { // Iterate through signatures
+		if signature == nil { // Check nil signature
+			return false // Invalid
+		}
+
+		index := multisig.Policy.KeyIndex(signature.Scheme, signature.MarshaledPublicKey) // Get key index
+		if index <= lastIndex {                                                           // Check key not in policy, or out of order
+			return false // Invalid
+		}
+
+		lastIndex = index // Set last key index
+
+		if !signature.verifyMultisigPart(messageHash) { // Check invalid signature
+			return false // Invalid
+		}
+	}
+
+	return true // Valid
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// setMultisigPolicy sets the multi-signature policy of a given transaction (if it has none), checking that the policy derives
+// the transaction's sender.
+func (transaction *Transaction) setMultisigPolicy(policy *crypto.MultisigPolicy) error {
+	if policy == nil || policy.Validate() != nil || transaction.Sender == nil || *policy.Address() != *transaction.Sender { // Check policy does not derive sender
+		return ErrMultisigPolicyMismatch // Return error
+	}
+
+	if transaction.Multisig == nil { // Check no policy
+		(*transaction).Multisig = &MultisigSignature{Policy: policy} // Set policy
+	}
+
+	return nil // No error occurred, return nil
+}
+
+// addSignature adds a given signature of a key participating in the multi-signature policy, keeping signatures ordered by the
+// index of their key in the policy.
+func (multisig *MultisigSignature) addSignature(signature *Signature) error {
+	if signature == nil { // Check nil signature
+		return ErrNilSignature // Return error
+	}
+
+	index := multisig.Policy.KeyIndex(signature.Scheme, signature.MarshaledPublicKey) // Get key index
+	if index < 0 {                                                                    // Check key not in policy
+		return ErrNotMultisigSigner // Return error
+	}
+
+	position := len(multisig.Signatures) // Init insertion position
+
+	for i, existing := range multisig.Signatures { // Iterate through signatures
+		if existing == nil { // Check nil signature
+			continue // Skip
+		}
+
+		existingIndex := multisig.Policy.KeyIndex(existing.Scheme, existing.MarshaledPublicKey) // Get existing key index
+
+		if existingIndex == index { // Check already signed
+			return ErrAlreadySigned // Return already signed error
+		}
+
+		if existingIndex > index { // Check found position
+			position = i // Set position
+
+			break // Stop searching
+		}
+	}
+
+	if len(multisig.Signatures) >= int(multisig.Policy.Threshold) { // Check threshold met
+		return ErrMultisigThresholdMet // Return error
+	}
+
+	multisig.Signatures = append(multisig.Signatures, nil)                 // Grow signatures
+	copy(multisig.Signatures[position+1:], multisig.Signatures[position:]) // Shift later signatures
+	multisig.Signatures[position] = signature                              // Insert signature
+
+	return nil // No error occurred, return nil
+}
+
+// verifyMultisigPart checks that a given signature is a valid signature of a given message hash by the key the signature
+// carries (regardless of the address of that key).
+func (signature *Signature) verifyMultisigPart(messageHash common.Hash) bool {
+	if signature == nil { // Check nil signature
+		return false // No signature to verify
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(signature.Scheme) // Get signature algorithm
+	if err != nil {                                                  // Check for errors
+		return false // Unknown scheme
+	}
+
+	signer, err := algorithm.Address(signature.MarshaledPublicKey) // Derive signer address
+	if err != nil {                                                // Check for errors
+		return false // Invalid public key
+	}
+
+	return signature.Verify(messageHash, signer) // Return signature validity
+}
+
+/* END INTERNAL METHODS */
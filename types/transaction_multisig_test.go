@@ -0,0 +1,107 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	gocrypto "crypto"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestSignMultisigTransaction tests the functionality of the SignMultisigTransaction() and MergeMultisigSignatures() helper methods,
+// as well as multi-signature signature verification.
+func TestSignMultisigTransaction(t *testing.T) {
+	var privateKeys []gocrypto.Signer // Init private key buffer
+	var keys []crypto.MultisigKey     // Init key buffer
+
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Ed25519, crypto.Secp256k1} { // Iterate through schemes
+		privateKey, err := crypto.GenerateKey(scheme) // Generate private key
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		key, err := crypto.NewMultisigKey(privateKey.Public()) // Initialize multi-signature key
+		if err != nil {                                        // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		privateKeys = append(privateKeys, privateKey) // Append private key
+		keys = append(keys, key)                      // Append key
+	}
+
+	policy, err := crypto.NewMultisigPolicy(2, keys) // Initialize 2-of-3 policy
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction := NewTransaction(0, big.NewInt(1), policy.Address(), nil, nil, 1, big.NewInt(1), []byte("test payload"), 1, time.Time{}) // Initialize transaction
+
+	otherCopy := *transaction // Copy transaction (as sent to another signer)
+
+	if err = SignMultisigTransaction(transaction, policy, privateKeys[2]); err != nil { // Sign with secp256k1 key
+		t.Fatal(err) // Panic
+	}
+
+	if err = SignMultisigTransaction(transaction, policy, privateKeys[2]); err != ErrAlreadySigned { // Sign with same key again
+		t.Fatalf("should have returned ErrAlreadySigned; got %v", err) // Panic
+	}
+
+	if transaction.VerifySignature() { // Check valid below threshold
+		t.Fatal("transaction signed by fewer keys than the threshold should be invalid") // Panic
+	}
+
+	if err = SignMultisigTransaction(&otherCopy, policy, privateKeys[0]); err != nil { // Sign other copy with P-521 key
+		t.Fatal(err) // Panic
+	}
+
+	if err = MergeMultisigSignatures(transaction, &otherCopy); err != nil { // Merge signatures
+		t.Fatal(err) // Panic
+	}
+
+	if !transaction.VerifySignature() { // Check invalid at threshold
+		t.Fatal("transaction signed by threshold keys should be valid") // Panic
+	}
+
+	if transaction.Multisig.Signatures[0].Scheme != crypto.P521 { // Check signatures not ordered
+		t.Fatal("signatures should be ordered by the index of their key in the policy") // Panic
+	}
+
+	if err = SignMultisigTransaction(transaction, policy, privateKeys[1]); err != ErrMultisigThresholdMet { // Sign past threshold
+		t.Fatalf("should have returned ErrMultisigThresholdMet; got %v", err) // Panic
+	}
+
+	decoded, err := DecodeTransaction(transaction.Bytes()) // Decode transaction
+	if err != nil {                                        // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if decoded.Hash != transaction.CalculateHash() || !decoded.VerifySignature() { // Check invalid decoded transaction
+		t.Fatal("decoded multi-signature transaction should be valid") // Panic
+	}
+
+	decoded.Multisig.Signatures[0], decoded.Multisig.Signatures[1] = decoded.Multisig.Signatures[1], decoded.Multisig.Signatures[0] // Reorder signatures
+
+	if decoded.VerifySignature() { // Check reordered signatures valid
+		t.Fatal("transaction with out of order signatures should be invalid") // Panic
+	}
+
+	transaction.Amount = big.NewInt(2) // Change signed amount
+
+	if transaction.VerifySignature() { // Check forged transaction valid
+		t.Fatal("transaction with altered contents should be invalid") // Panic
+	}
+
+	unrelated := NewTransaction(0, big.NewInt(1), common.NewAddress([]byte("other")), nil, nil, 1, big.NewInt(1), nil, 1, time.Time{}) // Initialize transaction from another sender
+
+	if err = SignMultisigTransaction(unrelated, policy, privateKeys[0]); err != ErrMultisigPolicyMismatch { // Sign with policy not deriving sender
+		t.Fatalf("should have returned ErrMultisigPolicyMismatch; got %v", err) // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
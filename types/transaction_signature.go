@@ -3,9 +3,7 @@
 package types
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	gocrypto "crypto"
 	"errors"
 	"math/big"
 
@@ -21,90 +19,186 @@ var (
 	ErrNilHash = errors.New("hash not set")
 )
 
-// Signature is a data type representing a verifiable ECDSA signature--that of which
+// Signature is a data type representing a verifiable signature (made via any registered signature scheme)--that of which
 // is not necessarily a transaction signature.
 type Signature struct {
-	MarshaledPublicKey []byte `json:"pub" gencodec:"required"` // Signature public key
+	Scheme crypto.SignatureScheme `json:"scheme"` // Signature scheme (P-521 for signatures made before signature schemes were introduced)
 
-	V []byte   `json:"v" gencodec:"required"` //  Signature message
+	MarshaledPublicKey []byte `json:"pub"` // Signature public key (empty if compact, in which case the key is recovered from the signature)
+
+	V []byte   `json:"v"`                     // Signed message hash (informational only; never trusted by Verify, and empty if compact)
 	R *big.Int `json:"r" gencodec:"required"` // Signature retrieval
 	S *big.Int `json:"s" gencodec:"required"` // Signature retrieval
+
+	RecoveryID byte `json:"recovery_id,omitempty"` // Public key recovery ID (compact signatures only)
 }
 
 /* BEGIN EXPORTED METHODS */
 
-// SignTransaction signs a given transaction via ecdsa, and sets the transaction signature to the new signature.
-// Returns a new signature composed of v, r, s values.
-// If the transaction has already been signed, returns an ErrAlreadySigned error, as well as a nil signature pointer.
-// If the transaction has no hash, returns an ErrNilHash error, as well as a nil signature pointer.
-func SignTransaction(transaction *Transaction, privateKey *ecdsa.PrivateKey) error {
-	if transaction.Hash.IsNil() { // Check no existing hash
-		return ErrNilHash // Return no hash error
+// SignTransaction signs the signing hash of a given transaction (see SigningPreimage()) via the signature scheme of the given private key,
+// sets the transaction signature to the new signature, and recalculates the transaction hash (which includes the signature).
+// If the transaction has already been signed, returns an ErrAlreadySigned error.
+func SignTransaction(transaction *Transaction, privateKey gocrypto.Signer) error {
+	return signTransaction(transaction, privateKey, false) // Sign transaction
+}
+
+// SignTransactionCompact signs the signing hash of a given transaction via the signature scheme of the given private key, like
+// SignTransaction, but sets the transaction signature to a compact signature (see SignMessageCompact()).
+// Returns a crypto.ErrUnrecoverableSignatureScheme error if public keys of the key's scheme cannot be recovered from signatures.
+func SignTransactionCompact(transaction *Transaction, privateKey gocrypto.Signer) error {
+	return signTransaction(transaction, privateKey, true) // Sign transaction
+}
+
+// SignMessage signs a given message hash via the signature scheme of a given private key, and returns a new signature.
+// Returns a crypto.ErrUnsupportedKey error if the key does not belong to any registered signature scheme.
+func SignMessage(messageHash common.Hash, privateKey gocrypto.Signer) (*Signature, error) {
+	return signMessage(messageHash, privateKey, false) // Sign message
+}
+
+// SignMessageCompact signs a given message hash via the signature scheme of a given private key, and returns a new compact
+// signature: a signature carrying neither its public key nor the message hash, but the recovery ID from which the public key is
+// recovered on verification.
+// Returns a crypto.ErrUnrecoverableSignatureScheme error if public keys of the key's scheme cannot be recovered from signatures
+// (e.g. Ed25519 keys).
+func SignMessageCompact(messageHash common.Hash, privateKey gocrypto.Signer) (*Signature, error) {
+	return signMessage(messageHash, privateKey, true) // Sign message
+}
+
+// IsCompact checks whether a given signature is compact (carries no public key).
+func (signature *Signature) IsCompact() bool {
+	return len(signature.MarshaledPublicKey) == 0 // Return is compact
+}
+
+// Verify checks that a given signature is a valid signature of a given message hash by the key of a given address, via the
+// signature's scheme (and the address derivation defined by that scheme). The public key of a compact signature is recovered
+// from the signature and message hash.
+// The signature's V value is not used: callers must recompute the message hash from the signed contents.
+// If no signature exists at the given memory address, or the signature's scheme is unknown, false is returned.
+func (signature *Signature) Verify(messageHash common.Hash, address *common.Address) bool {
+	if signature == nil || address == nil || signature.R == nil || signature.S == nil { // Check no existent signature
+		return false // No signature to verify
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(signature.Scheme) // Get signature algorithm
+	if err != nil {                                                  // Check for errors
+		return false // Unknown scheme
 	}
 
-	if transaction.Signature == nil { // Check no existing signature
-		r, s, err := ecdsa.Sign(rand.Reader, privateKey, transaction.Hash.Bytes()) // Sign via ECDSA
-		if err != nil {                                                            // Check for errors
-			return err // Return found error
+	marshaledPublicKey := signature.MarshaledPublicKey // Get public key
+
+	if signature.IsCompact() { // Check compact
+		recoverableAlgorithm, err := crypto.GetRecoverableSignatureAlgorithm(signature.Scheme) // Get recoverable signature algorithm
+		if err != nil {                                                                        // Check for errors
+			return false // Unrecoverable scheme
+		}
+
+		marshaledPublicKey, err = recoverableAlgorithm.RecoverPublicKey(messageHash.Bytes(), signature.R, signature.S, signature.RecoveryID) // Recover public key
+		if err != nil {                                                                                                                      // Check for errors
+			return false // Invalid
 		}
+	}
+
+	signer, err := algorithm.Address(marshaledPublicKey) // Derive signer address
+	if err != nil || *signer != *address {               // Check invalid public key
+		return false // Invalid
+	}
+
+	return algorithm.Verify(marshaledPublicKey, messageHash.Bytes(), signature.R, signature.S) // Verify signature of message hash
+}
 
-		(*transaction).Signature = &Signature{
-			MarshaledPublicKey: elliptic.Marshal(elliptic.P521(), privateKey.PublicKey.X, privateKey.PublicKey.Y), // Set marshaled public key
-			V:                  transaction.Hash.Bytes(),                                                          // Set hash
-			R:                  r,                                                                                 // Set R
-			S:                  s,                                                                                 // Set S
-		} // Set transaction signature
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
 
-		(*transaction).Hash = common.NewHash(nil) // Set hash to nil
+// signTransaction signs the signing hash of a given transaction, sets the transaction signature to the new (optionally compact)
+// signature, and recalculates the transaction hash.
+func signTransaction(transaction *Transaction, privateKey gocrypto.Signer, compact bool) error {
+	if transaction.IsSigned() { // Check existing signature
+		return ErrAlreadySigned // Return already signed error
+	}
 
-		(*transaction).Hash = crypto.Sha3(transaction.Bytes()) // Set transaction hash
+	signingHash := transaction.SigningHash() // Get signing hash
 
-		return nil // Return signature
+	signature, err := signMessage(signingHash, privateKey, compact) // Sign
+	if err != nil {                                                 // Check for errors
+		return err // Return found error
 	}
 
-	return ErrAlreadySigned // Return already signed error
+	(*transaction).Signature = signature // Set transaction signature
+
+	(*transaction).Hash = transaction.CalculateHash() // Set transaction hash
+
+	return nil // No error occurred, return nil
 }
 
-// SignMessage signs a given message hash via ecdsa, and returns a new signature
-func SignMessage(messageHash common.Hash, privateKey *ecdsa.PrivateKey) (*Signature, error) {
+// signMessage signs a given message hash via the signature scheme of a given private key, and returns a new (optionally compact) signature.
+func signMessage(messageHash common.Hash, privateKey gocrypto.Signer, compact bool) (*Signature, error) {
 	if messageHash.IsNil() { // Check nil hash
 		return nil, ErrNilHash // Return no hash error
 	}
 
-	r, s, err := ecdsa.Sign(rand.Reader, privateKey, messageHash.Bytes()) // Sign via ECDSA
-	if err != nil {                                                       // Check for errors
+	scheme, err := crypto.SignatureSchemeOfKey(privateKey) // Get signature scheme
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	if compact { // Check compact
+		recoverableAlgorithm, err := crypto.GetRecoverableSignatureAlgorithm(scheme) // Get recoverable signature algorithm
+		if err != nil {                                                              // Check for errors
+			return nil, err // Return found error
+		}
+
+		r, s, recoveryID, err := recoverableAlgorithm.SignRecoverable(privateKey, messageHash.Bytes()) // Sign
+		if err != nil {                                                                                // Check for errors
+			return nil, err // Return found error
+		}
+
+		return &Signature{
+			Scheme:     scheme,     // Set scheme
+			R:          r,          // Set R
+			S:          s,          // Set S
+			RecoveryID: recoveryID, // Set recovery ID
+		}, nil // Return compact signature
+	}
+
+	algorithm, err := crypto.GetSignatureAlgorithm(scheme) // Get signature algorithm
+	if err != nil {                                        // Check for errors
+		return nil, err // Return found error
+	}
+
+	marshaledPublicKey, err := algorithm.MarshalPublicKey(privateKey.Public()) // Marshal public key
+	if err != nil {                                                            // Check for errors
+		return nil, err // Return found error
+	}
+
+	r, s, err := algorithm.Sign(privateKey, messageHash.Bytes()) // Sign
+	if err != nil {                                              // Check for errors
 		return nil, err // Return found error
 	}
 
 	signature := &Signature{
-		V: messageHash.Bytes(), // Set hash
-		R: r,                   // Set R
-		S: s,                   // Set S
+		Scheme:             scheme,              // Set scheme
+		MarshaledPublicKey: marshaledPublicKey,  // Set marshaled public key
+		V:                  messageHash.Bytes(), // Set hash
+		R:                  r,                   // Set R
+		S:                  s,                   // Set S
 	} // Set transaction signature
 
 	return signature, nil // Return signature
 }
 
-// Verify checks that a given signature is valid, and returns whether or not the given signature is valid.
-// If no signature exists at the given memory address, false is returned.
-func (signature *Signature) Verify(address *common.Address) bool {
-	x, y := elliptic.Unmarshal(elliptic.P521(), signature.MarshaledPublicKey) // Unmarshal public key
-
-	publicKey := &ecdsa.PublicKey{
-		Curve: elliptic.P521(), // Set curve
-		X:     x,               // Set x
-		Y:     y,               // Set y
-	} // Recover public key
-
-	if signature == nil { // Check no existent signature
-		return false // No signature to verify
+// verifySignature checks that a given transaction has been signed by its sender, without consulting any signature verifier.
+// A transaction must carry either a single-key signature or multi-signature signatures, but not both.
+func (transaction *Transaction) verifySignature() bool {
+	if transaction.Signature == nil && transaction.Multisig != nil { // Check multi-signature transaction
+		return transaction.Multisig.Verify(transaction.SigningHash(), transaction.Sender) // Return signature validity
 	}
 
-	if *crypto.AddressFromPublicKey(publicKey) != *address { // Check invalid public key
+	if transaction.Signature == nil || transaction.Multisig != nil || transaction.Sender == nil { // Check no signature, both signatures or no sender
 		return false // Invalid
 	}
 
-	return ecdsa.Verify(publicKey, signature.V, signature.R, signature.S) // Verify signature contents
+	return transaction.Signature.Verify(transaction.SigningHash(), transaction.Sender) // Return signature validity
 }
 
-/* END EXPORTED METHODS */
+/* END INTERNAL METHODS */
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/polaris-project/go-polaris/crypto"
 )
@@ -30,7 +31,7 @@ func TestSignTransaction(t *testing.T) {
 		Signature:    nil,              // Set signature
 	} // Initialize transaction
 
-	transaction.Hash = crypto.Sha3(transaction.Bytes()) // Set hash
+	transaction.Hash = transaction.CalculateHash() // Set hash
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
 
@@ -51,15 +52,16 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 	transaction := &Transaction{
-		AccountNonce: 0,                // Set nonce
-		Sender:       nil,              // Set sender
-		Recipient:    nil,              // Set recipient
-		GasPrice:     big.NewInt(1000), // Set gas price
-		Payload:      []byte("test"),   // Set payload
-		Signature:    nil,              // Set signature
+		AccountNonce: 0,                                        // Set nonce
+		Amount:       big.NewInt(10),                           // Set amount
+		Sender:       crypto.AddressFromPrivateKey(privateKey), // Set sender
+		Recipient:    nil,                                      // Set recipient
+		GasPrice:     big.NewInt(1000),                         // Set gas price
+		Payload:      []byte("test"),                           // Set payload
+		Signature:    nil,                                      // Set signature
 	} // Initialize transaction
 
-	transaction.Hash = crypto.Sha3(transaction.Bytes()) // Set hash
+	transaction.Hash = transaction.CalculateHash() // Set hash
 
 	err = SignTransaction(transaction, privateKey) // Sign transaction
 
@@ -67,9 +69,116 @@ func TestVerifySignature(t *testing.T) {
 		t.Fatal(err) // Panic
 	}
 
-	if !transaction.Signature.Verify(crypto.AddressFromPrivateKey(privateKey)) { // Check that signature is valid
+	if !transaction.VerifySignature() { // Check that signature is valid
 		t.Fatal("signature should be valid") // Panic
 	}
+
+	if transaction.Hash != transaction.CalculateHash() { // Check hash doesn't cover signature
+		t.Fatal("transaction hash should be recalculated after signing") // Panic
+	}
+
+	tampered := *transaction // Copy transaction
+
+	tampered.Amount = big.NewInt(1000) // Alter amount, keeping the signature (and its V value)
+
+	tampered.Hash = tampered.CalculateHash() // Recalculate hash
+
+	if tampered.VerifySignature() { // Check signature still valid
+		t.Fatal("signature should not be valid for a transaction with altered fields") // Panic
+	}
+}
+
+// TestVerifySignatureSchemes tests the functionality of the SignTransaction() and VerifySignature() methods with keys of each signature scheme.
+func TestVerifySignatureSchemes(t *testing.T) {
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Ed25519, crypto.Secp256k1} { // Iterate through schemes
+		privateKey, err := crypto.GenerateKey(scheme) // Generate private key
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		sender, err := crypto.AddressFromSigner(privateKey) // Derive sender address
+		if err != nil {                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transaction := NewTransaction(0, big.NewInt(10), sender, nil, nil, 1, big.NewInt(1000), []byte("test"), 1, time.Time{}) // Initialize transaction
+
+		if err = SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+			t.Fatal(err) // Panic
+		}
+
+		if transaction.Signature.Scheme != scheme || !transaction.VerifySignature() { // Check invalid signature
+			t.Fatalf("%s signature should be valid", scheme) // Panic
+		}
+
+		decoded, err := DecodeTransaction(transaction.Bytes()) // Decode transaction
+		if err != nil || !decoded.VerifySignature() {          // Check signature not preserved
+			t.Fatalf("decoded %s signature should be valid", scheme) // Panic
+		}
+
+		decoded.Signature.Scheme = (scheme + 1) % 3 // Claim another scheme
+
+		if decoded.VerifySignature() { // Check signature valid under another scheme
+			t.Fatalf("%s signature should not be valid under another scheme", scheme) // Panic
+		}
+	}
+}
+
+// TestSignTransactionCompact tests the functionality of the SignTransactionCompact() helper method, as well as compact signature verification.
+func TestSignTransactionCompact(t *testing.T) {
+	for _, scheme := range []crypto.SignatureScheme{crypto.P521, crypto.Secp256k1} { // Iterate through recoverable schemes
+		privateKey, err := crypto.GenerateKey(scheme) // Generate private key
+		if err != nil {                               // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		sender, err := crypto.AddressFromSigner(privateKey) // Derive sender address
+		if err != nil {                                     // Check for errors
+			t.Fatal(err) // Panic
+		}
+
+		transaction := NewTransaction(0, big.NewInt(10), sender, nil, nil, 1, big.NewInt(1000), []byte("test"), 1, time.Time{}) // Initialize transaction
+
+		fullTransaction := *transaction // Copy transaction
+
+		if err = SignTransactionCompact(transaction, privateKey); err != nil { // Sign transaction
+			t.Fatal(err) // Panic
+		}
+
+		if err = SignTransaction(&fullTransaction, privateKey); err != nil { // Sign copy with full signature
+			t.Fatal(err) // Panic
+		}
+
+		if !transaction.Signature.IsCompact() || len(transaction.Bytes()) >= len(fullTransaction.Bytes()) { // Check not compact
+			t.Fatalf("compact %s signature should not carry a public key", scheme) // Panic
+		}
+
+		decoded, err := DecodeTransaction(transaction.Bytes()) // Decode transaction
+		if err != nil || !decoded.VerifySignature() {          // Check invalid signature
+			t.Fatalf("decoded compact %s signature should be valid", scheme) // Panic
+		}
+
+		decoded.Signature.RecoveryID ^= 1 // Recover another key
+
+		if decoded.VerifySignature() { // Check signature valid for another key
+			t.Fatalf("compact %s signature should not be valid with another recovery ID", scheme) // Panic
+		}
+
+		transaction.Amount = big.NewInt(1000) // Change signed amount
+
+		if transaction.VerifySignature() { // Check forged transaction valid
+			t.Fatalf("compact %s signature should not be valid for altered contents", scheme) // Panic
+		}
+	}
+
+	privateKey, err := crypto.GenerateKey(crypto.Ed25519) // Generate Ed25519 private key
+	if err != nil {                                       // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	if _, err = SignMessageCompact(crypto.Sha3([]byte("test")), privateKey); err != crypto.ErrUnrecoverableSignatureScheme { // Sign with unrecoverable scheme
+		t.Fatalf("should have returned ErrUnrecoverableSignatureScheme; got %v", err) // Panic
+	}
 }
 
 /* END EXPORTED METHODS TESTS */
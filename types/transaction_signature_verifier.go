@@ -0,0 +1,160 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"bytes"
+	"runtime"
+	"sync"
+
+	"github.com/polaris-project/go-polaris/common"
+	"github.com/polaris-project/go-polaris/crypto"
+)
+
+// DefaultSignatureCacheSize is the default number of verified signatures remembered by a signature verifier.
+const DefaultSignatureCacheSize = 65536
+
+// DefaultSignatureVerifier is the signature verifier used to verify every transaction signature (see VerifySignature()).
+var DefaultSignatureVerifier = NewSignatureVerifier(runtime.NumCPU(), DefaultSignatureCacheSize)
+
+// SignatureVerifier verifies transaction signatures on a bounded number of goroutines, remembering the most recently verified
+// (signing hash, sender, signature) sets so that a transaction received more than once is only verified once.
+type SignatureVerifier struct {
+	Concurrency int `json:"concurrency"` // Maximum number of signatures verified at once
+
+	CacheSize int `json:"cache_size"` // Maximum number of verified signatures remembered
+
+	slots chan struct{} // Verification slots
+
+	verified map[common.Hash]bool // Verified signature keys
+
+	verifiedQueue []common.Hash // Verified signature keys, in order of verification (ring buffer)
+
+	nextEvicted int // Index of the next key to evict from the verified queue
+
+	lock sync.Mutex // Cache lock
+}
+
+/* BEGIN EXPORTED METHODS */
+
+// NewSignatureVerifier initializes a new signature verifier verifying at most concurrency signatures at once, and remembering at
+// most cacheSize verified signatures (a cache size of zero disables caching).
+func NewSignatureVerifier(concurrency int, cacheSize int) *SignatureVerifier {
+	if concurrency < 1 { // Check invalid concurrency
+		concurrency = 1 // Verify serially
+	}
+
+	if cacheSize < 0 { // Check invalid cache size
+		cacheSize = 0 // Disable cache
+	}
+
+	return &SignatureVerifier{
+		Concurrency:   concurrency,                      // Set concurrency
+		CacheSize:     cacheSize,                        // Set cache size
+		slots:         make(chan struct{}, concurrency), // Init verification slots
+		verified:      make(map[common.Hash]bool),       // Init verified signature set
+		verifiedQueue: make([]common.Hash, cacheSize),   // Init verified signature queue
+	} // Return initialized verifier
+}
+
+// VerifyTransaction checks that a given transaction has been signed by its sender, waiting for a free verification slot if
+// the verifier is already verifying its maximum number of signatures.
+func (verifier *SignatureVerifier) VerifyTransaction(transaction *Transaction) bool {
+	if transaction == nil || !transaction.IsSigned() || transaction.Sender == nil { // Check no signature or sender
+		return false // Invalid
+	}
+
+	key := signatureKey(transaction) // Get signature key
+
+	if verifier.isVerified(key) { // Check already verified
+		return true // Valid
+	}
+
+	verifier.slots <- struct{}{} // Acquire slot
+
+	valid := transaction.verifySignature() // Verify signature
+
+	<-verifier.slots // Release slot
+
+	if valid { // Check valid
+		verifier.addVerified(key) // Remember signature
+	}
+
+	return valid // Return signature validity
+}
+
+// VerifyTransactions checks that each of a given set of transactions has been signed by its sender, verifying as many signatures
+// at once as the verifier allows. Returns the validity of each transaction's signature.
+func (verifier *SignatureVerifier) VerifyTransactions(transactions []*Transaction) []bool {
+	valid := make([]bool, len(transactions)) // Init results
+
+	indexes := make(chan int) // Init tx index channel
+
+	var wg sync.WaitGroup // Init wait group
+
+	for i := 0; i < verifier.Concurrency; i++ { // Start workers
+		wg.Add(1) // Add worker
+
+		go func() {
+			defer wg.Done() // Done
+
+			for index := range indexes { // Receive tx indexes
+				valid[index] = verifier.VerifyTransaction(transactions[index]) // Verify signature
+			}
+		}() // Start worker
+	}
+
+	for i := range transactions { // Iterate through transactions
+		indexes <- i // Send tx index
+	}
+
+	close(indexes) // Stop workers
+
+	wg.Wait() // Wait for workers
+
+	return valid // Return results
+}
+
+/* END EXPORTED METHODS */
+
+/* BEGIN INTERNAL METHODS */
+
+// isVerified checks whether the signature with a given key has already been verified.
+func (verifier *SignatureVerifier) isVerified(key common.Hash) bool {
+	verifier.lock.Lock() // Lock
+
+	defer verifier.lock.Unlock() // Unlock
+
+	return verifier.verified[key] // Return verified
+}
+
+// addVerified remembers the signature with a given key as verified, evicting the earliest verified signature if the cache is full.
+func (verifier *SignatureVerifier) addVerified(key common.Hash) {
+	verifier.lock.Lock() // Lock
+
+	defer verifier.lock.Unlock() // Unlock
+
+	if verifier.CacheSize == 0 || verifier.verified[key] { // Check cache disabled or already remembered
+		return // Nothing to remember
+	}
+
+	delete(verifier.verified, verifier.verifiedQueue[verifier.nextEvicted]) // Evict earliest verified signature
+
+	verifier.verified[key] = true                                          // Remember signature
+	verifier.verifiedQueue[verifier.nextEvicted] = key                     // Add to queue
+	verifier.nextEvicted = (verifier.nextEvicted + 1) % verifier.CacheSize // Advance queue
+}
+
+// signatureKey calculates the cache key of a given transaction's signature, committing to the transaction's signing hash,
+// sender and signature (or multi-signature signatures).
+func signatureKey(transaction *Transaction) common.Hash {
+	buffer := bytes.NewBuffer(transaction.SigningHash().Bytes()) // Write signing hash
+
+	writeAddress(buffer, transaction.Sender)      // Write sender
+	writeSignature(buffer, transaction.Signature) // Write signature
+	writeMultisig(buffer, transaction.Multisig)   // Write multi-signature signatures
+
+	return crypto.Sha3(buffer.Bytes()) // Return key
+}
+
+/* END INTERNAL METHODS */
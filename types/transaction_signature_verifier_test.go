@@ -0,0 +1,73 @@
+// Package types provides core primitives for the operation
+// of the Polaris protocol.
+package types
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/polaris-project/go-polaris/common"
+)
+
+/* BEGIN EXPORTED METHODS TESTS */
+
+// TestVerifyTransactions tests the functionality of the VerifyTransactions() signature verifier method, as well as signature caching.
+func TestVerifyTransactions(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate ecdsa private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	var transactions []*Transaction // Init tx buffer
+
+	for i := 0; i < 3; i++ { // Make transactions
+		transactions = append(transactions, newTestBatchTransaction(t, privateKey, uint64(i), common.NewHash([]byte("parent")))) // Append transaction
+	}
+
+	forged := newTestBatchTransaction(t, privateKey, 3, common.NewHash([]byte("parent"))) // Initialize forged transaction
+
+	forged.Amount = big.NewInt(1000) // Change signed amount
+
+	otherSender := newTestBatchTransaction(t, privateKey, 4, common.NewHash([]byte("parent"))) // Initialize transaction claiming another sender
+
+	otherSender.Sender = common.NewAddress([]byte("other")) // Change sender
+
+	transactions = append(transactions, forged, otherSender, nil) // Append invalid transactions
+
+	verifier := NewSignatureVerifier(2, 2) // Initialize verifier
+
+	valid := verifier.VerifyTransactions(transactions) // Verify signatures
+
+	for i, expected := range []bool{true, true, true, false, false, false} { // Iterate through expected results
+		if valid[i] != expected { // Check invalid result
+			t.Fatalf("invalid signature validity for transaction %d; found %t, but wanted %t", i, valid[i], expected) // Panic
+		}
+	}
+
+	if len(verifier.verified) != 2 { // Check cache not bounded
+		t.Fatalf("verifier should remember at most 2 signatures; found %d", len(verifier.verified)) // Panic
+	}
+
+	if verifier.isVerified(signatureKey(forged)) || verifier.isVerified(signatureKey(otherSender)) { // Check invalid signature remembered
+		t.Fatal("verifier should not remember invalid signatures") // Panic
+	}
+
+	verifier.addVerified(signatureKey(transactions[0])) // Remember first signature
+
+	forged.Signature = transactions[0].Signature // Reuse remembered signature
+
+	if verifier.VerifyTransaction(forged) { // Check forged transaction verified via cache
+		t.Fatal("remembered signature should not verify a transaction with different contents") // Panic
+	}
+
+	if !verifier.isVerified(signatureKey(transactions[0])) { // Check first signature not remembered
+		t.Fatal("verifier should remember the most recently verified signature") // Panic
+	}
+}
+
+/* END EXPORTED METHODS TESTS */
@@ -8,6 +8,7 @@ import (
 	"crypto/rand"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/polaris-project/go-polaris/crypto"
 )
@@ -18,13 +19,15 @@ import (
 func TestNewTransactions(t *testing.T) {
 	transaction := NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(10),       // Amount
+		big.NewInt(10),         // Amount
 		nil,                    // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		1,                      // Gas limit
 		big.NewInt(1000),       // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Create a new transaction using the NewTransaction method
 
 	t.Log(transaction) // Log the initialized transaction
@@ -41,16 +44,18 @@ func TestCalculateTotalValue(t *testing.T) {
 
 	transaction := NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(5),        // Amount
+		big.NewInt(5),          // Amount
 		address,                // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		1000,                   // Gas limit
 		big.NewInt(1),          // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Initialize a new transaction
 
-	if transaction.CalculateTotalValue().Cmp(big.NewFloat(5+1000)) != 0 { // Check invalid value calculation
+	if transaction.CalculateTotalValue().Cmp(big.NewInt(5+1000)) != 0 { // Check invalid value calculation
 		t.Fatal("invalid total value calculation") // Panic
 	}
 }
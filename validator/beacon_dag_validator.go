@@ -6,10 +6,9 @@ package validator
 import (
 	"bytes"
 	"errors"
+	"time"
 
-	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
-	"github.com/polaris-project/go-polaris/crypto"
 	"github.com/polaris-project/go-polaris/types"
 )
 
@@ -22,12 +21,24 @@ var (
 	// ErrInvalidTransactionHash is an error definition representing a transaction hash of invalid value.
 	ErrInvalidTransactionHash = errors.New("transaction hash is invalid")
 
+	// ErrInvalidTransactionNetwork is an error definition representing a transaction signed for a different network.
+	ErrInvalidTransactionNetwork = errors.New("transaction network does not match the working network")
+
+	// ErrTransactionExpired is an error definition representing a transaction whose valid until time has passed.
+	ErrTransactionExpired = errors.New("transaction has expired")
+
 	// ErrInvalidTransactionTimestamp is an error definition representing a transaction timestamp of invalid value.
 	ErrInvalidTransactionTimestamp = errors.New("invalid transaction timestamp")
 
+	// ErrUnsupportedSignatureScheme is an error definition representing a transaction signed via a signature scheme not accepted by the working network.
+	ErrUnsupportedSignatureScheme = errors.New("transaction signature scheme is not accepted by the working network")
+
 	// ErrInvalidTransactionSignature is an error definition representing a transaction signature of invalid value.
 	ErrInvalidTransactionSignature = errors.New("invalid transaction signature")
 
+	// ErrInvalidTransactionAmount is an error definition representing a transaction amount or gas price of nil or negative value.
+	ErrInvalidTransactionAmount = errors.New("transaction amount or gas price is nil or negative")
+
 	// ErrInsufficientSenderBalance is an error definition representing a sender balance of insufficient value.
 	ErrInsufficientSenderBalance = errors.New("insufficient sender balance")
 
@@ -46,6 +57,8 @@ type BeaconDagValidator struct {
 	Config *config.DagConfig `json:"config"` // Config represents the beacon dag config
 
 	WorkingDag *types.Dag `json:"dag"` // Working validator dag
+
+	Historical bool `json:"historical"` // Whether or not the validator replays historical transactions (accepting transactions that have expired since their creation)
 }
 
 /* BEGIN EXPORTED METHODS */
@@ -58,6 +71,17 @@ func NewBeaconDagValidator(config *config.DagConfig, workingDag *types.Dag) *Bea
 	}
 }
 
+// NewHistoricalBeaconDagValidator initializes a new beacon dag validator with a given config and working dag for replaying
+// historical transactions already accepted by the network (i.e. when importing a dag archive, or syncing the dag from peers):
+// transactions that have expired since their creation are accepted.
+func NewHistoricalBeaconDagValidator(config *config.DagConfig, workingDag *types.Dag) *BeaconDagValidator {
+	validator := NewBeaconDagValidator(config, workingDag) // Initialize validator
+
+	validator.Historical = true // Set historical
+
+	return validator // Return validator
+}
+
 // ValidateTransaction validates the given transaction, transaction via the standard beacon dag validator.
 // Each validation issue is returned as an error.
 func (validator *BeaconDagValidator) ValidateTransaction(transaction *types.Transaction) error {
@@ -65,14 +89,30 @@ func (validator *BeaconDagValidator) ValidateTransaction(transaction *types.Tran
 		return ErrInvalidTransactionHash // Invalid hash
 	}
 
+	if !validator.ValidateTransactionNetwork(transaction) { // Check invalid network
+		return ErrInvalidTransactionNetwork // Invalid network
+	}
+
+	if !validator.ValidateTransactionExpiry(transaction) { // Check expired
+		return ErrTransactionExpired // Expired
+	}
+
 	if !validator.ValidateTransactionTimestamp(transaction) { // Check invalid timestamp
 		return ErrInvalidTransactionTimestamp // Invalid timestamp
 	}
 
+	if !validator.ValidateTransactionSignatureScheme(transaction) { // Check unsupported signature scheme
+		return ErrUnsupportedSignatureScheme // Unsupported signature scheme
+	}
+
 	if !validator.ValidateTransactionSignature(transaction) { // Check invalid signature
 		return ErrInvalidTransactionSignature // Invalid signature
 	}
 
+	if !validator.ValidateTransactionAmounts(transaction) { // Check invalid amounts
+		return ErrInvalidTransactionAmount // Invalid amounts
+	}
+
 	if !validator.ValidateTransactionSenderBalance(transaction) { // Check invalid value
 		return ErrInsufficientSenderBalance // Invalid value
 	}
@@ -98,13 +138,24 @@ func (validator *BeaconDagValidator) ValidateTransactionHash(transaction *types.
 		return false // No valid hash
 	}
 
-	unsignedTx := *transaction // Get unsigned
+	return transaction.Hash == transaction.CalculateHash() // Return hashes equivalent
+}
 
-	unsignedTx.Hash = common.NewHash(nil) // Set hash to nil
+// ValidateTransactionNetwork checks that a given transaction was created for the validator's working network,
+// preventing transactions signed for one network from being replayed on another.
+func (validator *BeaconDagValidator) ValidateTransactionNetwork(transaction *types.Transaction) bool {
+	return transaction.Network == validator.Config.Network // Return network valid
+}
 
-	unsignedTx.Signature = transaction.Signature // Reset signature
+// ValidateTransactionExpiry checks that a given transaction has not expired, and that it was not already expired when it was created.
+// Transactions without a valid until time never expire. Historical validators (see NewHistoricalBeaconDagValidator), used when
+// importing or syncing a dag, only check that the transaction was not created expired.
+func (validator *BeaconDagValidator) ValidateTransactionExpiry(transaction *types.Transaction) bool {
+	if transaction.IsExpired(transaction.Timestamp) { // Check created expired
+		return false // Invalid
+	}
 
-	return bytes.Equal(transaction.Hash.Bytes(), crypto.Sha3(unsignedTx.Bytes()).Bytes()) // Return hashes equivalent
+	return validator.Historical || !transaction.IsExpired(time.Now()) // Return not expired
 }
 
 // ValidateTransactionTimestamp validates the given transaction's timestamp against that of its parents.
@@ -125,24 +176,45 @@ func (validator *BeaconDagValidator) ValidateTransactionTimestamp(transaction *t
 	return true // Valid timestamp
 }
 
-// ValidateTransactionSignature validates the given transaction's signature against the transaction sender's public key.
+// ValidateTransactionSignatureScheme checks that a given transaction is signed via a signature scheme accepted by the validator's
+// working network (see config.DagConfig.SignatureSchemes). A transaction sent from a multi-signature address is only valid if
+// every key of its policy belongs to an accepted scheme. Unsigned transactions are left to ValidateTransactionSignature.
+func (validator *BeaconDagValidator) ValidateTransactionSignatureScheme(transaction *types.Transaction) bool {
+	if transaction.Multisig != nil && transaction.Multisig.Policy != nil { // Check multi-signature transaction
+		for _, key := range transaction.Multisig.Policy.Keys { // Iterate through policy keys
+			if !validator.Config.AcceptsSignatureScheme(key.Scheme) { // Check scheme not accepted
+				return false // Invalid
+			}
+		}
+	}
+
+	return transaction.Signature == nil || validator.Config.AcceptsSignatureScheme(transaction.Signature.Scheme) // Return scheme accepted
+}
+
+// ValidateTransactionSignature validates the given transaction's signature of its signing preimage against the transaction sender's public key.
 // If the transaction's signature is nil, false is returned.
 func (validator *BeaconDagValidator) ValidateTransactionSignature(transaction *types.Transaction) bool {
-	if transaction.Signature == nil { // Check has no signature
-		return false // Nil signature
-	}
+	return transaction.VerifySignature() // Return signature validity
+}
 
-	return transaction.Signature.Verify(transaction.Sender) // Return signature validity
+// ValidateTransactionAmounts checks that a given transaction's amount and gas price are set, and are not negative.
+func (validator *BeaconDagValidator) ValidateTransactionAmounts(transaction *types.Transaction) bool {
+	return transaction.HasValidAmounts() // Return amounts valid
 }
 
 // ValidateTransactionSenderBalance checks that a given transaction's sender has a balance greater than or equal to the transaction's total value (including gas costs).
+// Transactions with invalid amounts (see ValidateTransactionAmounts) are never covered by the sender's balance.
 func (validator *BeaconDagValidator) ValidateTransactionSenderBalance(transaction *types.Transaction) bool {
+	if !transaction.HasValidAmounts() { // Check invalid amounts
+		return false // Invalid
+	}
+
 	balance, err := validator.WorkingDag.CalculateAddressBalance(transaction.Sender) // Calculate balance
 	if err != nil {                                                                  // Check for errors
 		return false // Invalid
 	}
 
-	return balance.Cmp(transaction.CalculateTotalValue()) == 0 || balance.Cmp(transaction.CalculateTotalValue()) == 1 // Return sender balance adequate
+	return balance.Cmp(transaction.CalculateTotalValue()) >= 0 // Return sender balance adequate
 }
 
 // ValidateTransactionIsNotDuplicate checks that a given transaction does not already exist in the working dag.
@@ -185,32 +257,12 @@ func (validator *BeaconDagValidator) ValidateTransactionDepth(transaction *types
 
 // ValidateTransactionNonce checks that a given transaction's nonce is equivalent to the sending account's last nonce + 1.
 func (validator *BeaconDagValidator) ValidateTransactionNonce(transaction *types.Transaction) bool {
-	senderTransactions, err := validator.WorkingDag.GetTransactionsBySender(transaction.Sender) // Get sender txs
-	if err != nil {                                                                             // Check for errors
+	nextNonce, err := validator.WorkingDag.GetNextNonce(transaction.Sender) // Get next sender nonce
+	if err != nil {                                                         // Check for errors
 		return false // Invalid
 	}
 
-	if len(senderTransactions) == 0 { // Check is genesis
-		if transaction.AccountNonce != 0 { // Check nonce is not 0
-			return false // Invalid nonce
-		}
-
-		return true // Valid nonce
-	}
-
-	lastNonce := uint64(0) // Init nonce buffer
-
-	for _, currentTransaction := range senderTransactions { // Iterate through sender txs
-		if currentTransaction.AccountNonce > lastNonce { // Check greater than last nonce
-			lastNonce = currentTransaction.AccountNonce // Set last nonce
-		}
-	}
-
-	if transaction.AccountNonce != lastNonce+1 { // Check invalid nonce
-		return false // Invalid nonce
-	}
-
-	return true // Valid nonce
+	return transaction.AccountNonce == nextNonce // Return nonce valid
 }
 
 // ValidationProtocol fetches the current validator's validation protocol.
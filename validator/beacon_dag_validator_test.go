@@ -4,17 +4,21 @@
 package validator
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"encoding/hex"
+	"io/ioutil"
 	"math/big"
 	"os"
-	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/polaris-project/go-polaris/common"
 	"github.com/polaris-project/go-polaris/config"
 	"github.com/polaris-project/go-polaris/crypto"
+	"github.com/polaris-project/go-polaris/storage"
 	"github.com/polaris-project/go-polaris/types"
 )
 
@@ -22,37 +26,37 @@ import (
 
 // TestNewBeaconDagValidator tests the functionality of the NewBeaconDagValidator() helper method.
 func TestNewBeaconDagValidator(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	validator := NewBeaconDagValidator(dagConfig, dag) // Initialize validator
 
 	if validator == nil { // Check validator is nil
 		t.Fatal("validator should not be nil") // Panic
 	}
-
-	types.WorkingDagDB.Close() // Close dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 // TestValidateTransaction tests the functionality of the ValidateTransaction() helper method.
 func TestValidateTransaction(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
 	if err != nil {                                                    // Check for errors
 		t.Fatal(err) // Panic
@@ -62,13 +66,15 @@ func TestValidateTransaction(t *testing.T) {
 
 	transaction := types.NewTransaction(
 		0,                      // Nonce
-		big.NewFloat(0),        // Amount
+		big.NewInt(0),          // Amount
 		address,                // Sender
 		nil,                    // Recipient
 		nil,                    // Parents
 		0,                      // Gas limit
 		big.NewInt(0),          // Gas price
 		[]byte("test payload"), // Payload
+		1,                      // Network
+		time.Time{},            // Valid until
 	) // Initialize a new transaction
 
 	err = types.SignTransaction(transaction, privateKey) // Sign transaction
@@ -95,24 +101,28 @@ func TestValidateTransaction(t *testing.T) {
 
 	child := types.NewTransaction(
 		1,                               // Nonce
-		big.NewFloat(0),                 // Amount
+		big.NewInt(0),                   // Amount
 		address,                         // Sender
 		nil,                             // Recipient
 		[]common.Hash{transaction.Hash}, // Parents
 		0,                               // Gas limit
 		big.NewInt(0),                   // Gas price
 		[]byte("test payload"),          // Payload
+		1,                               // Network
+		time.Time{},                     // Valid until
 	) // Create child transaction
 
 	sibling := types.NewTransaction(
 		2,                               // Nonce
-		big.NewFloat(0),                 // Amount
+		big.NewInt(0),                   // Amount
 		address,                         // Sender
 		nil,                             // Recipient
 		[]common.Hash{transaction.Hash}, // Parents
 		0,                               // Gas limit
 		big.NewInt(0),                   // Gas price
 		[]byte("test payload"),          // Payload
+		1,                               // Network
+		time.Time{},                     // Valid until
 	) // Create child transaction
 
 	err = types.SignTransaction(child, privateKey) // Sign transaction
@@ -140,22 +150,235 @@ func TestValidateTransaction(t *testing.T) {
 	if err := validator.ValidateTransaction(sibling); err != nil { // Validate
 		t.Fatalf("tx should be valid; got %s error", err.Error()) // Panic
 	}
+}
+
+// TestValidateTransactionNetwork tests the functionality of the ValidateTransactionNetwork() helper method.
+func TestValidateTransactionNetwork(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	validator := NewBeaconDagValidator(config.NewDagConfig(nil, "test_network", 1), nil) // Initialize validator
+
+	if !validator.ValidateTransactionNetwork(&types.Transaction{Network: 1}) { // Check valid network rejected
+		t.Fatal("transaction for the working network should be valid") // Panic
+	}
 
-	types.WorkingDagDB.Close() // Close dag db
+	if validator.ValidateTransactionNetwork(&types.Transaction{Network: 2}) { // Check invalid network accepted
+		t.Fatal("transaction for another network should be invalid") // Panic
+	}
+}
 
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+// TestValidateTransactionExpiry tests the functionality of the ValidateTransactionExpiry() helper method.
+func TestValidateTransactionExpiry(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	validator := NewBeaconDagValidator(config.NewDagConfig(nil, "test_network", 1), nil)                     // Initialize validator
+	historicalValidator := NewHistoricalBeaconDagValidator(config.NewDagConfig(nil, "test_network", 1), nil) // Initialize historical validator
+
+	now := time.Now() // Get current time
+
+	for _, test := range []struct {
+		timestamp       time.Time // Transaction timestamp
+		validUntil      time.Time // Transaction valid until time
+		valid           bool      // Whether or not the transaction should be valid
+		historicalValid bool      // Whether or not the transaction should be valid when replaying historical transactions
+	}{
+		{now, time.Time{}, true, true},                            // No expiry
+		{now, now.Add(time.Hour), true, true},                     // Not yet expired
+		{now.Add(-time.Hour), now.Add(-time.Minute), false, true}, // Expired since creation
+		{now, now.Add(-time.Minute), false, false},                // Created expired
+	} { // Iterate through tests
+		transaction := &types.Transaction{Timestamp: test.timestamp, ValidUntil: test.validUntil} // Initialize transaction
+
+		if valid := validator.ValidateTransactionExpiry(transaction); valid != test.valid { // Check invalid expiry validation
+			t.Fatalf("transaction valid until %s should have validity %t", test.validUntil, test.valid) // Panic
+		}
+
+		if valid := historicalValidator.ValidateTransactionExpiry(transaction); valid != test.historicalValid { // Check invalid historical expiry validation
+			t.Fatalf("transaction valid until %s should have historical validity %t", test.validUntil, test.historicalValid) // Panic
+		}
+	}
+}
+
+// TestImportExpiredTransaction tests that a dag containing a transaction that has expired since it was added can still be imported
+// via the historical beacon dag validator, but not added via the beacon dag validator.
+func TestImportExpiredTransaction(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := crypto.AddressFromPrivateKey(privateKey) // Generate address
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(address.Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	transaction := types.NewTransaction(
+		0,                                      // Nonce
+		big.NewInt(10),                         // Amount
+		address,                                // Sender
+		common.NewAddress([]byte("recipient")), // Recipient
+		[]common.Hash{genesisTransactions[len(genesisTransactions)-1].Hash}, // Parents
+		1,                                   // Gas limit
+		big.NewInt(1),                       // Gas price
+		[]byte("test payload"),              // Payload
+		1,                                   // Network
+		time.Now().Add(50*time.Millisecond), // Valid until
+	) // Initialize transaction expiring shortly
+
+	if err = types.SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err = NewBeaconDagValidator(dagConfig, dag).ValidateTransaction(transaction); err != nil { // Validate transaction
+		t.Fatal(err) // Panic
+	}
+
+	if err = dag.AddTransaction(transaction); err != nil { // Add transaction
+		t.Fatal(err) // Panic
+	}
+
+	var archive bytes.Buffer // Init archive buffer
+
+	if err = dag.Export(&archive); err != nil { // Export dag
+		t.Fatal(err) // Panic
+	}
+
+	time.Sleep(time.Until(transaction.ValidUntil.Add(time.Millisecond))) // Wait for transaction to expire
+
+	if err = NewBeaconDagValidator(dagConfig, dag).ValidateTransaction(transaction); err != ErrTransactionExpired { // Validate expired transaction
+		t.Fatalf("expected %v, got %v", ErrTransactionExpired, err) // Panic
+	}
+
+	dbDir, err := ioutil.TempDir("", "polaris_test") // Create temporary db dir
+	if err != nil {                                  // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer os.RemoveAll(dbDir) // Remove temporary db dir
+
+	importedDag, err := types.ImportDag(&archive, dbDir, func(importedDag *types.Dag) types.TransactionValidator {
+		return NewHistoricalBeaconDagValidator(importedDag.DagConfig, importedDag) // Validate via historical beacon dag validator
+	}) // Import dag
+	if err != nil { // Check for errors
+		t.Fatalf("dag with an expired transaction should be importable; got %s", err.Error()) // Panic
+	}
+
+	defer importedDag.Close() // Close imported dag
+
+	if _, err = importedDag.GetTransactionByHash(transaction.Hash); err != nil { // Check transaction not imported
+		t.Fatal(err) // Panic
+	}
+}
+
+// TestValidateTransactionAmounts tests that the ValidateTransaction() helper method rejects transactions with a nil or negative amount
+// or gas price.
+func TestValidateTransactionAmounts(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader) // Generate private key
+	if err != nil {                                                    // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	address := crypto.AddressFromPrivateKey(privateKey) // Generate address
+
+	dagConfig := config.NewDagConfig(map[string]*big.Int{hex.EncodeToString(address.Bytes()): big.NewInt(100)}, "test_network", 1) // Initialize new dag config with test alloc
+
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	defer dag.Close() // Close dag
+
+	genesisTransactions, err := dag.MakeGenesis() // Make genesis
+	if err != nil {                               // Check for errors
+		t.Fatal(err) // Panic
+	}
+
+	for _, test := range []struct {
+		amount, gasPrice *big.Int // Transaction amount and gas price
+	}{
+		{big.NewInt(-50), big.NewInt(1)}, // Negative amount
+		{big.NewInt(1), big.NewInt(-50)}, // Negative gas price
+		{nil, big.NewInt(1)},             // No amount
+		{big.NewInt(1), nil},             // No gas price
+	} { // Iterate through tests
+		transaction := types.NewTransaction(
+			0,                                      // Nonce
+			test.amount,                            // Amount
+			address,                                // Sender
+			common.NewAddress([]byte("recipient")), // Recipient
+			[]common.Hash{genesisTransactions[len(genesisTransactions)-1].Hash}, // Parents
+			1,                      // Gas limit
+			test.gasPrice,          // Gas price
+			[]byte("test payload"), // Payload
+			1,                      // Network
+			time.Time{},            // Valid until
+		) // Initialize transaction
+
+		if err = types.SignTransaction(transaction, privateKey); err != nil { // Sign transaction
+			t.Fatal(err) // Panic
+		}
+
+		transaction = types.TransactionFromBytes(transaction.Bytes()) // Round trip transaction through its encoding
+
+		if err = NewBeaconDagValidator(dagConfig, dag).ValidateTransaction(transaction); err != ErrInvalidTransactionAmount { // Validate transaction
+			t.Fatalf("amount %v and gas price %v: expected %v, got %v", test.amount, test.gasPrice, ErrInvalidTransactionAmount, err) // Panic
+		}
+	}
+}
+
+// TestValidateTransactionSignatureScheme tests the functionality of the ValidateTransactionSignatureScheme() helper method.
+func TestValidateTransactionSignatureScheme(t *testing.T) {
+	t.Parallel() // Run in parallel
+
+	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config
+
+	dagConfig.SignatureSchemes = []crypto.SignatureScheme{crypto.Ed25519, crypto.Secp256k1} // Accept ed25519 and secp256k1 signatures
+
+	validator := NewBeaconDagValidator(dagConfig, nil) // Initialize validator
+
+	if !validator.ValidateTransactionSignatureScheme(&types.Transaction{Signature: &types.Signature{Scheme: crypto.Ed25519}}) { // Check accepted scheme rejected
+		t.Fatal("transaction signed via an accepted scheme should be valid") // Panic
+	}
+
+	if validator.ValidateTransactionSignatureScheme(&types.Transaction{Signature: &types.Signature{Scheme: crypto.P521}}) { // Check unaccepted scheme accepted
+		t.Fatal("transaction signed via a scheme not accepted by the network should be invalid") // Panic
+	}
+
+	multisig := &types.MultisigSignature{Policy: &crypto.MultisigPolicy{Threshold: 1, Keys: []crypto.MultisigKey{{Scheme: crypto.Ed25519}, {Scheme: crypto.P521}}}} // Initialize multi-signature signatures with a P-521 key
+
+	if validator.ValidateTransactionSignatureScheme(&types.Transaction{Multisig: multisig}) { // Check unaccepted policy key scheme accepted
+		t.Fatal("transaction sent from a multi-signature address with a key of a scheme not accepted by the network should be invalid") // Panic
+	}
 }
 
 func TestBeaconDagValidationProtocol(t *testing.T) {
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
+	t.Parallel() // Run in parallel
 
 	dagConfig := config.NewDagConfig(nil, "test_network", 1) // Initialize new dag config with test genesis file.
 
-	dag, err := types.NewDag(dagConfig) // Initialize dag with dag config
-	if err != nil {                     // Check for errors
+	dag, err := types.NewDagWithStorage(dagConfig, storage.NewMemoryStorage(), "") // Initialize in-memory dag with dag config
+	if err != nil {                                                                // Check for errors
 		t.Fatal(err) // Panic
 	}
 
+	defer dag.Close() // Close dag
+
 	validator := NewBeaconDagValidator(dagConfig, dag) // Initialize validator
 
 	if validator == nil { // Check validator is nil
@@ -165,10 +388,6 @@ func TestBeaconDagValidationProtocol(t *testing.T) {
 	if protocol := validator.ValidationProtocol(); protocol != BeaconDagValidatorValidationProtocol { // Check invalid validation protocol
 		t.Fatalf("invalid validation protocol: %s", protocol) // Log invalid validation protocol
 	}
-
-	types.WorkingDagDB.Close() // Close dag db
-
-	os.RemoveAll(filepath.FromSlash("data/db/test_network.db")) // Remove existing db
 }
 
 /* END EXPORTED METHODS TESTS */
@@ -14,10 +14,18 @@ type Validator interface {
 
 	ValidateTransactionHash(transaction *types.Transaction) bool // Validate a given transaction's hash
 
+	ValidateTransactionNetwork(transaction *types.Transaction) bool // Validate that a given transaction was created for the working network
+
+	ValidateTransactionExpiry(transaction *types.Transaction) bool // Validate that a given transaction has not expired
+
 	ValidateTransactionTimestamp(transaction *types.Transaction) bool // Validate a given transaction's timestamp
 
+	ValidateTransactionSignatureScheme(transaction *types.Transaction) bool // Validate that a given transaction's signature scheme is accepted by the working network
+
 	ValidateTransactionSignature(transaction *types.Transaction) bool // Validate a given transaction's signature
 
+	ValidateTransactionAmounts(transaction *types.Transaction) bool // Validate that a given transaction's amount and gas price are set and not negative
+
 	ValidateTransactionSenderBalance(transaction *types.Transaction) bool // Validate a given transaction's sender has
 
 	ValidateTransactionIsNotDuplicate(transaction *types.Transaction) bool // Validate that a given transaction does not already exist in the working dag